@@ -0,0 +1,49 @@
+// migrate-payment-notifications 归档早期版本重复保存的支付通知, 并给 payment_notifications 创建唯一索引.
+// 重复的支付通知复制到 payment_notifications_archive 后删除, 并计入平台订单收到的重复支付通知次数.
+//
+// 用法:
+//
+//	migrate-payment-notifications -conf ./etc/wechat-payment-callback-service-prod.json
+//
+// NOTE: 一次性迁移, 在服务启动日志出现唯一索引创建失败时执行, 执行期间可以不停服.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+)
+
+var (
+	_ConfigFile = flag.String("conf", "./etc/wechat-payment-callback-service-dev.json", "config file path")
+)
+
+func main() {
+	flag.Parse()
+	config.LoadConfigFileOrPanic(*_ConfigFile)
+	logger.SetGlobalLogger(config.GetConfig())
+
+	dao.InitConnPool(&(config.GetConfig().ServiceInternalConfig.Storage))
+	defer dao.CloseConnPool()
+
+	ctx := context.Background()
+	for _, field := range []string{"notify_id", "resource_transaction_id"} {
+		archived, err := dao.GetConnPool().ArchiveDuplicatePaymentNotifications(ctx, field)
+		if err != nil {
+			// 可以使用相同参数重新执行
+			fmt.Fprintf(os.Stderr, "Failed to archive duplicate %s (archived:%d): %v\n", field, archived, err)
+			os.Exit(1)
+		}
+		fmt.Printf("archived %d duplicate %s\n", archived, field)
+	}
+	if err := dao.GetConnPool().CreatePaymentNotificationUniqueIndexes(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create unique indexes: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("created unique indexes")
+}
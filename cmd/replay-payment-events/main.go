@@ -0,0 +1,90 @@
+// replay-payment-events 调用 ReplayPaymentEvents 接口, 按平台订单交易ID列表或创建时间窗口重放支付事件.
+//
+// 用法:
+//
+//	replay-payment-events -sink redis -target payment_events.replay -trade_ids S1...,S2...
+//	replay-payment-events -sink file -target 20240101.jsonl -begin 1704038400 -end 1704124800
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+var (
+	_Addr     = flag.String("addr", "localhost:16887", "grpc endpoint of wechat-payment-callback-service")
+	_TradeIds = flag.String("trade_ids", "", "comma-separated trade ids, exclusive with -begin/-end")
+	_Begin    = flag.Int64("begin", 0, "create time window begin in unix seconds (inclusive)")
+	_End      = flag.Int64("end", 0, "create time window end in unix seconds (exclusive)")
+	_AppId    = flag.String("app_id", "", "app id filter for create time window")
+	_Sink     = flag.String("sink", "", "replay sink, webhook/redis/file")
+	_Target   = flag.String("target", "", "webhook url, redis stream or file name under replay file_dir")
+	_PageSize = flag.Int("page_size", 100, "platform orders replayed per request")
+	_Timeout  = flag.Duration("timeout", time.Minute, "timeout per request")
+)
+
+func main() {
+	flag.Parse()
+
+	conn, err := grpc.Dial(*_Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to %s: %v\n", *_Addr, err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+	client := proto_gens.NewWechatPaymentCallbackServiceClient(conn)
+
+	req := &proto_gens.ReplayPaymentEventsRequest{
+		Sink:     *_Sink,
+		Target:   *_Target,
+		PageSize: int32(*_PageSize),
+	}
+	if len(*_TradeIds) > 0 {
+		req.TradeIds = strings.Split(*_TradeIds, ",")
+	} else {
+		req.CreateTimeBegin = *_Begin
+		req.CreateTimeEnd = *_End
+		req.AppId = *_AppId
+	}
+
+	replayed, skipped := 0, 0
+	for {
+		resp, err := replay(client, req)
+		if err != nil {
+			// 重放失败时可使用相同参数及最后一次输出的cursor继续重放
+			fmt.Fprintf(os.Stderr, "Failed to replay payment-events (cursor:%q): %v\n", req.Cursor, err)
+			os.Exit(1)
+		}
+		replayed += int(resp.Replayed)
+		skipped += len(resp.SkippedTradeIds)
+		for _, tradeId := range resp.SkippedTradeIds {
+			fmt.Printf("skipped trade_id:%s\n", tradeId)
+		}
+		fmt.Printf("replayed:%d, skipped:%d, next_cursor:%q\n", replayed, skipped, resp.NextCursor)
+		if len(resp.NextCursor) == 0 {
+			break
+		}
+		req.Cursor = resp.NextCursor
+	}
+}
+
+func replay(client proto_gens.WechatPaymentCallbackServiceClient, req *proto_gens.ReplayPaymentEventsRequest) (
+	*proto_gens.ReplayPaymentEventsResponse, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), *_Timeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, common.ReqHeaderKeyRequestId, uuid.New().String())
+	return client.ReplayPaymentEvents(ctx, req)
+}
@@ -39,6 +39,10 @@ func main() {
 	defer wg.Wait()
 	wg.Add(1)
 	go setupGrpcService(ctx, wg, stopCh)
+	wg.Add(1)
+	go setupHttpService(ctx, wg, stopCh)
+	wg.Add(1)
+	go setupBackgroundJobs(ctx, wg, stopCh)
 
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
@@ -52,6 +52,9 @@ func setupGrpcService(_ context.Context, wg *sync.WaitGroup, stopCh chan struct{
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			interceptors.RecoverPanicAndReportLatencyUnaryInterceptor,
 		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			interceptors.RecoverPanicAndReportLatencyStreamInterceptor,
+		)),
 	}
 	// Create a gRPC server.
 	grpcServer := grpc.NewServer(opts...)
@@ -107,3 +110,11 @@ func setupHttpService(ctx context.Context, wg *sync.WaitGroup, stopCh chan struc
 	<-stopCh
 	logger.GetGlobalLogger().Warning("Stopped http service.")
 }
+
+func setupBackgroundJobs(_ context.Context, wg *sync.WaitGroup, stopCh chan struct{}) {
+	defer wg.Done()
+
+	logger.GetGlobalLogger().Info("Background jobs started.")
+	service.GetWechatPaymentCallbackServiceImpl().RunBackgroundJobs(stopCh)
+	logger.GetGlobalLogger().Warning("Stopped background jobs.")
+}
@@ -33,8 +33,9 @@ type Config struct {
 }
 
 type SupportedApp struct {
-	AppID     string `json:"app_id"`
-	AppSecret string `json:"app_secret"`
+	AppID                    string `json:"app_id"`
+	AppSecret                string `json:"app_secret"`
+	FulfillmentOverduePolicy string `json:"fulfillment_overdue_policy"` // alert/refund, 履约超时后告警或自动退款, 默认alert
 }
 
 type Storage struct {
@@ -54,16 +55,78 @@ type Cache struct {
 	ConnTimeout int    `json:"conn_timeout"`
 }
 
+type Job struct {
+	Enable           bool  `json:"enable"`
+	IntervalInSecond int64 `json:"interval_in_second"`
+	BatchSize        int64 `json:"batch_size"`
+	DelayInSecond    int64 `json:"delay_in_second"`
+}
+
+type TradeIdGenerator struct {
+	Backend string `json:"backend"` // snowflake/redis, 默认snowflake
+	Secret  string `json:"secret"`  // 交易ID校验码密钥, 必须配置且不能与商户APIv3密钥相同
+}
+
+type Webhook struct {
+	Name            string `json:"name"`
+	Url             string `json:"url"`
+	Secret          string `json:"secret"` // 请求体HMAC-SHA256签名密钥
+	TimeoutInSecond int64  `json:"timeout_in_second"`
+}
+
+type Outbox struct {
+	Enable             bool      `json:"enable"` // 需要MongoDB以副本集方式部署以支持事务
+	MaxAttempts        int       `json:"max_attempts"`
+	BackoffInSecond    int64     `json:"backoff_in_second"`
+	MaxBackoffInSecond int64     `json:"max_backoff_in_second"`
+	Webhooks           []Webhook `json:"webhooks"`
+	Dispatcher         Job       `json:"dispatcher"`
+}
+
+type EventPublisherRedis struct {
+	Stream string `json:"stream"`
+	MaxLen int64  `json:"max_len"` // 近似裁剪的流长度上限, 为0时不裁剪
+}
+
+type EventPublisherKafka struct {
+	Brokers         []string `json:"brokers"`
+	Topic           string   `json:"topic"`
+	TimeoutInSecond int64    `json:"timeout_in_second"`
+}
+
+type EventPublisher struct {
+	Backend string              `json:"backend"` // redis/kafka, 为空时不发布支付事件, 需要同时开启outbox及其投递任务
+	Redis   EventPublisherRedis `json:"redis"`
+	Kafka   EventPublisherKafka `json:"kafka"`
+}
+
+type Fulfillment struct {
+	Enable                bool  `json:"enable"`                   // 开启后平台订单支付成功时开始计算确认履约的期限
+	ConfirmWindowInSecond int64 `json:"confirm_window_in_second"` // 支付成功后确认履约的期限
+	Checker               Job   `json:"checker"`
+}
+
+type Replay struct {
+	FileDir string `json:"file_dir"` // 重放到文件时的输出目录
+}
+
 type ServiceInternalConfig struct {
-	MerchantID                  string         `json:"merchant_id"`
-	MerchantCertSerialNo        string         `json:"merchant_cert_serial_no"`
-	MerchantAPIv3Key            string         `json:"merchant_api_v3_key"`
-	MerchantAPIv3SecretCertPath string         `json:"merchant_api_v3_secret_cert_path"`
-	SupportedAppList            []SupportedApp `json:"supported_app_list"`
-	PaymentCallbackNotifyURL    string         `json:"payment_callback_notify_url"`
-	PaymentExpireTimeInMinute   int64          `json:"payment_expire_time_in_minute"`
-	Storage                     Storage        `json:"storage"`
-	Cache                       Cache          `json:"cache"`
+	MerchantID                  string           `json:"merchant_id"`
+	MerchantCertSerialNo        string           `json:"merchant_cert_serial_no"`
+	MerchantAPIv3Key            string           `json:"merchant_api_v3_key"`
+	MerchantAPIv3SecretCertPath string           `json:"merchant_api_v3_secret_cert_path"`
+	SupportedAppList            []SupportedApp   `json:"supported_app_list"`
+	PaymentCallbackNotifyURL    string           `json:"payment_callback_notify_url"`
+	PaymentExpireTimeInMinute   int64            `json:"payment_expire_time_in_minute"`
+	Storage                     Storage          `json:"storage"`
+	Cache                       Cache            `json:"cache"`
+	CloseExpiredOrdersJob       Job              `json:"close_expired_orders_job"`
+	CompensateOrdersJob         Job              `json:"compensate_orders_job"`
+	TradeIdGenerator            TradeIdGenerator `json:"trade_id_generator"`
+	Outbox                      Outbox           `json:"outbox"`
+	EventPublisher              EventPublisher   `json:"event_publisher"`
+	Replay                      Replay           `json:"replay"`
+	Fulfillment                 Fulfillment      `json:"fulfillment"`
 }
 
 func (conf *Config) UnmarshalJSON(data []byte) error {
@@ -103,6 +166,14 @@ func (conf *Config) UnmarshalJSON(data []byte) error {
 	if aux.ServiceInternalConfig.Cache.Pwd == "CACHE_PWD" {
 		conf.ServiceInternalConfig.Cache.Pwd = os.Getenv("CACHE_PWD")
 	}
+	if aux.ServiceInternalConfig.TradeIdGenerator.Secret == "TRADE_ID_GENERATOR_SECRET" {
+		conf.ServiceInternalConfig.TradeIdGenerator.Secret = os.Getenv("TRADE_ID_GENERATOR_SECRET")
+	}
+	for i := range aux.ServiceInternalConfig.Outbox.Webhooks {
+		if aux.ServiceInternalConfig.Outbox.Webhooks[i].Secret == "WEBHOOK_SECRET" {
+			conf.ServiceInternalConfig.Outbox.Webhooks[i].Secret = os.Getenv("WEBHOOK_SECRET")
+		}
+	}
 
 	return nil
 }
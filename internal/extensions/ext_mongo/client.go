@@ -22,6 +22,11 @@ type MongoClientConnPool struct {
 	timeout time.Duration
 	client  *mongo.Client
 
+	// 开启后支付相关的状态迁移与支付事件在同一事务中写入
+	enableOutbox bool
+	// 大于0时平台订单支付成功后需要在该期限内确认履约
+	fulfillmentWindow time.Duration
+
 	database    *mongo.Database
 	collections map[string]*mongo.Collection
 }
@@ -32,7 +37,14 @@ func InitConnPool(cfg *config.Storage) {
 	var err error
 
 	p = &MongoClientConnPool{
-		logger: logger.GetGlobalLogger().WithField("infra", "mongo"),
+		logger:       logger.GetGlobalLogger().WithField("infra", "mongo"),
+		enableOutbox: config.GetConfig().ServiceInternalConfig.Outbox.Enable,
+	}
+	if fulfillment := config.GetConfig().ServiceInternalConfig.Fulfillment; fulfillment.Enable {
+		p.fulfillmentWindow = time.Duration(fulfillment.ConfirmWindowInSecond) * time.Second
+		if p.fulfillmentWindow <= 0 {
+			p.fulfillmentWindow = FulfillmentDefaultConfirmWindow
+		}
 	}
 	if cfg.ConnTimeout > 0 {
 		p.timeout = time.Duration(cfg.ConnTimeout) * time.Second
@@ -75,19 +87,70 @@ func InitConnPool(cfg *config.Storage) {
 	}
 
 	p.database = p.client.Database(cfg.DB)
-	p.collections = make(map[string]*mongo.Collection, 2)
+	p.collections = make(map[string]*mongo.Collection, 5)
 	p.collections[PlatformOrderCollection] = p.database.Collection(PlatformOrderCollection)
 	p.collections[PaymentNotificationCollection] = p.database.Collection(PaymentNotificationCollection)
+	p.collections[PaymentNotificationArchiveCollection] = p.database.Collection(PaymentNotificationArchiveCollection)
+	p.collections[SuspiciousNotificationCollection] = p.database.Collection(SuspiciousNotificationCollection)
+	p.collections[RefundCollection] = p.database.Collection(RefundCollection)
+	p.collections[RefundNotificationCollection] = p.database.Collection(RefundNotificationCollection)
+	p.collections[OrderEventCollection] = p.database.Collection(OrderEventCollection)
+	p.collections[OutboxEventCollection] = p.database.Collection(OutboxEventCollection)
 
-	// 给 PlatformOrderCollection 创建额外的索引
-	indexes := []string{"trade_id"}
-	indexOrders := []int{1}
-	for i := 0; i < len(indexes); i++ {
+	// 给 PlatformOrderCollection 创建额外的索引, 平台订单交易ID唯一
+	// NOTE: 早期版本创建的 platform_order_trade_id_index 不是唯一索引, 先以新名称创建唯一索引, 创建成功后再删除旧索引.
+	// 唯一索引创建失败(如已存在重复的交易ID)时保留旧索引, 不影响启动, 但需要人工介入处理.
+	specs, err := p.collections[PlatformOrderCollection].Indexes().ListSpecifications(context.Background())
+	if err != nil {
+		p.logger.WithError(err).Fatalf("failed to list indexes for %s.%s",
+			cfg.DB, PlatformOrderCollection)
+	}
+	legacyIndexExisted, uniqueIndexExisted := false, false
+	for _, spec := range specs {
+		if spec.Name != "platform_order_trade_id_index" {
+			continue
+		}
+		if spec.Unique != nil && *spec.Unique {
+			uniqueIndexExisted = true
+		} else {
+			legacyIndexExisted = true
+		}
+	}
+	if !uniqueIndexExisted {
 		index, err := p.collections[PlatformOrderCollection].Indexes().CreateOne(context.Background(), mongo.IndexModel{
-			Keys: bson.D{
-				{Key: indexes[i], Value: indexOrders[i]},
-			},
-			Options: options.Index().SetName(fmt.Sprintf("platform_order_%s_index", indexes[i])),
+			Keys: bson.D{{Key: "trade_id", Value: 1}},
+			Options: options.Index().
+				SetName("platform_order_trade_id_unique_index").
+				SetUnique(true),
+		})
+		if err != nil {
+			p.logger.WithError(err).Errorf("failed to create unique index for %s.%s",
+				cfg.DB, PlatformOrderCollection)
+		} else {
+			p.logger.Infof("create unique index %s for %s.%s",
+				index, cfg.DB, PlatformOrderCollection)
+			if legacyIndexExisted {
+				if _, err = p.collections[PlatformOrderCollection].Indexes().DropOne(context.Background(), "platform_order_trade_id_index"); err != nil {
+					p.logger.WithError(err).Errorf("failed to drop index platform_order_trade_id_index for %s.%s",
+						cfg.DB, PlatformOrderCollection)
+				}
+			}
+		}
+	}
+	// 给 PlatformOrderCollection 创建额外的索引, 用于扫描已过期的未支付平台订单、停留在中间状态的平台订单
+	// 和超过期限未确认履约的平台订单, 以及按应用ID/用户唯一标识分页查询平台订单
+	indexKeys := []bson.D{
+		{{Key: "status", Value: 1}, {Key: "expire_time", Value: 1}},
+		{{Key: "status", Value: 1}, {Key: "update_time", Value: 1}},
+		{{Key: "app_id", Value: 1}, {Key: "_id", Value: -1}},
+		{{Key: "payer_uid", Value: 1}, {Key: "_id", Value: -1}},
+		{{Key: "fulfillment_state", Value: 1}, {Key: "fulfillment_deadline", Value: 1}},
+	}
+	indexNames := []string{"status_expire_time", "status_update_time", "app_id_id", "payer_uid_id", "fulfillment_state_deadline"}
+	for i := 0; i < len(indexKeys); i++ {
+		index, err := p.collections[PlatformOrderCollection].Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys:    indexKeys[i],
+			Options: options.Index().SetName(fmt.Sprintf("platform_order_%s_index", indexNames[i])),
 		})
 		if err != nil {
 			p.logger.WithError(err).Fatalf("failed to create index for %s.%s",
@@ -98,8 +161,8 @@ func InitConnPool(cfg *config.Storage) {
 		}
 	}
 	// 给 PaymentNotificationCollection 创建额外的索引
-	indexes = []string{"trade_id"}
-	indexOrders = []int{1}
+	indexes := []string{"trade_id"}
+	indexOrders := []int{1}
 	for i := 0; i < len(indexes); i++ {
 		index, err := p.collections[PaymentNotificationCollection].Indexes().CreateOne(context.Background(), mongo.IndexModel{
 			Keys: bson.D{
@@ -115,6 +178,126 @@ func InitConnPool(cfg *config.Storage) {
 				index, cfg.DB, PaymentNotificationCollection)
 		}
 	}
+	// 给 PaymentNotificationCollection 创建唯一索引, 同一个通知或同一笔微信支付订单只能被保存一次
+	// NOTE: 早期版本可能重复保存过支付通知, 唯一索引创建失败时不影响启动, 重复通知仍由 HasPaymentNotification 拦截,
+	// 需要运行 migrate-payment-notifications 归档重复记录并创建唯一索引.
+	_ = p.CreatePaymentNotificationUniqueIndexes(context.Background())
+	// 给 SuspiciousNotificationCollection 创建额外的索引, 用于可疑通知去重
+	index, err := p.collections[SuspiciousNotificationCollection].Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "trade_id", Value: 1}, {Key: "resource_transaction_id", Value: 1}},
+		Options: options.Index().SetName("suspicious_notification_trade_id_transaction_id_index"),
+	})
+	if err != nil {
+		p.logger.WithError(err).Fatalf("failed to create index for %s.%s",
+			cfg.DB, SuspiciousNotificationCollection)
+	} else {
+		p.logger.Infof("create index %s for %s.%s",
+			index, cfg.DB, SuspiciousNotificationCollection)
+	}
+	// 给 RefundCollection 创建额外的索引, 商户退款单号唯一
+	indexes = []string{"out_refund_no", "trade_id"}
+	indexOrders = []int{1, 1}
+	indexUniques := []bool{true, false}
+	for i := 0; i < len(indexes); i++ {
+		index, err := p.collections[RefundCollection].Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				{Key: indexes[i], Value: indexOrders[i]},
+			},
+			Options: options.Index().
+				SetName(fmt.Sprintf("refund_%s_index", indexes[i])).
+				SetUnique(indexUniques[i]),
+		})
+		if err != nil {
+			p.logger.WithError(err).Fatalf("failed to create index for %s.%s",
+				cfg.DB, RefundCollection)
+		} else {
+			p.logger.Infof("create index %s for %s.%s",
+				index, cfg.DB, RefundCollection)
+		}
+	}
+	// 给 RefundNotificationCollection 创建额外的索引, 同一个通知或同一退款单的同一退款状态只能被保存一次
+	indexKeys = []bson.D{
+		{{Key: "trade_id", Value: 1}},
+		{{Key: "notify_id", Value: 1}},
+		{{Key: "resource_refund_id", Value: 1}, {Key: "resource_refund_status", Value: 1}},
+	}
+	indexNames = []string{"trade_id", "notify_id", "resource_refund_id_status"}
+	indexUniques = []bool{false, true, true}
+	for i := 0; i < len(indexKeys); i++ {
+		index, err := p.collections[RefundNotificationCollection].Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: indexKeys[i],
+			Options: options.Index().
+				SetName(fmt.Sprintf("refund_notification_%s_index", indexNames[i])).
+				SetUnique(indexUniques[i]),
+		})
+		if err != nil {
+			p.logger.WithError(err).Fatalf("failed to create index for %s.%s",
+				cfg.DB, RefundNotificationCollection)
+		} else {
+			p.logger.Infof("create index %s for %s.%s",
+				index, cfg.DB, RefundNotificationCollection)
+		}
+	}
+	// 给 OrderEventCollection 创建额外的索引, 用于按平台订单查询状态迁移记录
+	index, err = p.collections[OrderEventCollection].Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "trade_id", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("order_event_trade_id_id_index"),
+	})
+	if err != nil {
+		p.logger.WithError(err).Fatalf("failed to create index for %s.%s",
+			cfg.DB, OrderEventCollection)
+	} else {
+		p.logger.Infof("create index %s for %s.%s",
+			index, cfg.DB, OrderEventCollection)
+	}
+	// 给 OutboxEventCollection 创建额外的索引, 支付事件ID唯一
+	indexKeys = []bson.D{
+		{{Key: "event_id", Value: 1}},
+		{{Key: "delivery_status", Value: 1}, {Key: "next_attempt_time", Value: 1}},
+		{{Key: "trade_id", Value: 1}, {Key: "_id", Value: 1}},
+	}
+	indexNames = []string{"event_id", "delivery_status_next_attempt_time", "trade_id_id"}
+	indexUniques = []bool{true, false, false}
+	for i := 0; i < len(indexKeys); i++ {
+		index, err := p.collections[OutboxEventCollection].Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: indexKeys[i],
+			Options: options.Index().
+				SetName(fmt.Sprintf("outbox_event_%s_index", indexNames[i])).
+				SetUnique(indexUniques[i]),
+		})
+		if err != nil {
+			p.logger.WithError(err).Fatalf("failed to create index for %s.%s",
+				cfg.DB, OutboxEventCollection)
+		} else {
+			p.logger.Infof("create index %s for %s.%s",
+				index, cfg.DB, OutboxEventCollection)
+		}
+	}
+}
+
+// CreatePaymentNotificationUniqueIndexes 给 PaymentNotificationCollection 创建 notify_id 与 resource_transaction_id 唯一索引,
+// 返回最后一个创建失败的错误.
+// NOTE: resource_transaction_id 索引同时用于由微信支付订单号反查平台订单.
+func (p *MongoClientConnPool) CreatePaymentNotificationUniqueIndexes(ctx context.Context) (err error) {
+	for _, field := range []string{"notify_id", "resource_transaction_id"} {
+		index, innerErr := p.collections[PaymentNotificationCollection].Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: field, Value: 1}},
+			Options: options.Index().
+				SetName(fmt.Sprintf("payment_notification_%s_unique_index", field)).
+				SetUnique(true).
+				// 忽略空值, 例如未支付成功的通知没有微信支付订单号
+				SetPartialFilterExpression(bson.M{field: bson.M{"$gt": ""}}),
+		})
+		if innerErr != nil {
+			p.logger.WithError(innerErr).Errorf("failed to create unique index for %s.%s",
+				p.database.Name(), PaymentNotificationCollection)
+			err = innerErr
+		} else {
+			p.logger.Infof("create unique index %s for %s.%s",
+				index, p.database.Name(), PaymentNotificationCollection)
+		}
+	}
+	return
 }
 
 func GetConnPool() *MongoClientConnPool {
@@ -0,0 +1,24 @@
+package extmongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	OrderEventCollection = "order_events"
+)
+
+// 平台订单的一次状态迁移, 新建平台订单时 FromState 为空.
+type OrderEventModel struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty"`
+	TradeId     string             `bson:"trade_id"`
+	FromState   string             `bson:"from_state"`
+	FromStatus  int                `bson:"from_status"`
+	ToState     string             `bson:"to_state"`
+	ToStatus    int                `bson:"to_status"`
+	Version     int64              `bson:"version"`       // 迁移后的平台订单版本号
+	TraceId     string             `bson:"trace_id"`      // 触发迁移的请求链路ID
+	Source      string             `bson:"source"`        // 触发迁移的来源, rpc/notify/job/admin
+	WxErrorCode string             `bson:"wx_error_code"` // 导致迁移的微信支付错误码, 可为空
+	CreateTime  int64              `bson:"create_time"`
+}
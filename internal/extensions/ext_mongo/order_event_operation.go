@@ -0,0 +1,88 @@
+package extmongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+func (impl *MongoClientConnPool) AddOrderEvent(ctx context.Context, event *OrderEventModel) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "AddOrderEvent")
+
+	// 新建一条平台订单状态迁移记录
+	_, err = impl.collections[OrderEventCollection].InsertOne(
+		ctx,
+		event,
+		options.InsertOne().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.add_order_event"),
+	)
+	if err != nil {
+		logger.WithError(err).Errorf(
+			"failed to insert one new order-event(trade-id:%s)",
+			event.TradeId,
+		)
+		return
+	}
+
+	return
+}
+
+// ListOrderEvents 查询平台订单的全部状态迁移记录, 按迁移顺序从早到晚排序.
+func (impl *MongoClientConnPool) ListOrderEvents(ctx context.Context, tradeId string) (
+	events []*OrderEventModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListOrderEvents")
+
+	cursor, err := impl.collections[OrderEventCollection].Find(
+		ctx,
+		bson.M{"trade_id": tradeId},
+		options.Find().
+			SetSort(bson.D{{Key: "_id", Value: 1}}).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.list_order_events"),
+	)
+	if err != nil {
+		logger.WithError(err).Errorf(
+			"failed to list order-events(trade-id:%s)",
+			tradeId,
+		)
+		return
+	}
+	events = make([]*OrderEventModel, 0)
+	if err = cursor.All(ctx, &events); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to decode order-events(trade-id:%s)",
+			tradeId,
+		)
+		return
+	}
+
+	return
+}
+
+// newOrderEvent 根据上下文中的链路ID、来源与微信支付错误码构造一条状态迁移记录.
+func newOrderEvent(ctx context.Context, tradeId string, fromState string, fromStatus int,
+	toState string, toStatus int, version int64, ct int64) *OrderEventModel {
+	return &OrderEventModel{
+		TradeId:     tradeId,
+		FromState:   fromState,
+		FromStatus:  fromStatus,
+		ToState:     toState,
+		ToStatus:    toStatus,
+		Version:     version,
+		TraceId:     common.TraceId(ctx),
+		Source:      common.EventSource(ctx),
+		WxErrorCode: common.WxErrorCode(ctx),
+		CreateTime:  ct,
+	}
+}
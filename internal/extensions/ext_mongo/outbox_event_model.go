@@ -0,0 +1,77 @@
+package extmongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	OutboxEventCollection = "outbox_events"
+)
+
+// 支付事件类型.
+const (
+	OutboxEventTypePaymentSucceeded = "payment.succeeded"
+	OutboxEventTypePaymentClosed    = "payment.closed"
+	OutboxEventTypeRefundSucceeded  = "refund.succeeded"
+)
+
+// 支付事件及其Webhook投递状态.
+const (
+	DeliveryStatusPending   = "PENDING"
+	DeliveryStatusDelivered = "DELIVERED"
+	DeliveryStatusDead      = "DEAD"
+)
+
+// 待投递的支付事件, 与平台订单状态迁移在同一事务中写入.
+type OutboxEventModel struct {
+	Id                  primitive.ObjectID      `bson:"_id,omitempty"`
+	EventId             string                  `bson:"event_id"`
+	EventType           string                  `bson:"event_type"`
+	AppId               string                  `bson:"app_id"`
+	TradeId             string                  `bson:"trade_id"`
+	PayerUid            string                  `bson:"payer_uid"`
+	TradeType           string                  `bson:"trade_type"`
+	TransactionId       string                  `bson:"transaction_id"`
+	ItemAmountTotal     int64                   `bson:"item_amount_total"`
+	RefundedAmountTotal int64                   `bson:"refunded_amount_total"`
+	Metadata            map[string]string       `bson:"metadata,omitempty"`
+	State               string                  `bson:"state"`   // 迁移后的平台订单业务状态
+	Status              int                     `bson:"status"`  // 迁移后的平台订单处理步骤
+	Version             int64                   `bson:"version"` // 迁移后的平台订单版本号
+	DeliveryStatus      string                  `bson:"delivery_status"`
+	Deliveries          []*WebhookDeliveryModel `bson:"deliveries"`        // 各Webhook及消息总线的投递状态
+	NextAttemptTime     int64                   `bson:"next_attempt_time"` // 下一次投递时间
+	CreateTime          int64                   `bson:"create_time"`
+	UpdateTime          int64                   `bson:"update_time"`
+}
+
+// 支付事件向单个Webhook(或消息总线)的投递状态.
+type WebhookDeliveryModel struct {
+	Name            string `bson:"name"`
+	Url             string `bson:"url"`
+	Status          string `bson:"status"`
+	Attempts        int    `bson:"attempts"`
+	LastError       string `bson:"last_error"`
+	LastAttemptTime int64  `bson:"last_attempt_time"`
+	DeliveredTime   int64  `bson:"delivered_time"`
+}
+
+// 支付事件列表的过滤条件, 零值字段不参与过滤.
+type OutboxEventFilter struct {
+	TradeId        string
+	DeliveryStatus string
+}
+
+// OutboxEventTypeOf 返回平台订单从 fromState 推进到(toState, toStatus)时产生的支付事件类型, 不产生支付事件时返回空.
+func OutboxEventTypeOf(fromState, toState string, toStatus int) string {
+	if toStatus == PaymentStatusRefundSuccess {
+		return OutboxEventTypeRefundSucceeded
+	}
+	if IsPaymentAccepted(fromState, toState) {
+		return OutboxEventTypePaymentSucceeded
+	}
+	if toState == OrderStateClosed && fromState != OrderStateClosed {
+		return OutboxEventTypePaymentClosed
+	}
+	return ""
+}
@@ -0,0 +1,231 @@
+package extmongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+// newOutboxEvent 由迁移前的平台订单生成迁移后的支付事件.
+func newOutboxEvent(order *PlatformOrderModel, eventType, state string, status int, refundedAmount int64, ct int64) *OutboxEventModel {
+	return &OutboxEventModel{
+		EventId:             uuid.New().String(),
+		EventType:           eventType,
+		AppId:               order.AppId,
+		TradeId:             order.TradeId,
+		PayerUid:            order.PayerUid,
+		TradeType:           order.TradeType,
+		TransactionId:       order.TransactionId,
+		ItemAmountTotal:     order.ItemAmountTotal,
+		RefundedAmountTotal: order.RefundedAmountTotal + refundedAmount,
+		Metadata:            order.Metadata,
+		State:               state,
+		Status:              status,
+		Version:             order.Version + 1,
+		DeliveryStatus:      DeliveryStatusPending,
+		Deliveries:          []*WebhookDeliveryModel{},
+		NextAttemptTime:     ct,
+		CreateTime:          ct,
+		UpdateTime:          ct,
+	}
+}
+
+// updatePlatformOrderWithOutboxEvent 在同一事务中按版本号条件更新平台订单并写入支付事件, 版本号冲突时不写入支付事件.
+func (impl *MongoClientConnPool) updatePlatformOrderWithOutboxEvent(
+	ctx context.Context, filter bson.M, update bson.D, event *OutboxEventModel, comment string) (
+	result *mongo.UpdateResult, err error) {
+
+	session, err := impl.client.StartSession()
+	if err != nil {
+		return
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		var innerErr error
+		if result, innerErr = impl.collections[PlatformOrderCollection].UpdateOne(
+			sc,
+			filter,
+			update,
+			options.Update().SetComment(comment),
+		); innerErr != nil {
+			return nil, innerErr
+		}
+		if result.MatchedCount == 0 {
+			return nil, nil
+		}
+		_, innerErr = impl.collections[OutboxEventCollection].InsertOne(
+			sc,
+			event,
+			options.InsertOne().
+				SetComment("service.wechat_pay_backend_service.storage.mongo.method.add_outbox_event"),
+		)
+		return nil, innerErr
+	})
+	return
+}
+
+// ListDueOutboxEvents 查询到达投递时间的待投递支付事件, 按投递时间从早到晚排序.
+func (impl *MongoClientConnPool) ListDueOutboxEvents(ctx context.Context, attemptBefore int64, limit int64) (
+	events []*OutboxEventModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListDueOutboxEvents")
+
+	cursor, err := impl.collections[OutboxEventCollection].Find(
+		ctx,
+		bson.M{
+			"delivery_status":   DeliveryStatusPending,
+			"next_attempt_time": bson.M{"$lte": attemptBefore},
+		},
+		options.Find().
+			SetSort(bson.D{{Key: "next_attempt_time", Value: 1}}).
+			SetLimit(limit).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.list_due_outbox_events"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to list due outbox-events")
+		return
+	}
+	events = make([]*OutboxEventModel, 0, limit)
+	if err = cursor.All(ctx, &events); err != nil {
+		logger.WithError(err).Error(
+			"failed to decode due outbox-events")
+		return
+	}
+
+	return
+}
+
+// UpdateOutboxEventDelivery 保存支付事件一轮投递后的投递状态.
+func (impl *MongoClientConnPool) UpdateOutboxEventDelivery(ctx context.Context, event *OutboxEventModel) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "UpdateOutboxEventDelivery")
+
+	event.UpdateTime = time.Now().Unix()
+	if _, err = impl.collections[OutboxEventCollection].UpdateOne(
+		ctx,
+		bson.M{"event_id": event.EventId},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "delivery_status", Value: event.DeliveryStatus},
+			{Key: "deliveries", Value: event.Deliveries},
+			{Key: "next_attempt_time", Value: event.NextAttemptTime},
+			{Key: "update_time", Value: event.UpdateTime},
+		}}},
+		options.Update().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.update_outbox_event_delivery"),
+	); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to update delivery of outbox-event(event-id:%s)",
+			event.EventId,
+		)
+		return
+	}
+
+	return
+}
+
+// ListOutboxEvents 分页查询支付事件及其投递状态, 按写入时间从晚到早排序.
+func (impl *MongoClientConnPool) ListOutboxEvents(ctx context.Context, filter *OutboxEventFilter, cursor string, limit int64) (
+	events []*OutboxEventModel, nextCursor string, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListOutboxEvents")
+
+	query := bson.M{}
+	if len(filter.TradeId) > 0 {
+		query["trade_id"] = filter.TradeId
+	}
+	if len(filter.DeliveryStatus) > 0 {
+		query["delivery_status"] = filter.DeliveryStatus
+	}
+	if len(cursor) > 0 {
+		var lastId primitive.ObjectID
+		if lastId, err = primitive.ObjectIDFromHex(cursor); err != nil {
+			err = ErrInvalidCursor
+			return
+		}
+		query["_id"] = bson.M{"$lt": lastId}
+	}
+
+	// 多取一条用于判断是否还有下一页
+	c, err := impl.collections[OutboxEventCollection].Find(
+		ctx,
+		query,
+		options.Find().
+			SetSort(bson.D{{Key: "_id", Value: -1}}).
+			SetLimit(limit+1).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.list_outbox_events"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to list outbox-events")
+		return
+	}
+	events = make([]*OutboxEventModel, 0, limit+1)
+	if err = c.All(ctx, &events); err != nil {
+		logger.WithError(err).Error(
+			"failed to decode outbox-events")
+		return
+	}
+	if int64(len(events)) > limit {
+		events = events[:limit]
+		nextCursor = events[limit-1].Id.Hex()
+	}
+
+	return
+}
+
+// GetOutboxEventsByTradeIds 查询平台订单的全部支付事件, 按平台订单交易ID分组, 组内按写入时间从早到晚排序.
+func (impl *MongoClientConnPool) GetOutboxEventsByTradeIds(ctx context.Context, tradeIds []string) (
+	events map[string][]*OutboxEventModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "GetOutboxEventsByTradeIds")
+
+	events = make(map[string][]*OutboxEventModel, len(tradeIds))
+	if len(tradeIds) == 0 {
+		return
+	}
+	c, err := impl.collections[OutboxEventCollection].Find(
+		ctx,
+		bson.M{"trade_id": bson.M{"$in": tradeIds}},
+		options.Find().
+			SetSort(bson.D{{Key: "trade_id", Value: 1}, {Key: "_id", Value: 1}}).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.get_outbox_events_by_trade_ids"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to get outbox-events")
+		return
+	}
+	list := make([]*OutboxEventModel, 0, len(tradeIds))
+	if err = c.All(ctx, &list); err != nil {
+		logger.WithError(err).Error(
+			"failed to decode outbox-events")
+		return
+	}
+	for _, event := range list {
+		events[event.TradeId] = append(events[event.TradeId], event)
+	}
+
+	return
+}
@@ -6,6 +6,16 @@ import (
 
 const (
 	PaymentNotificationCollection = "payment_notifications"
+	// 早期版本重复保存的支付通知, 由 migrate-payment-notifications 归档, 保留用于对账
+	PaymentNotificationArchiveCollection = "payment_notifications_archive"
+)
+
+// 支付结果的来源.
+const (
+	// 微信支付推送的支付通知
+	NotificationSourceNotify = "notify"
+	// 主动查询订单得到的支付结果, 由此合成的支付通知没有通知ID
+	NotificationSourceQuery = "query"
 )
 
 type PaymentNotificationModel struct {
@@ -27,5 +37,7 @@ type PaymentNotificationModel struct {
 	ResourceAmountPayerTotal    int                `bson:"resource_amount_payer_total"`
 	ResourceAmountCurrency      string             `bson:"resource_amount_currency"`
 	ResourceAmountPayerCurrency string             `bson:"resource_amount_payer_currency"`
+	ResourceAttach              string             `bson:"resource_attach"`
 	Summary                     string             `bson:"summary"`
+	Source                      string             `bson:"source"`
 }
@@ -2,7 +2,11 @@ package extmongo
 
 import (
 	"context"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
@@ -24,6 +28,11 @@ func (impl *MongoClientConnPool) AddPaymentNotification(ctx context.Context, not
 			SetComment("service.wechat_pay_backend_service.storage.mongo.method.add_payment_notification"),
 	)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			logger.Warn("payment-notification has been inserted before")
+			err = ErrDuplicateRecord
+			return
+		}
 		logger.WithError(err).Error(
 			"failed to insert one new payment-notification")
 		return
@@ -34,3 +43,241 @@ func (impl *MongoClientConnPool) AddPaymentNotification(ctx context.Context, not
 
 	return
 }
+
+func (impl *MongoClientConnPool) HasPaymentNotification(ctx context.Context, notifyId, transactionId string) (
+	existed bool, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "HasPaymentNotification")
+
+	// 按通知ID或微信支付订单号查找已保存的支付通知, 主动查询合成的支付通知没有通知ID
+	filter := bson.A{}
+	if len(notifyId) > 0 {
+		filter = append(filter, bson.M{"notify_id": notifyId})
+	}
+	if len(transactionId) > 0 {
+		filter = append(filter, bson.M{"resource_transaction_id": transactionId})
+	}
+	if len(filter) == 0 {
+		return
+	}
+	var cnt int64
+	cnt, err = impl.collections[PaymentNotificationCollection].CountDocuments(
+		ctx,
+		bson.M{"$or": filter},
+		options.Count().
+			SetLimit(1).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.has_payment_notification"),
+	)
+	if err != nil {
+		logger.WithError(err).Errorf(
+			"failed to count payment-notification(notify-id:%s)",
+			notifyId,
+		)
+		return
+	}
+	existed = cnt > 0
+
+	return
+}
+
+// GetPaymentNotificationByTransactionId 按微信支付订单号查询已保存的支付通知, 用于由微信支付订单号反查平台订单.
+func (impl *MongoClientConnPool) GetPaymentNotificationByTransactionId(ctx context.Context, transactionId string) (
+	notification *PaymentNotificationModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "GetPaymentNotificationByTransactionId")
+
+	notification = &PaymentNotificationModel{}
+	if err = impl.collections[PaymentNotificationCollection].FindOne(
+		ctx,
+		bson.M{"resource_transaction_id": transactionId},
+		options.FindOne().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.get_payment_notification_by_transaction_id"),
+	).Decode(notification); err != nil {
+		notification = nil
+		if err == mongo.ErrNoDocuments {
+			err = ErrRecordNotFound
+			return
+		}
+		logger.WithError(err).Errorf(
+			"failed to get payment-notification(transaction-id:%s)",
+			transactionId,
+		)
+		return
+	}
+
+	return
+}
+
+// GetLatestPaymentNotifications 查询每笔平台订单最近保存的一条支付通知, 未收到支付通知的平台订单不在结果中.
+func (impl *MongoClientConnPool) GetLatestPaymentNotifications(ctx context.Context, tradeIds []string) (
+	notifications map[string]*PaymentNotificationModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "GetLatestPaymentNotifications")
+
+	notifications = make(map[string]*PaymentNotificationModel, len(tradeIds))
+	if len(tradeIds) == 0 {
+		return
+	}
+	cursor, err := impl.collections[PaymentNotificationCollection].Find(
+		ctx,
+		bson.M{"trade_id": bson.M{"$in": tradeIds}},
+		options.Find().
+			SetSort(bson.D{{Key: "_id", Value: -1}}).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.get_latest_payment_notifications"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to list payment-notifications")
+		return
+	}
+	list := make([]*PaymentNotificationModel, 0)
+	if err = cursor.All(ctx, &list); err != nil {
+		logger.WithError(err).Error(
+			"failed to decode payment-notifications")
+		return
+	}
+	for _, notification := range list {
+		if _, ok := notifications[notification.TradeId]; !ok {
+			notifications[notification.TradeId] = notification
+		}
+	}
+
+	return
+}
+
+// ArchiveDuplicatePaymentNotifications 归档早期版本按 field 重复保存的支付通知, 同一个字段值只保留最早保存的一条,
+// 其余的复制到 PaymentNotificationArchiveCollection 后删除, 并累加到平台订单收到的重复支付通知次数.
+// NOTE: 仅用于创建唯一索引前的一次性迁移(migrate-payment-notifications), 不在服务启动时执行.
+// 中途失败时可以重新执行, 已归档的记录不会重复归档.
+func (impl *MongoClientConnPool) ArchiveDuplicatePaymentNotifications(ctx context.Context, field string) (
+	archived int64, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyEvent, "ArchiveDuplicatePaymentNotifications").
+		WithField("field", field)
+
+	cursor, err := impl.collections[PaymentNotificationCollection].Aggregate(
+		ctx,
+		mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{field: bson.M{"$gt": ""}}}},
+			{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+			{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: "$" + field},
+				{Key: "ids", Value: bson.M{"$push": "$_id"}},
+				{Key: "count", Value: bson.M{"$sum": 1}},
+			}}},
+			{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		},
+		options.Aggregate().
+			SetAllowDiskUse(true).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.archive_duplicate_payment_notifications"),
+	)
+	if err != nil {
+		logger.WithError(err).Error("failed to aggregate duplicate payment-notifications")
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var group struct {
+			Ids []primitive.ObjectID `bson:"ids"`
+		}
+		if err = cursor.Decode(&group); err != nil {
+			logger.WithError(err).Error("failed to decode duplicate payment-notifications")
+			return
+		}
+		var n int64
+		if n, err = impl.archivePaymentNotifications(ctx, group.Ids[1:], "duplicate "+field); err != nil {
+			logger.WithError(err).Errorf("failed to archive duplicate payment-notifications %v", group.Ids[1:])
+			return
+		}
+		archived += n
+	}
+	if err = cursor.Err(); err != nil {
+		logger.WithError(err).Error("failed to iterate duplicate payment-notifications")
+	}
+
+	return
+}
+
+// archivePaymentNotifications 将支付通知复制到 PaymentNotificationArchiveCollection 后删除,
+// 每删除一条即累加一次平台订单收到的重复支付通知次数.
+func (impl *MongoClientConnPool) archivePaymentNotifications(ctx context.Context, ids []primitive.ObjectID, reason string) (
+	archived int64, err error) {
+
+	c, err := impl.collections[PaymentNotificationCollection].Find(
+		ctx,
+		bson.M{"_id": bson.M{"$in": ids}},
+		options.Find().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.archive_payment_notifications"),
+	)
+	if err != nil {
+		return
+	}
+	docs := make([]bson.M, 0, len(ids))
+	if err = c.All(ctx, &docs); err != nil {
+		return
+	}
+	if len(docs) == 0 {
+		return
+	}
+
+	// 1. 复制到归档集合, 保留原记录的全部字段和_id, 重新执行时忽略已归档的记录
+	ct := time.Now().Unix()
+	redeliveries := make(map[string]int64)
+	for _, doc := range docs {
+		doc["archive_reason"] = reason
+		doc["archive_time"] = ct
+		if _, err = impl.collections[PaymentNotificationArchiveCollection].InsertOne(
+			ctx,
+			doc,
+			options.InsertOne().
+				SetComment("service.wechat_pay_backend_service.storage.mongo.method.archive_payment_notifications"),
+		); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return
+		}
+		err = nil
+		if tradeId, ok := doc["trade_id"].(string); ok && len(tradeId) > 0 {
+			redeliveries[tradeId] += 1
+		}
+	}
+
+	// 2. 删除已归档的支付通知
+	result, err := impl.collections[PaymentNotificationCollection].DeleteMany(
+		ctx,
+		bson.M{"_id": bson.M{"$in": ids}},
+		options.Delete().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.archive_payment_notifications"),
+	)
+	if err != nil {
+		return
+	}
+	archived = result.DeletedCount
+
+	// 3. 删除的重复支付通知计入平台订单收到的重复支付通知次数
+	for tradeId, count := range redeliveries {
+		if _, err = impl.collections[PlatformOrderCollection].UpdateOne(
+			ctx,
+			bson.M{"trade_id": tradeId},
+			bson.D{
+				{Key: "$inc", Value: bson.D{{Key: "notify_redelivery_count", Value: count}}},
+				{Key: "$set", Value: bson.D{{Key: "update_time", Value: ct}}},
+			},
+			options.Update().
+				SetComment("service.wechat_pay_backend_service.storage.mongo.method.archive_payment_notifications"),
+		); err != nil {
+			return
+		}
+	}
+
+	return
+}
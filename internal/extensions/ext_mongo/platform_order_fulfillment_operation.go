@@ -0,0 +1,252 @@
+package extmongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+// ConfirmPlatformOrderFulfillment 确认平台订单已履约, 返回确认履约的时间.
+// 已确认履约, 或履约超时且已按自动退款策略处理的平台订单不允许再确认履约, 返回 ErrIllegalStateTransition.
+func (impl *MongoClientConnPool) ConfirmPlatformOrderFulfillment(ctx context.Context, tradeId string) (
+	confirmTime int64, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ConfirmPlatformOrderFulfillment")
+
+	confirmTime = time.Now().Unix()
+	result, err := impl.collections[PlatformOrderCollection].UpdateOne(
+		ctx,
+		bson.M{
+			"trade_id": tradeId,
+			"$or": bson.A{
+				// 早期保存的平台订单没有履约状态字段
+				bson.M{"fulfillment_state": bson.M{"$in": bson.A{"", nil, FulfillmentStatePending}}},
+				bson.M{"fulfillment_state": FulfillmentStateOverdue, "fulfillment_overdue_policy": FulfillmentOverduePolicyAlert},
+			},
+		},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "fulfillment_state", Value: FulfillmentStateConfirmed},
+			{Key: "fulfillment_confirm_time", Value: confirmTime},
+			{Key: "update_time", Value: confirmTime},
+		}}},
+		options.Update().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.confirm_platform_order_fulfillment"),
+	)
+	if err != nil {
+		logger.WithError(err).Errorf(
+			"failed to confirm fulfillment of platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	}
+	if result.MatchedCount == 0 {
+		logger.Warnf(
+			"reject to confirm fulfillment of platform-order(trade-id:%s)",
+			tradeId,
+		)
+		err = ErrIllegalStateTransition
+		return
+	}
+	logger.Infof(
+		"confirm fulfillment of platform-order(trade-id:%s)",
+		tradeId,
+	)
+
+	return
+}
+
+// MarkPlatformOrderFulfillmentOverdue 将超过期限仍未确认履约的平台订单标记为履约超时, 并记录执行的策略.
+// 平台订单已被确认履约(已全额退款, 或已被其他副本标记)时返回 ErrConcurrentUpdate.
+func (impl *MongoClientConnPool) MarkPlatformOrderFulfillmentOverdue(ctx context.Context, tradeId, policy string) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "MarkPlatformOrderFulfillmentOverdue")
+
+	ct := time.Now().Unix()
+	query := fulfillmentRefundableQuery()
+	query["trade_id"] = tradeId
+	query["fulfillment_state"] = FulfillmentStatePending
+	query["fulfillment_deadline"] = bson.M{"$lt": ct}
+	result, err := impl.collections[PlatformOrderCollection].UpdateOne(
+		ctx,
+		query,
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "fulfillment_state", Value: FulfillmentStateOverdue},
+			{Key: "fulfillment_overdue_policy", Value: policy},
+			{Key: "update_time", Value: ct},
+		}}},
+		options.Update().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.mark_platform_order_fulfillment_overdue"),
+	)
+	if err != nil {
+		logger.WithError(err).Errorf(
+			"failed to mark fulfillment of platform-order(trade-id:%s) overdue",
+			tradeId,
+		)
+		return
+	}
+	if result.MatchedCount == 0 {
+		err = ErrConcurrentUpdate
+		return
+	}
+	logger.Infof(
+		"mark fulfillment of platform-order(trade-id:%s) overdue, policy:%s",
+		tradeId, policy,
+	)
+
+	return
+}
+
+// SavePlatformOrderFulfillmentRefund 回写履约超时自动退款的商户退款单号, 不改变平台订单状态.
+func (impl *MongoClientConnPool) SavePlatformOrderFulfillmentRefund(ctx context.Context, tradeId, outRefundNo string) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "SavePlatformOrderFulfillmentRefund")
+
+	if _, err = impl.collections[PlatformOrderCollection].UpdateOne(
+		ctx,
+		bson.M{"trade_id": tradeId},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "fulfillment_out_refund_no", Value: outRefundNo},
+			{Key: "update_time", Value: time.Now().Unix()},
+		}}},
+		options.Update().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.save_platform_order_fulfillment_refund"),
+	); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to save fulfillment-refund of platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	} else {
+		logger.Infof(
+			"save fulfillment-refund of platform-order(trade-id:%s)",
+			tradeId,
+		)
+	}
+
+	return
+}
+
+// SavePlatformOrderFulfillmentRefundError 记录履约超时自动退款不可重试的失败原因, 此后不再自动退款, 由人工处理.
+func (impl *MongoClientConnPool) SavePlatformOrderFulfillmentRefundError(ctx context.Context, tradeId, reason string) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "SavePlatformOrderFulfillmentRefundError")
+
+	if _, err = impl.collections[PlatformOrderCollection].UpdateOne(
+		ctx,
+		bson.M{"trade_id": tradeId},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "fulfillment_refund_error", Value: reason},
+			{Key: "update_time", Value: time.Now().Unix()},
+		}}},
+		options.Update().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.save_platform_order_fulfillment_refund_error"),
+	); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to save fulfillment-refund-error of platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	}
+
+	return
+}
+
+// ListFulfillmentOverduePlatformOrders 查询超过期限仍未确认履约、且尚未全额退款的平台订单, 按期限从早到晚排序.
+func (impl *MongoClientConnPool) ListFulfillmentOverduePlatformOrders(ctx context.Context, deadlineBefore int64, limit int64) (
+	orders []*PlatformOrderModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListFulfillmentOverduePlatformOrders")
+
+	query := fulfillmentRefundableQuery()
+	query["fulfillment_state"] = FulfillmentStatePending
+	query["fulfillment_deadline"] = bson.M{"$lt": deadlineBefore}
+	cursor, err := impl.collections[PlatformOrderCollection].Find(
+		ctx,
+		query,
+		options.Find().
+			SetSort(bson.D{{Key: "fulfillment_deadline", Value: 1}}).
+			SetLimit(limit).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.list_fulfillment_overdue_platform_orders"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to list fulfillment-overdue platform-orders")
+		return
+	}
+	orders = make([]*PlatformOrderModel, 0)
+	if err = cursor.All(ctx, &orders); err != nil {
+		logger.WithError(err).Error(
+			"failed to decode fulfillment-overdue platform-orders")
+		return
+	}
+
+	return
+}
+
+// ListFulfillmentUnrefundedPlatformOrders 查询履约超时、按自动退款策略处理但退款尚未受理(且未因不可重试的错误放弃)的平台订单, 按期限从早到晚排序.
+// 已部分退款的平台订单同样需要自动退款剩余的金额.
+func (impl *MongoClientConnPool) ListFulfillmentUnrefundedPlatformOrders(ctx context.Context, limit int64) (
+	orders []*PlatformOrderModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListFulfillmentUnrefundedPlatformOrders")
+
+	query := fulfillmentRefundableQuery()
+	query["fulfillment_state"] = FulfillmentStateOverdue
+	query["fulfillment_overdue_policy"] = FulfillmentOverduePolicyRefund
+	query["fulfillment_out_refund_no"] = bson.M{"$in": bson.A{"", nil}}
+	query["fulfillment_refund_error"] = bson.M{"$in": bson.A{"", nil}}
+	cursor, err := impl.collections[PlatformOrderCollection].Find(
+		ctx,
+		query,
+		options.Find().
+			SetSort(bson.D{{Key: "fulfillment_deadline", Value: 1}}).
+			SetLimit(limit).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.list_fulfillment_unrefunded_platform_orders"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to list fulfillment-unrefunded platform-orders")
+		return
+	}
+	orders = make([]*PlatformOrderModel, 0)
+	if err = cursor.All(ctx, &orders); err != nil {
+		logger.WithError(err).Error(
+			"failed to decode fulfillment-unrefunded platform-orders")
+		return
+	}
+
+	return
+}
+
+// fulfillmentRefundableQuery 返回匹配已支付且尚未全额退款的平台订单的查询条件, 与 IsFulfillmentRefundable 保持一致.
+func fulfillmentRefundableQuery() bson.M {
+	return bson.M{
+		"state": bson.M{"$in": fulfillmentRefundableStates},
+		"$expr": bson.M{"$lt": bson.A{"$refunded_amount_total", "$item_amount_total"}},
+	}
+}
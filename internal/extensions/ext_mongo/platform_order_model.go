@@ -1,23 +1,87 @@
 package extmongo
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 const (
-	PlatformOrderCollection = "platform_orders"
+	PlatformOrderCollection         = "platform_orders"
+	PlatformOrderTransitMaxRetries  = 3
+	FulfillmentDefaultConfirmWindow = 24 * time.Hour
+)
+
+// 平台订单履约状态, 为空表示不跟踪履约(支付成功时未开启履约确认, 或早期保存的平台订单).
+const (
+	FulfillmentStatePending   = "PENDING"
+	FulfillmentStateConfirmed = "CONFIRMED"
+	FulfillmentStateOverdue   = "OVERDUE"
+)
+
+// 履约超时后执行的策略.
+const (
+	FulfillmentOverduePolicyAlert  = "alert"
+	FulfillmentOverduePolicyRefund = "refund"
+)
+
+// 交易类型, 取值与微信支付返回的trade_type保持一致.
+const (
+	TradeTypeJSAPI  = "JSAPI"
+	TradeTypeNative = "NATIVE"
+	TradeTypeH5     = "MWEB"
+	TradeTypeAPP    = "APP"
 )
 
 type PlatformOrderModel struct {
-	Id              primitive.ObjectID `bson:"_id,omitempty"`
-	AppId           string             `bson:"app_id"`
-	MchId           string             `bson:"merchant_id"`
-	TradeId         string             `bson:"trade_id"`
-	PayerUid        string             `bson:"payer_uid"`
-	ItemDescription string             `bson:"item_description"`
-	ItemAmountTotal int64              `bson:"item_amount_total"`
-	Status          int                `bson:"status"`
-	ExpireTime      int64              `bson:"expire_time"`
-	CreateTime      int64              `bson:"create_time"`
-	UpdateTime      int64              `bson:"update_time"`
+	Id                       primitive.ObjectID `bson:"_id,omitempty"`
+	AppId                    string             `bson:"app_id"`
+	MchId                    string             `bson:"merchant_id"`
+	TradeId                  string             `bson:"trade_id"`
+	PayerUid                 string             `bson:"payer_uid"`
+	TradeType                string             `bson:"trade_type"`
+	ItemDescription          string             `bson:"item_description"`
+	ItemAmountTotal          int64              `bson:"item_amount_total"`
+	PrepayId                 string             `bson:"prepay_id"`                  // 微信预支付订单标识, 重复下单时复用
+	PrepayTime               int64              `bson:"prepay_time"`                // 微信预支付订单的创建时间, 预支付订单标识有效期为2小时
+	Items                    []*OrderItemModel  `bson:"items,omitempty"`            // 商品明细
+	Metadata                 map[string]string  `bson:"metadata,omitempty"`         // 平台订单元数据, 随下单请求的附加数据回传
+	TransactionId            string             `bson:"transaction_id"`             // 微信支付订单号, 支付成功后回写
+	SuccessTime              string             `bson:"success_time"`               // 支付完成时间, 支付成功后回写
+	State                    string             `bson:"state"`                      // 业务状态, 见 OrderStateXXX
+	Status                   int                `bson:"status"`                     // 处理步骤, 见 PaymentStatusXXX
+	Version                  int64              `bson:"version"`                    // 乐观锁版本号, 每次推进状态加一
+	NotifyRedeliveryCount    int64              `bson:"notify_redelivery_count"`    // 微信重复推送支付通知的次数
+	RefundedAmountTotal      int64              `bson:"refunded_amount_total"`      // 已退款总额, 单位（分）
+	RefundedOutRefundNos     []string           `bson:"refunded_out_refund_nos"`    // 已累加到已退款总额的商户退款单号
+	FulfillmentState         string             `bson:"fulfillment_state"`          // 履约状态, 见 FulfillmentStateXXX
+	FulfillmentDeadline      int64              `bson:"fulfillment_deadline"`       // 确认履约的期限, 支付成功时写入
+	FulfillmentConfirmTime   int64              `bson:"fulfillment_confirm_time"`   // 确认履约的时间
+	FulfillmentOverduePolicy string             `bson:"fulfillment_overdue_policy"` // 履约超时后执行的策略, 见 FulfillmentOverduePolicyXXX
+	FulfillmentOutRefundNo   string             `bson:"fulfillment_out_refund_no"`  // 履约超时自动退款的商户退款单号, 退款受理后写入
+	FulfillmentRefundError   string             `bson:"fulfillment_refund_error"`   // 履约超时自动退款不可重试的失败原因, 写入后不再自动退款
+	CheckAttempts            int64              `bson:"check_attempts"`             // 后台任务检查该平台订单的次数
+	NextCheckTime            int64              `bson:"next_check_time"`            // 后台任务下次检查该平台订单的时间
+	ExpireTime               int64              `bson:"expire_time"`
+	CreateTime               int64              `bson:"create_time"`
+	UpdateTime               int64              `bson:"update_time"`
+}
+
+// 平台订单的商品明细.
+type OrderItemModel struct {
+	SkuId            string `bson:"sku_id"`
+	Name             string `bson:"name"`
+	UnitPrice        int64  `bson:"unit_price"` // 单位（分）
+	Quantity         int64  `bson:"quantity"`
+	WechatpayGoodsId string `bson:"wechatpay_goods_id,omitempty"`
+}
+
+// 平台订单列表的过滤条件, 零值字段不参与过滤.
+type PlatformOrderFilter struct {
+	AppId           string
+	PayerUid        string
+	StateList       []string
+	StatusList      []int
+	CreateTimeBegin int64 // 左闭区间
+	CreateTimeEnd   int64 // 右开区间
 }
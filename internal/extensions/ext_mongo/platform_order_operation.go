@@ -2,9 +2,12 @@ package extmongo
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
@@ -26,6 +29,11 @@ func (impl *MongoClientConnPool) AddPlatformOrder(ctx context.Context, order *Pl
 			SetComment("service.wechat_pay_backend_service.storage.mongo.method.add_platform_order"),
 	)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			logger.Warn("platform-order has been inserted before")
+			err = ErrDuplicateRecord
+			return
+		}
 		logger.WithError(err).Error(
 			"failed to insert one new platform-order")
 		return
@@ -33,10 +41,13 @@ func (impl *MongoClientConnPool) AddPlatformOrder(ctx context.Context, order *Pl
 		logger.Info(
 			"insert one new platform-order")
 	}
+	_ = impl.AddOrderEvent(ctx, newOrderEvent(ctx, order.TradeId,
+		"", order.Status, order.State, order.Status, order.Version, order.CreateTime))
 
 	return
 }
 
+// UpdatePlatformOrder 推进平台订单的处理步骤, 业务状态随之迁移, 非法迁移返回 ErrIllegalStateTransition.
 func (impl *MongoClientConnPool) UpdatePlatformOrder(ctx context.Context, tradeId string, status int) (
 	err error) {
 
@@ -46,24 +57,479 @@ func (impl *MongoClientConnPool) UpdatePlatformOrder(ctx context.Context, tradeI
 		WithField(common.LoggerKeyEvent, "UpdatePlatformOrder")
 
 	// 更新平台订单状态
+	if err = impl.transitPlatformOrder(ctx, tradeId, status, "", 0,
+		"service.wechat_pay_backend_service.storage.mongo.method.update_platform_order"); err != nil {
+		if err == ErrIllegalStateTransition {
+			logger.Warnf(
+				"reject to update platform-order(trade-id:%s) to status:%d",
+				tradeId, status,
+			)
+			return
+		}
+		logger.WithError(err).Errorf(
+			"failed to update platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	} else {
+		logger.Infof(
+			"update platform-order(trade-id:%s)",
+			tradeId,
+		)
+	}
+
+	return
+}
+
+// transitPlatformOrder 读取平台订单后按版本号条件更新, 保证并发写入不会使平台订单状态回退.
+// 版本号冲突时重新读取平台订单并再次校验状态迁移, 最多重试 PlatformOrderTransitMaxRetries 次.
+// 退款金额按商户退款单号只累加一次, 同一退款单的结果重复写入时只推进状态.
+func (impl *MongoClientConnPool) transitPlatformOrder(
+	ctx context.Context, tradeId string, status int, outRefundNo string, refundedAmount int64, comment string) (
+	err error) {
+
+	for i := 0; i < PlatformOrderTransitMaxRetries; i++ {
+		order := &PlatformOrderModel{}
+		if err = impl.collections[PlatformOrderCollection].FindOne(
+			ctx,
+			bson.M{"trade_id": tradeId},
+			options.FindOne().SetComment(comment),
+		).Decode(order); err != nil {
+			if err == mongo.ErrNoDocuments {
+				err = ErrRecordNotFound
+			}
+			return
+		}
+		if refundedAmount > 0 && slices.Contains(order.RefundedOutRefundNos, outRefundNo) {
+			refundedAmount = 0
+		}
+
+		state := NextOrderState(order, status, refundedAmount)
+		if err = CheckOrderTransition(order.CurrentState(), order.Status, state, status); err != nil {
+			return
+		}
+
+		filter := bson.M{"trade_id": tradeId, "version": order.Version}
+		if order.Version == 0 {
+			// 早期保存的平台订单没有版本号字段
+			filter["version"] = bson.M{"$in": bson.A{0, nil}}
+		}
+		ct := time.Now().Unix()
+		set := bson.D{
+			{Key: "state", Value: state},
+			{Key: "status", Value: status},
+			{Key: "update_time", Value: ct},
+		}
+		if impl.fulfillmentWindow > 0 && IsPaymentAccepted(order.CurrentState(), state) {
+			// 支付成功, 开始计算确认履约的期限
+			set = append(set,
+				bson.E{Key: "fulfillment_state", Value: FulfillmentStatePending},
+				bson.E{Key: "fulfillment_deadline", Value: ct + int64(impl.fulfillmentWindow.Seconds())},
+			)
+		}
+		update := bson.D{{Key: "$set", Value: set}}
+		inc := bson.D{{Key: "version", Value: 1}}
+		if refundedAmount > 0 {
+			inc = append(inc, bson.E{Key: "refunded_amount_total", Value: refundedAmount})
+			update = append(update, bson.E{Key: "$addToSet", Value: bson.D{
+				{Key: "refunded_out_refund_nos", Value: outRefundNo},
+			}})
+		}
+		update = append(update, bson.E{Key: "$inc", Value: inc})
+
+		var result *mongo.UpdateResult
+		eventType := ""
+		if impl.enableOutbox {
+			eventType = OutboxEventTypeOf(order.CurrentState(), state, status)
+		}
+		if len(eventType) > 0 {
+			result, err = impl.updatePlatformOrderWithOutboxEvent(ctx, filter, update,
+				newOutboxEvent(order, eventType, state, status, refundedAmount, ct), comment)
+		} else {
+			result, err = impl.collections[PlatformOrderCollection].UpdateOne(
+				ctx,
+				filter,
+				update,
+				options.Update().SetComment(comment),
+			)
+		}
+		if err != nil {
+			return
+		}
+		if result.MatchedCount > 0 {
+			if order.CurrentState() == OrderStateClosed && state == OrderStatePaid {
+				// 已关闭的平台订单被支付, 以 Error 级别上报, 由 Sentry 告警, 需要人工退款或补发
+				impl.logger.
+					WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+					WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+					WithField(common.LoggerKeyEvent, "TransitPlatformOrder").
+					Errorf("closed platform-order(trade-id:%s) was paid", tradeId)
+			}
+			// 记录状态迁移, 写入失败不影响已完成的状态迁移
+			_ = impl.AddOrderEvent(ctx, newOrderEvent(ctx, tradeId,
+				order.CurrentState(), order.Status, state, status, order.Version+1, ct))
+			return
+		}
+	}
+	err = ErrConcurrentUpdate
+
+	return
+}
+
+// SavePlatformOrderPrepayId 保存微信预支付订单标识及其创建时间, 不改变平台订单状态.
+func (impl *MongoClientConnPool) SavePlatformOrderPrepayId(ctx context.Context, tradeId, prepayId string) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "SavePlatformOrderPrepayId")
+
 	if _, err = impl.collections[PlatformOrderCollection].UpdateOne(
 		ctx,
 		bson.M{"trade_id": tradeId},
 		bson.D{{Key: "$set", Value: bson.D{
-			{Key: "status", Value: status},
+			{Key: "prepay_id", Value: prepayId},
+			{Key: "prepay_time", Value: time.Now().Unix()},
 			{Key: "update_time", Value: time.Now().Unix()},
 		}}},
 		options.Update().
-			SetComment("service.wechat_pay_backend_service.storage.mongo.method.update_platform_order"),
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.save_platform_order_prepay_id"),
 	); err != nil {
 		logger.WithError(err).Errorf(
-			"failed to update platform-order(trade-id:%s)",
+			"failed to save prepay-id of platform-order(trade-id:%s)",
 			tradeId,
 		)
 		return
 	} else {
 		logger.Infof(
-			"update platform-order(trade-id:%s)",
+			"save prepay-id of platform-order(trade-id:%s)",
+			tradeId,
+		)
+	}
+
+	return
+}
+
+// SavePlatformOrderTransaction 回写微信支付订单号和支付完成时间, 不改变平台订单状态.
+func (impl *MongoClientConnPool) SavePlatformOrderTransaction(ctx context.Context, tradeId, transactionId, successTime string) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "SavePlatformOrderTransaction")
+
+	if _, err = impl.collections[PlatformOrderCollection].UpdateOne(
+		ctx,
+		bson.M{"trade_id": tradeId},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "transaction_id", Value: transactionId},
+			{Key: "success_time", Value: successTime},
+			{Key: "update_time", Value: time.Now().Unix()},
+		}}},
+		options.Update().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.save_platform_order_transaction"),
+	); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to save transaction of platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	} else {
+		logger.Infof(
+			"save transaction of platform-order(trade-id:%s)",
+			tradeId,
+		)
+	}
+
+	return
+}
+
+func (impl *MongoClientConnPool) IncrPlatformOrderNotifyRedelivery(ctx context.Context, tradeId string) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "IncrPlatformOrderNotifyRedelivery")
+
+	// 累加平台订单收到的重复支付通知次数
+	if _, err = impl.collections[PlatformOrderCollection].UpdateOne(
+		ctx,
+		bson.M{"trade_id": tradeId},
+		bson.D{
+			{Key: "$inc", Value: bson.D{{Key: "notify_redelivery_count", Value: 1}}},
+			{Key: "$set", Value: bson.D{{Key: "update_time", Value: time.Now().Unix()}}},
+		},
+		options.Update().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.incr_platform_order_notify_redelivery"),
+	); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to increase notify-redelivery-count of platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	} else {
+		logger.Infof(
+			"increase notify-redelivery-count of platform-order(trade-id:%s)",
+			tradeId,
+		)
+	}
+
+	return
+}
+
+func (impl *MongoClientConnPool) GetPlatformOrder(ctx context.Context, tradeId string) (
+	order *PlatformOrderModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "GetPlatformOrder")
+
+	// 查询平台订单
+	order = &PlatformOrderModel{}
+	if err = impl.collections[PlatformOrderCollection].FindOne(
+		ctx,
+		bson.M{"trade_id": tradeId},
+		options.FindOne().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.get_platform_order"),
+	).Decode(order); err != nil {
+		order = nil
+		if err == mongo.ErrNoDocuments {
+			err = ErrRecordNotFound
+			return
+		}
+		logger.WithError(err).Errorf(
+			"failed to get platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	}
+
+	return
+}
+
+// ListPlatformOrders 按过滤条件分页查询平台订单, 按创建顺序从晚到早排序.
+// 分页游标为上一页最后一条平台订单的ObjectID, 返回的 nextCursor 为空表示没有更多数据.
+func (impl *MongoClientConnPool) ListPlatformOrders(ctx context.Context, filter *PlatformOrderFilter, cursor string, limit int64) (
+	orders []*PlatformOrderModel, nextCursor string, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListPlatformOrders")
+
+	query := bson.M{}
+	if len(filter.AppId) > 0 {
+		query["app_id"] = filter.AppId
+	}
+	if len(filter.PayerUid) > 0 {
+		query["payer_uid"] = filter.PayerUid
+	}
+	if len(filter.StateList) > 0 {
+		query["$or"] = orderStateListQuery(filter.StateList)
+	}
+	if len(filter.StatusList) > 0 {
+		query["status"] = bson.M{"$in": filter.StatusList}
+	}
+	createTime := bson.M{}
+	if filter.CreateTimeBegin > 0 {
+		createTime["$gte"] = filter.CreateTimeBegin
+	}
+	if filter.CreateTimeEnd > 0 {
+		createTime["$lt"] = filter.CreateTimeEnd
+	}
+	if len(createTime) > 0 {
+		query["create_time"] = createTime
+	}
+	if len(cursor) > 0 {
+		var lastId primitive.ObjectID
+		if lastId, err = primitive.ObjectIDFromHex(cursor); err != nil {
+			err = ErrInvalidCursor
+			return
+		}
+		query["_id"] = bson.M{"$lt": lastId}
+	}
+
+	// 多取一条用于判断是否还有下一页
+	c, err := impl.collections[PlatformOrderCollection].Find(
+		ctx,
+		query,
+		options.Find().
+			SetSort(bson.D{{Key: "_id", Value: -1}}).
+			SetLimit(limit+1).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.list_platform_orders"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to list platform-orders")
+		return
+	}
+	orders = make([]*PlatformOrderModel, 0, limit+1)
+	if err = c.All(ctx, &orders); err != nil {
+		logger.WithError(err).Error(
+			"failed to decode platform-orders")
+		return
+	}
+	if int64(len(orders)) > limit {
+		orders = orders[:limit]
+		nextCursor = orders[limit-1].Id.Hex()
+	}
+
+	return
+}
+
+// orderStateListQuery 返回匹配 states 中任一业务状态的查询条件, 早期保存的平台订单没有业务状态字段, 按处理步骤匹配.
+func orderStateListQuery(states []string) bson.A {
+	legacyState := bson.M{"$in": bson.A{nil, ""}}
+	query := bson.A{
+		bson.M{"state": bson.M{"$in": states}},
+		bson.M{"state": legacyState, "status": bson.M{"$in": legacyPaymentStatusesOf(states)}},
+	}
+	for _, state := range states {
+		switch state {
+		case OrderStatePaid:
+			query = append(query, bson.M{"state": legacyState, "status": PaymentStatusRefundClosed,
+				"refunded_amount_total": bson.M{"$not": bson.M{"$gt": 0}}})
+		case OrderStateRefunded:
+			query = append(query, bson.M{"state": legacyState, "status": PaymentStatusRefundClosed,
+				"refunded_amount_total": bson.M{"$gt": 0}})
+		}
+	}
+	return query
+}
+
+func (impl *MongoClientConnPool) ListExpiredPlatformOrders(ctx context.Context, expireBefore int64, limit int64) (
+	orders []*PlatformOrderModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListExpiredPlatformOrders")
+
+	// 查询已过期且到了下次检查时间的未支付平台订单, 按过期时间从早到晚排序
+	cursor, err := impl.collections[PlatformOrderCollection].Find(
+		ctx,
+		bson.M{
+			"status":          bson.M{"$in": PaymentStatusUnpaidList},
+			"expire_time":     bson.M{"$lt": expireBefore},
+			"next_check_time": bson.M{"$not": bson.M{"$gt": time.Now().Unix()}},
+		},
+		options.Find().
+			SetSort(bson.D{{Key: "expire_time", Value: 1}}).
+			SetLimit(limit).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.list_expired_platform_orders"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to list expired platform-orders")
+		return
+	}
+	orders = make([]*PlatformOrderModel, 0)
+	if err = cursor.All(ctx, &orders); err != nil {
+		logger.WithError(err).Error(
+			"failed to decode expired platform-orders")
+		return
+	}
+
+	return
+}
+
+func (impl *MongoClientConnPool) ListStuckPlatformOrders(ctx context.Context, updateBefore int64, limit int64) (
+	orders []*PlatformOrderModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListStuckPlatformOrders")
+
+	// 查询长时间停留在中间状态且到了下次检查时间的平台订单, 按更新时间从早到晚排序
+	cursor, err := impl.collections[PlatformOrderCollection].Find(
+		ctx,
+		bson.M{
+			"status":          bson.M{"$in": PaymentStatusIntermediateList},
+			"update_time":     bson.M{"$lt": updateBefore},
+			"next_check_time": bson.M{"$not": bson.M{"$gt": time.Now().Unix()}},
+		},
+		options.Find().
+			SetSort(bson.D{{Key: "update_time", Value: 1}}).
+			SetLimit(limit).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.list_stuck_platform_orders"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to list stuck platform-orders")
+		return
+	}
+	orders = make([]*PlatformOrderModel, 0)
+	if err = cursor.All(ctx, &orders); err != nil {
+		logger.WithError(err).Error(
+			"failed to decode stuck platform-orders")
+		return
+	}
+
+	return
+}
+
+// DeferPlatformOrderCheck 记录后台任务检查了一次平台订单, 并将下次检查推迟到 nextCheckTime, 不改变平台订单状态.
+// NOTE: 始终无法处理的平台订单(如微信支付接口持续报错)被推迟后, 不会占满后台任务每一轮的批次.
+func (impl *MongoClientConnPool) DeferPlatformOrderCheck(ctx context.Context, tradeId string, nextCheckTime int64) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "DeferPlatformOrderCheck")
+
+	if _, err = impl.collections[PlatformOrderCollection].UpdateOne(
+		ctx,
+		bson.M{"trade_id": tradeId},
+		bson.D{
+			{Key: "$set", Value: bson.D{{Key: "next_check_time", Value: nextCheckTime}}},
+			{Key: "$inc", Value: bson.D{{Key: "check_attempts", Value: 1}}},
+		},
+		options.Update().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.defer_platform_order_check"),
+	); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to defer check of platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	}
+
+	return
+}
+
+// ApplyPlatformOrderRefund 使用退款单的结果更新平台订单, 该更新是幂等的, 同一退款单的退款金额只累加一次.
+func (impl *MongoClientConnPool) ApplyPlatformOrderRefund(ctx context.Context, tradeId, outRefundNo string, status int, refundedAmount int64) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ApplyPlatformOrderRefund")
+
+	// 更新平台订单退款状态, 退款成功时累加已退款总额
+	if err = impl.transitPlatformOrder(ctx, tradeId, status, outRefundNo, refundedAmount,
+		"service.wechat_pay_backend_service.storage.mongo.method.apply_platform_order_refund"); err != nil {
+		if err == ErrIllegalStateTransition {
+			logger.Warnf(
+				"reject to apply refund(status:%d) to platform-order(trade-id:%s)",
+				status, tradeId,
+			)
+			return
+		}
+		logger.WithError(err).Errorf(
+			"failed to apply refund to platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	} else {
+		logger.Infof(
+			"apply refund to platform-order(trade-id:%s)",
 			tradeId,
 		)
 	}
@@ -0,0 +1,162 @@
+package extmongo
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	ErrIllegalStateTransition = errors.New("ext_mongo: illegal state transition")
+	ErrConcurrentUpdate       = errors.New("ext_mongo: concurrent update")
+)
+
+// 平台订单业务状态, 与记录处理步骤的 PaymentStatus 相互独立.
+const (
+	OrderStateCreated   = "CREATED"
+	OrderStatePrepaid   = "PREPAID"
+	OrderStatePaid      = "PAID"
+	OrderStateClosed    = "CLOSED"
+	OrderStateRefunding = "REFUNDING"
+	OrderStateRefunded  = "REFUNDED"
+	OrderStateFailed    = "FAILED"
+)
+
+// 各处理步骤推进到的业务状态.
+// NOTE: 关闭平台订单失败、退款关闭不改变(或依赖当前订单决定)业务状态, 由 NextOrderState 单独处理.
+var paymentStatusOrderStates = map[int]string{
+	PaymentStatusCreatePlatformOrder:          OrderStateCreated,
+	PaymentStatusCreatePlatformOrderFailed:    OrderStateFailed,
+	PaymentStatusCreateWXPrepayOrder:          OrderStateCreated,
+	PaymentStatusCreateWXPrepayOrderFailed:    OrderStateFailed,
+	PaymentStatusGetPrepayId:                  OrderStatePrepaid,
+	PaymentStatusCreatePaymentSignature:       OrderStatePrepaid,
+	PaymentStatusCreatePaymentSignatureFailed: OrderStatePrepaid,
+	PaymentStatusClosePlatformOrder:           OrderStateClosed,
+	PaymentStatusRecvAsyncNotification:        OrderStatePaid,
+	PaymentStatusStoreAsyncNotification:       OrderStatePaid,
+	PaymentStatusStoreAsyncNotificationFailed: OrderStatePaid,
+	PaymentStatusAckAsyncNotification:         OrderStatePaid,
+	PaymentStatusRefundSuccess:                OrderStateRefunded,
+	PaymentStatusRefundAbnormal:               OrderStateRefunding,
+	PaymentStatusRefundProcessing:             OrderStateRefunding,
+}
+
+// 合法的业务状态迁移, 同一业务状态内推进处理步骤总是允许的(受 CheckOrderTransition 中的步骤顺序约束).
+// NOTE: 关单与用户支付并发时, 已关闭的平台订单仍可能收到经过校验的支付成功结果, 此时必须记录为已支付以便退款.
+var orderStateTransitions = map[string][]string{
+	OrderStateCreated:   {OrderStatePrepaid, OrderStatePaid, OrderStateClosed, OrderStateFailed},
+	OrderStatePrepaid:   {OrderStatePaid, OrderStateClosed},
+	OrderStateFailed:    {OrderStatePaid, OrderStateClosed},
+	OrderStatePaid:      {OrderStateRefunding, OrderStateRefunded},
+	OrderStateRefunding: {OrderStatePaid, OrderStateRefunded},
+	OrderStateRefunded:  {OrderStateRefunding},
+	OrderStateClosed:    {OrderStatePaid},
+}
+
+// 退款相关业务状态的处理步骤由微信支付退款结果驱动, 不要求步骤单调推进.
+var orderStatesWithoutStepOrder = map[string]bool{
+	OrderStateRefunding: true,
+	OrderStateRefunded:  true,
+}
+
+// CurrentState 返回平台订单当前的业务状态, 早期保存的平台订单没有业务状态字段, 由处理步骤推导.
+func (order *PlatformOrderModel) CurrentState() string {
+	if len(order.State) > 0 {
+		return order.State
+	}
+	if state, ok := paymentStatusOrderStates[order.Status]; ok {
+		return state
+	}
+	if order.Status == PaymentStatusRefundClosed {
+		if order.RefundedAmountTotal > 0 {
+			return OrderStateRefunded
+		}
+		return OrderStatePaid
+	}
+	// 关闭平台订单失败的订单仍未支付
+	return OrderStatePrepaid
+}
+
+// legacyPaymentStatusesOf 返回早期保存的平台订单(没有业务状态字段)推导出 states 中任一业务状态的处理步骤, 与 CurrentState 保持一致.
+// NOTE: 退款关闭推导出的业务状态依赖已退款总额, 不在返回结果中, 由调用方单独处理
+func legacyPaymentStatusesOf(states []string) (statuses []int) {
+	for status := PaymentStatusCreatePlatformOrder; status <= PaymentStatusRefundProcessing; status++ {
+		if status == PaymentStatusRefundClosed {
+			continue
+		}
+		state := (&PlatformOrderModel{Status: status}).CurrentState()
+		for _, s := range states {
+			if s == state {
+				statuses = append(statuses, status)
+				break
+			}
+		}
+	}
+	return
+}
+
+// IsPaymentSettled 返回平台订单的支付结果是否已经确定(已支付/已关闭/退款中/已退款), 支付结果确定后无需再查询微信支付.
+func (order *PlatformOrderModel) IsPaymentSettled() bool {
+	switch order.CurrentState() {
+	case OrderStatePaid, OrderStateRefunding, OrderStateRefunded, OrderStateClosed:
+		return true
+	}
+	return false
+}
+
+// 可以跟踪履约并在履约超时后自动退款的业务状态, 已部分退款的平台订单仍需退款剩余的金额.
+var fulfillmentRefundableStates = bson.A{OrderStatePaid, OrderStateRefunding, OrderStateRefunded}
+
+// IsFulfillmentRefundable 返回平台订单是否已支付且尚未全额退款, 只有这样的平台订单会被标记履约超时和自动退款.
+func (order *PlatformOrderModel) IsFulfillmentRefundable() bool {
+	switch order.CurrentState() {
+	case OrderStatePaid, OrderStateRefunding, OrderStateRefunded:
+		return order.RefundedAmountTotal < order.ItemAmountTotal
+	}
+	return false
+}
+
+// IsPaymentAccepted 返回平台订单从 fromState 推进到 toState 是否表示支付成功, 退款关闭回到已支付不算作支付成功.
+func IsPaymentAccepted(fromState, toState string) bool {
+	return toState == OrderStatePaid && fromState != OrderStatePaid &&
+		fromState != OrderStateRefunding && fromState != OrderStateRefunded
+}
+
+// NextOrderState 返回平台订单推进到处理步骤 status 后的业务状态.
+func NextOrderState(order *PlatformOrderModel, status int, refundedAmount int64) string {
+	switch status {
+	case PaymentStatusClosePlatformOrderFailed:
+		return order.CurrentState()
+	case PaymentStatusRefundClosed:
+		if order.RefundedAmountTotal+refundedAmount > 0 {
+			return OrderStateRefunded
+		}
+		return OrderStatePaid
+	}
+	return paymentStatusOrderStates[status]
+}
+
+// CheckOrderTransition 校验平台订单能否从(fromState, fromStatus)推进到(toState, toStatus).
+// 同一业务状态内处理步骤不允许回退, 例如已返回预付单标识后, 迟到的"请求下单接口"写入会被拒绝.
+// 退款中的平台订单只有在退款关闭时才能回到已支付, 迟到的支付通知不能使退款中的平台订单回退.
+func CheckOrderTransition(fromState string, fromStatus int, toState string, toStatus int) error {
+	if len(toState) == 0 {
+		return ErrIllegalStateTransition
+	}
+	if fromState == OrderStateRefunding && toState == OrderStatePaid && toStatus != PaymentStatusRefundClosed {
+		return ErrIllegalStateTransition
+	}
+	if fromState == toState {
+		if !orderStatesWithoutStepOrder[fromState] && toStatus < fromStatus {
+			return ErrIllegalStateTransition
+		}
+		return nil
+	}
+	for _, state := range orderStateTransitions[fromState] {
+		if state == toState {
+			return nil
+		}
+	}
+	return ErrIllegalStateTransition
+}
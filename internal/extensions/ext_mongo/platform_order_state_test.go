@@ -0,0 +1,123 @@
+package extmongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCheckOrderTransition(t *testing.T) {
+	paid := &PlatformOrderModel{State: OrderStatePaid, Status: PaymentStatusAckAsyncNotification}
+	// 迟到的生成支付签名写入不能使已支付的平台订单回退
+	state := NextOrderState(paid, PaymentStatusCreatePaymentSignature, 0)
+	assert.Equal(t, OrderStatePrepaid, state)
+	assert.Equal(t, ErrIllegalStateTransition,
+		CheckOrderTransition(paid.CurrentState(), paid.Status, state, PaymentStatusCreatePaymentSignature))
+	// 同一业务状态内处理步骤不能回退
+	assert.Equal(t, ErrIllegalStateTransition,
+		CheckOrderTransition(OrderStatePaid, PaymentStatusAckAsyncNotification,
+			OrderStatePaid, PaymentStatusRecvAsyncNotification))
+	assert.Nil(t, CheckOrderTransition(OrderStatePaid, PaymentStatusStoreAsyncNotificationFailed,
+		OrderStatePaid, PaymentStatusAckAsyncNotification))
+	// 关闭平台订单失败不改变业务状态
+	prepaid := &PlatformOrderModel{Status: PaymentStatusCreatePaymentSignature}
+	assert.Equal(t, OrderStatePrepaid, prepaid.CurrentState())
+	assert.Equal(t, OrderStatePrepaid, NextOrderState(prepaid, PaymentStatusClosePlatformOrderFailed, 0))
+	assert.Nil(t, CheckOrderTransition(OrderStatePrepaid, PaymentStatusCreatePaymentSignature,
+		OrderStateClosed, PaymentStatusClosePlatformOrder))
+	// 已关闭的平台订单不能回到未支付, 但可以记录经过校验的支付成功结果
+	assert.Equal(t, ErrIllegalStateTransition,
+		CheckOrderTransition(OrderStateClosed, PaymentStatusClosePlatformOrder,
+			OrderStatePrepaid, PaymentStatusGetPrepayId))
+	assert.Nil(t, CheckOrderTransition(OrderStateClosed, PaymentStatusClosePlatformOrder,
+		OrderStatePaid, PaymentStatusRecvAsyncNotification))
+	// 退款关闭后按已退款总额回到已支付或已退款
+	refunding := &PlatformOrderModel{State: OrderStateRefunding, Status: PaymentStatusRefundProcessing}
+	assert.Equal(t, OrderStatePaid, NextOrderState(refunding, PaymentStatusRefundClosed, 0))
+	refunding.RefundedAmountTotal = 100
+	assert.Equal(t, OrderStateRefunded, NextOrderState(refunding, PaymentStatusRefundClosed, 0))
+	assert.Nil(t, CheckOrderTransition(OrderStateRefunding, PaymentStatusRefundProcessing,
+		OrderStateRefunding, PaymentStatusRefundAbnormal))
+	// 退款中的平台订单只有退款关闭时才能回到已支付, 迟到的支付通知不能使其回退
+	assert.Nil(t, CheckOrderTransition(OrderStateRefunding, PaymentStatusRefundProcessing,
+		OrderStatePaid, PaymentStatusRefundClosed))
+	assert.Equal(t, ErrIllegalStateTransition,
+		CheckOrderTransition(OrderStateRefunding, PaymentStatusRefundProcessing,
+			OrderStatePaid, PaymentStatusAckAsyncNotification))
+}
+
+func TestIsPaymentAccepted(t *testing.T) {
+	assert.True(t, IsPaymentAccepted(OrderStatePrepaid, OrderStatePaid))
+	assert.True(t, IsPaymentAccepted(OrderStateFailed, OrderStatePaid))
+	assert.True(t, IsPaymentAccepted(OrderStateClosed, OrderStatePaid))
+	// 重复的支付通知/退款关闭回到已支付不重新计算确认履约的期限
+	assert.False(t, IsPaymentAccepted(OrderStatePaid, OrderStatePaid))
+	assert.False(t, IsPaymentAccepted(OrderStateRefunding, OrderStatePaid))
+}
+
+func TestLegacyPaymentStatusesOf(t *testing.T) {
+	assert.Equal(t, []int{PaymentStatusClosePlatformOrder}, legacyPaymentStatusesOf([]string{OrderStateClosed}))
+	assert.Equal(t, []int{PaymentStatusRefundSuccess, PaymentStatusRefundAbnormal, PaymentStatusRefundProcessing},
+		legacyPaymentStatusesOf([]string{OrderStateRefunding, OrderStateRefunded}))
+	// 与 CurrentState 由处理步骤推导的业务状态保持一致
+	for _, state := range []string{OrderStateCreated, OrderStatePrepaid, OrderStatePaid, OrderStateClosed,
+		OrderStateRefunding, OrderStateRefunded, OrderStateFailed} {
+		statuses := legacyPaymentStatusesOf([]string{state})
+		for status := PaymentStatusCreatePlatformOrder; status <= PaymentStatusRefundProcessing; status++ {
+			if status == PaymentStatusRefundClosed {
+				assert.NotContains(t, statuses, status)
+				continue
+			}
+			order := &PlatformOrderModel{Status: status}
+			assert.Equal(t, order.CurrentState() == state, contains(statuses, status),
+				"state:%s status:%d", state, status)
+		}
+	}
+}
+
+func contains(statuses []int, status int) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOutboxEventTypeOf(t *testing.T) {
+	assert.Equal(t, OutboxEventTypePaymentSucceeded,
+		OutboxEventTypeOf(OrderStatePrepaid, OrderStatePaid, PaymentStatusRecvAsyncNotification))
+	assert.Equal(t, "",
+		OutboxEventTypeOf(OrderStatePaid, OrderStatePaid, PaymentStatusAckAsyncNotification))
+	assert.Equal(t, "",
+		OutboxEventTypeOf(OrderStateRefunding, OrderStatePaid, PaymentStatusRefundClosed))
+	assert.Equal(t, OutboxEventTypePaymentClosed,
+		OutboxEventTypeOf(OrderStatePrepaid, OrderStateClosed, PaymentStatusClosePlatformOrder))
+	assert.Equal(t, OutboxEventTypeRefundSucceeded,
+		OutboxEventTypeOf(OrderStateRefunded, OrderStateRefunded, PaymentStatusRefundSuccess))
+}
+
+func TestFulfillmentRefundable(t *testing.T) {
+	order := &PlatformOrderModel{State: OrderStatePaid, ItemAmountTotal: 100}
+	assert.True(t, order.IsFulfillmentRefundable())
+	// 部分退款后仍需退款剩余的金额
+	order.State, order.RefundedAmountTotal = OrderStateRefunded, 40
+	assert.True(t, order.IsFulfillmentRefundable())
+	order.State = OrderStateRefunding
+	assert.True(t, order.IsFulfillmentRefundable())
+	// 已全额退款
+	order.State, order.RefundedAmountTotal = OrderStateRefunded, 100
+	assert.False(t, order.IsFulfillmentRefundable())
+	// 尚未支付或已关闭
+	order.RefundedAmountTotal = 0
+	for _, state := range []string{OrderStateCreated, OrderStatePrepaid, OrderStateClosed, OrderStateFailed} {
+		order.State = state
+		assert.False(t, order.IsFulfillmentRefundable())
+	}
+
+	// 标记履约超时和查询待自动退款的平台订单使用相同的条件, 包含已部分退款的平台订单
+	query := fulfillmentRefundableQuery()
+	assert.Equal(t, bson.M{"$in": bson.A{OrderStatePaid, OrderStateRefunding, OrderStateRefunded}}, query["state"])
+	assert.Equal(t, bson.M{"$lt": bson.A{"$refunded_amount_total", "$item_amount_total"}}, query["$expr"])
+}
@@ -0,0 +1,42 @@
+package extmongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RefundCollection = "refunds"
+)
+
+const (
+	// 创建退款单
+	RefundStatusCreated = iota
+	// 申请退款失败
+	RefundStatusCreateFailed
+	// 退款处理中
+	RefundStatusProcessing
+	// 退款成功
+	RefundStatusSuccess
+	// 退款异常
+	RefundStatusAbnormal
+	// 退款关闭
+	RefundStatusClosed
+)
+
+type RefundModel struct {
+	Id                  primitive.ObjectID `bson:"_id,omitempty"`
+	MchId               string             `bson:"merchant_id"`
+	TradeId             string             `bson:"trade_id"`
+	TransactionId       string             `bson:"transaction_id"`
+	OutRefundNo         string             `bson:"out_refund_no"`
+	RefundId            string             `bson:"refund_id"`
+	Reason              string             `bson:"reason"`
+	AmountTotal         int64              `bson:"amount_total"`
+	AmountRefund        int64              `bson:"amount_refund"`
+	Status              int                `bson:"status"`
+	WxRefundStatus      string             `bson:"wx_refund_status"`
+	UserReceivedAccount string             `bson:"user_received_account"`
+	SuccessTime         string             `bson:"success_time"`
+	CreateTime          int64              `bson:"create_time"`
+	UpdateTime          int64              `bson:"update_time"`
+}
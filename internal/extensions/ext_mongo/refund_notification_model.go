@@ -0,0 +1,29 @@
+package extmongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RefundNotificationCollection = "refund_notifications"
+)
+
+type RefundNotificationModel struct {
+	Id                          primitive.ObjectID `bson:"_id,omitempty"`
+	NotifyId                    string             `bson:"notify_id"`
+	CreateTime                  string             `bson:"create_time"`
+	EventType                   string             `bson:"event_type"`
+	ResourceMchId               string             `bson:"resource_mchid"`
+	TradeId                     string             `bson:"trade_id"`
+	ResourceTransactionId       string             `bson:"resource_transaction_id"`
+	OutRefundNo                 string             `bson:"out_refund_no"`
+	ResourceRefundId            string             `bson:"resource_refund_id"`
+	ResourceRefundStatus        string             `bson:"resource_refund_status"`
+	ResourceSuccessTime         string             `bson:"resource_success_time"`
+	ResourceUserReceivedAccount string             `bson:"resource_user_received_account"`
+	ResourceAmountTotal         int                `bson:"resource_amount_total"`
+	ResourceAmountRefund        int                `bson:"resource_amount_refund"`
+	ResourceAmountPayerTotal    int                `bson:"resource_amount_payer_total"`
+	ResourceAmountPayerRefund   int                `bson:"resource_amount_payer_refund"`
+	Summary                     string             `bson:"summary"`
+}
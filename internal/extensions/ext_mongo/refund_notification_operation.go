@@ -0,0 +1,75 @@
+package extmongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+func (impl *MongoClientConnPool) AddRefundNotification(ctx context.Context, notification *RefundNotificationModel) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "AddRefundNotification")
+
+	// 新建一条来自微信的退款通知
+	_, err = impl.collections[RefundNotificationCollection].InsertOne(
+		ctx,
+		notification,
+		options.InsertOne().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.add_refund_notification"),
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			logger.Warn("refund-notification has been inserted before")
+			err = ErrDuplicateRecord
+			return
+		}
+		logger.WithError(err).Error(
+			"failed to insert one new refund-notification")
+		return
+	} else {
+		logger.Info(
+			"insert one new refund-notification")
+	}
+
+	return
+}
+
+func (impl *MongoClientConnPool) HasRefundNotification(ctx context.Context, notifyId, refundId, refundStatus string) (
+	existed bool, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "HasRefundNotification")
+
+	// 按通知ID或同一退款单的同一退款状态查找已保存的退款通知
+	var cnt int64
+	cnt, err = impl.collections[RefundNotificationCollection].CountDocuments(
+		ctx,
+		bson.M{"$or": bson.A{
+			bson.M{"notify_id": notifyId},
+			bson.M{"resource_refund_id": refundId, "resource_refund_status": refundStatus},
+		}},
+		options.Count().
+			SetLimit(1).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.has_refund_notification"),
+	)
+	if err != nil {
+		logger.WithError(err).Errorf(
+			"failed to count refund-notification(notify-id:%s)",
+			notifyId,
+		)
+		return
+	}
+	existed = cnt > 0
+
+	return
+}
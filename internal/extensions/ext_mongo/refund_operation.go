@@ -0,0 +1,193 @@
+package extmongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+// ApplyRefundResult 使用微信退款结果更新退款单, 退款单不存在时(如在商户平台手动退款)则新建.
+func (impl *MongoClientConnPool) ApplyRefundResult(ctx context.Context, refund *RefundModel) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ApplyRefundResult")
+
+	ut := time.Now().Unix()
+	if _, err = impl.collections[RefundCollection].UpdateOne(
+		ctx,
+		bson.M{"out_refund_no": refund.OutRefundNo},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "merchant_id", Value: refund.MchId},
+				{Key: "trade_id", Value: refund.TradeId},
+				{Key: "transaction_id", Value: refund.TransactionId},
+				{Key: "refund_id", Value: refund.RefundId},
+				{Key: "amount_total", Value: refund.AmountTotal},
+				{Key: "amount_refund", Value: refund.AmountRefund},
+				{Key: "status", Value: refund.Status},
+				{Key: "wx_refund_status", Value: refund.WxRefundStatus},
+				{Key: "user_received_account", Value: refund.UserReceivedAccount},
+				{Key: "success_time", Value: refund.SuccessTime},
+				{Key: "update_time", Value: ut},
+			}},
+			{Key: "$setOnInsert", Value: bson.D{
+				{Key: "create_time", Value: ut},
+			}},
+		},
+		options.Update().
+			SetUpsert(true).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.apply_refund_result"),
+	); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to update refund(out-refund-no:%s)",
+			refund.OutRefundNo,
+		)
+		return
+	} else {
+		logger.Infof(
+			"update refund(out-refund-no:%s)",
+			refund.OutRefundNo,
+		)
+	}
+
+	return
+}
+
+func (impl *MongoClientConnPool) AddRefund(ctx context.Context, refund *RefundModel) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "AddRefund")
+
+	// 新建一条退款单
+	_, err = impl.collections[RefundCollection].InsertOne(
+		ctx,
+		refund,
+		options.InsertOne().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.add_refund"),
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = ErrDuplicateRecord
+			return
+		}
+		logger.WithError(err).Error(
+			"failed to insert one new refund")
+		return
+	} else {
+		logger.Info(
+			"insert one new refund")
+	}
+
+	return
+}
+
+func (impl *MongoClientConnPool) GetRefund(ctx context.Context, outRefundNo string) (
+	refund *RefundModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "GetRefund")
+
+	// 查询退款单
+	refund = &RefundModel{}
+	if err = impl.collections[RefundCollection].FindOne(
+		ctx,
+		bson.M{"out_refund_no": outRefundNo},
+		options.FindOne().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.get_refund"),
+	).Decode(refund); err != nil {
+		refund = nil
+		if err == mongo.ErrNoDocuments {
+			err = ErrRecordNotFound
+			return
+		}
+		logger.WithError(err).Errorf(
+			"failed to get refund(out-refund-no:%s)",
+			outRefundNo,
+		)
+		return
+	}
+
+	return
+}
+
+func (impl *MongoClientConnPool) ListRefunds(ctx context.Context, tradeId string) (
+	refunds []*RefundModel, err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListRefunds")
+
+	// 查询平台订单的全部退款单
+	cursor, err := impl.collections[RefundCollection].Find(
+		ctx,
+		bson.M{"trade_id": tradeId},
+		options.Find().
+			SetSort(bson.D{{Key: "create_time", Value: 1}}).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.list_refunds"),
+	)
+	if err != nil {
+		logger.WithError(err).Errorf(
+			"failed to list refunds of platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	}
+	refunds = make([]*RefundModel, 0)
+	if err = cursor.All(ctx, &refunds); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to decode refunds of platform-order(trade-id:%s)",
+			tradeId,
+		)
+		return
+	}
+
+	return
+}
+
+func (impl *MongoClientConnPool) UpdateRefundStatus(ctx context.Context, outRefundNo string, status int) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "UpdateRefundStatus")
+
+	// 更新退款单状态
+	if _, err = impl.collections[RefundCollection].UpdateOne(
+		ctx,
+		bson.M{"out_refund_no": outRefundNo},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "status", Value: status},
+			{Key: "update_time", Value: time.Now().Unix()},
+		}}},
+		options.Update().
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.update_refund_status"),
+	); err != nil {
+		logger.WithError(err).Errorf(
+			"failed to update refund(out-refund-no:%s)",
+			outRefundNo,
+		)
+		return
+	} else {
+		logger.Infof(
+			"update refund(out-refund-no:%s)",
+			outRefundNo,
+		)
+	}
+
+	return
+}
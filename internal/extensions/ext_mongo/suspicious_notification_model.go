@@ -0,0 +1,28 @@
+package extmongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	SuspiciousNotificationCollection = "suspicious_notifications"
+)
+
+const (
+	// 平台订单不存在
+	SuspiciousReasonUnknownTradeId = "UNKNOWN_TRADE_ID"
+	// 通知信息与平台订单不一致
+	SuspiciousReasonMismatch = "MISMATCH"
+)
+
+type SuspiciousNotificationModel struct {
+	Id                    primitive.ObjectID `bson:"_id,omitempty"`
+	NotifyId              string             `bson:"notify_id"`
+	EventType             string             `bson:"event_type"`
+	TradeId               string             `bson:"trade_id"`
+	ResourceTransactionId string             `bson:"resource_transaction_id"`
+	ResourcePlaintext     string             `bson:"resource_plaintext"`
+	Reason                string             `bson:"reason"`
+	MismatchedFields      []string           `bson:"mismatched_fields"`
+	CreateTime            int64              `bson:"create_time"`
+}
@@ -0,0 +1,49 @@
+package extmongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+// AddSuspiciousNotification 记录一条可疑的支付通知, 同一通知(或同一主动查询结果)因同一原因只记录一次,
+// 已经记录过时返回 ErrDuplicateRecord.
+func (impl *MongoClientConnPool) AddSuspiciousNotification(ctx context.Context, notification *SuspiciousNotificationModel) (
+	err error) {
+
+	logger := impl.logger.
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "AddSuspiciousNotification")
+
+	// 新建一条可疑的支付通知, 主动查询合成的支付通知没有通知ID, 按平台订单和微信支付订单号去重
+	result, err := impl.collections[SuspiciousNotificationCollection].UpdateOne(
+		ctx,
+		bson.M{
+			"notify_id":               notification.NotifyId,
+			"trade_id":                notification.TradeId,
+			"resource_transaction_id": notification.ResourceTransactionId,
+			"reason":                  notification.Reason,
+		},
+		bson.D{{Key: "$setOnInsert", Value: notification}},
+		options.Update().
+			SetUpsert(true).
+			SetComment("service.wechat_pay_backend_service.storage.mongo.method.add_suspicious_notification"),
+	)
+	if err != nil {
+		logger.WithError(err).Error(
+			"failed to insert one new suspicious-notification")
+		return
+	}
+	if result.UpsertedCount == 0 {
+		err = ErrDuplicateRecord
+		return
+	}
+	logger.Info(
+		"insert one new suspicious-notification")
+
+	return
+}
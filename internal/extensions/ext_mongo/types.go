@@ -1,6 +1,15 @@
 package extmongo
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrRecordNotFound  = errors.New("ext_mongo: record not found")
+	ErrDuplicateRecord = errors.New("ext_mongo: duplicate record")
+	ErrInvalidCursor   = errors.New("ext_mongo: invalid cursor")
+)
 
 const (
 	// 生成平台订单
@@ -29,10 +38,72 @@ const (
 	PaymentStatusStoreAsyncNotificationFailed
 	// 返回告知成功接收处理
 	PaymentStatusAckAsyncNotification
+	// 退款成功
+	PaymentStatusRefundSuccess
+	// 退款异常
+	PaymentStatusRefundAbnormal
+	// 退款关闭
+	PaymentStatusRefundClosed
+	// 退款处理中
+	PaymentStatusRefundProcessing
 )
 
+// 尚未支付且尚未关闭的平台订单状态, 超过过期时间后需要由系统关闭.
+var PaymentStatusUnpaidList = []int{
+	PaymentStatusCreatePlatformOrder,
+	PaymentStatusCreateWXPrepayOrder,
+	PaymentStatusCreateWXPrepayOrderFailed,
+	PaymentStatusGetPrepayId,
+	PaymentStatusCreatePaymentSignature,
+	PaymentStatusCreatePaymentSignatureFailed,
+	PaymentStatusClosePlatformOrderFailed,
+}
+
+// 处于中间状态的平台订单状态, 长时间停留在这些状态的平台订单需要主动查询支付结果进行补偿.
+var PaymentStatusIntermediateList = []int{
+	PaymentStatusCreatePlatformOrder,
+	PaymentStatusCreateWXPrepayOrder,
+	PaymentStatusGetPrepayId,
+	PaymentStatusCreatePaymentSignature,
+	PaymentStatusRecvAsyncNotification,
+	PaymentStatusStoreAsyncNotification,
+	PaymentStatusStoreAsyncNotificationFailed,
+}
+
 type PaymentInfoStorage interface {
 	AddPlatformOrder(ctx context.Context, order *PlatformOrderModel) (err error)
+	GetPlatformOrder(ctx context.Context, tradeId string) (order *PlatformOrderModel, err error)
 	UpdatePlatformOrder(ctx context.Context, orderId string, status int) (err error)
+	SavePlatformOrderPrepayId(ctx context.Context, tradeId, prepayId string) (err error)
+	SavePlatformOrderTransaction(ctx context.Context, tradeId, transactionId, successTime string) (err error)
+	IncrPlatformOrderNotifyRedelivery(ctx context.Context, tradeId string) (err error)
+	ListPlatformOrders(ctx context.Context, filter *PlatformOrderFilter, cursor string, limit int64) (orders []*PlatformOrderModel, nextCursor string, err error)
+	ListExpiredPlatformOrders(ctx context.Context, expireBefore int64, limit int64) (orders []*PlatformOrderModel, err error)
+	ListStuckPlatformOrders(ctx context.Context, updateBefore int64, limit int64) (orders []*PlatformOrderModel, err error)
+	DeferPlatformOrderCheck(ctx context.Context, tradeId string, nextCheckTime int64) (err error)
+	ApplyPlatformOrderRefund(ctx context.Context, tradeId, outRefundNo string, status int, refundedAmount int64) (err error)
+	ConfirmPlatformOrderFulfillment(ctx context.Context, tradeId string) (confirmTime int64, err error)
+	MarkPlatformOrderFulfillmentOverdue(ctx context.Context, tradeId, policy string) (err error)
+	SavePlatformOrderFulfillmentRefund(ctx context.Context, tradeId, outRefundNo string) (err error)
+	SavePlatformOrderFulfillmentRefundError(ctx context.Context, tradeId, reason string) (err error)
+	ListFulfillmentOverduePlatformOrders(ctx context.Context, deadlineBefore int64, limit int64) (orders []*PlatformOrderModel, err error)
+	ListFulfillmentUnrefundedPlatformOrders(ctx context.Context, limit int64) (orders []*PlatformOrderModel, err error)
 	AddPaymentNotification(ctx context.Context, notification *PaymentNotificationModel) (err error)
+	GetLatestPaymentNotifications(ctx context.Context, tradeIds []string) (notifications map[string]*PaymentNotificationModel, err error)
+	HasPaymentNotification(ctx context.Context, notifyId, transactionId string) (existed bool, err error)
+	GetPaymentNotificationByTransactionId(ctx context.Context, transactionId string) (notification *PaymentNotificationModel, err error)
+	AddSuspiciousNotification(ctx context.Context, notification *SuspiciousNotificationModel) (err error)
+	AddRefund(ctx context.Context, refund *RefundModel) (err error)
+	GetRefund(ctx context.Context, outRefundNo string) (refund *RefundModel, err error)
+	ListRefunds(ctx context.Context, tradeId string) (refunds []*RefundModel, err error)
+	UpdateRefundStatus(ctx context.Context, outRefundNo string, status int) (err error)
+	ListDueOutboxEvents(ctx context.Context, attemptBefore int64, limit int64) (events []*OutboxEventModel, err error)
+	UpdateOutboxEventDelivery(ctx context.Context, event *OutboxEventModel) (err error)
+	ListOutboxEvents(ctx context.Context, filter *OutboxEventFilter, cursor string, limit int64) (events []*OutboxEventModel, nextCursor string, err error)
+	GetOutboxEventsByTradeIds(ctx context.Context, tradeIds []string) (events map[string][]*OutboxEventModel, err error)
+	ApplyRefundResult(ctx context.Context, refund *RefundModel) (err error)
+	AddRefundNotification(ctx context.Context, notification *RefundNotificationModel) (err error)
+	HasRefundNotification(ctx context.Context, notifyId, refundId, refundStatus string) (existed bool, err error)
+	AddOrderEvent(ctx context.Context, event *OrderEventModel) (err error)
+	ListOrderEvents(ctx context.Context, tradeId string) (events []*OrderEventModel, err error)
 }
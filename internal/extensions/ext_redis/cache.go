@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"time"
 
 	redis "github.com/redis/go-redis/v9"
 )
@@ -67,6 +68,15 @@ func (impl *BigCache) SetString(
 	return impl.pool.client.Set(ctx, item.Key(), item.Value().(string), item.TTL()).Err()
 }
 
+// SetNX is like SetString but only sets the key if it does not exist yet,
+// it reports whether the key was set.
+func (impl *BigCache) SetNX(
+	ctx context.Context,
+	item *Item,
+) (bool, error) {
+	return impl.pool.client.SetNX(ctx, item.Key(), item.Value().(string), item.TTL()).Result()
+}
+
 func (impl *BigCache) SetInt(
 	ctx context.Context,
 	item *Item,
@@ -161,6 +171,16 @@ func (impl *BigCache) Incr(
 	return err
 }
 
+// IncrWithTTL is like Incr but sets the key's ttl when it is created,
+// it returns the value after the increment.
+func (impl *BigCache) IncrWithTTL(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+) (int64, error) {
+	return incrWithTTLLuaScript.Run(ctx, impl.pool.client, []string{key}, ttl.Milliseconds()).Int64()
+}
+
 func (impl *BigCache) Decr(
 	ctx context.Context,
 	key string,
@@ -0,0 +1,57 @@
+package extredis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLockNotAcquired = errors.New("big_cache: lock is held by others")
+)
+
+// Lock is a simple distributed lock based on SET NX PX, it is released
+// only by the holder who owns the random token.
+type Lock struct {
+	bc    *BigCache
+	key   string
+	token string
+}
+
+// TryLock tries to acquire the lock once, it returns ErrLockNotAcquired
+// if the lock is held by others.
+func (impl *BigCache) TryLock(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+) (*Lock, error) {
+	token := uuid.New().String()
+	ok, err := impl.pool.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLockNotAcquired
+	}
+	return &Lock{bc: impl, key: key, token: token}, nil
+}
+
+func (l *Lock) Unlock(ctx context.Context) error {
+	_, err := unlockLuaScript.Run(ctx, l.bc.pool.client, []string{l.key}, l.token).Result()
+	return err
+}
+
+// Refresh extends the lock's ttl, it returns ErrLockNotAcquired if the lock
+// has expired or is held by others, so it can be used as a renewable lease.
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	ret, err := refreshLuaScript.Run(ctx, l.bc.pool.client, []string{l.key}, l.token, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if ret == 0 {
+		return ErrLockNotAcquired
+	}
+	return nil
+}
@@ -19,3 +19,30 @@ return {
     ret
 }
 `)
+
+// Only delete the lock if it is still held by the given token.
+var unlockLuaScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("DEL", KEYS[1])
+else
+    return 0
+end
+`)
+
+// Only extend the lock's ttl if it is still held by the given token.
+var refreshLuaScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+else
+    return 0
+end
+`)
+
+// Increase the counter and set its ttl when it is created.
+var incrWithTTLLuaScript = redis.NewScript(`
+local v = redis.call("INCR", KEYS[1])
+if v == 1 then
+    redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return v
+`)
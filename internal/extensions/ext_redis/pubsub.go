@@ -0,0 +1,52 @@
+package extredis
+
+import (
+	"context"
+
+	redis "github.com/redis/go-redis/v9"
+)
+
+// Subscription is a subscription to a single pub/sub channel, message
+// payloads are encoded the same way as cached values.
+type Subscription struct {
+	pubsub *redis.PubSub
+	ch     <-chan *redis.Message
+}
+
+// Publish encodes value and publishes it to the given channel.
+func (impl *BigCache) Publish(
+	ctx context.Context,
+	channel string,
+	value interface{},
+) error {
+	b, err := Marshal(value)
+	if err != nil {
+		return err
+	}
+	return impl.pool.client.Publish(ctx, channel, b).Err()
+}
+
+// Subscribe subscribes to the given channel, it returns after the
+// subscription is confirmed by the server so that no later message is missed.
+func (impl *BigCache) Subscribe(
+	ctx context.Context,
+	channel string,
+) (*Subscription, error) {
+	pubsub := impl.pool.client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+	return &Subscription{pubsub: pubsub, ch: pubsub.Channel()}, nil
+}
+
+// Channel returns the channel of received messages, it is closed when
+// the subscription is closed. Callers should select on it together with
+// ctx.Done() since receiving never blocks on ctx.
+func (s *Subscription) Channel() <-chan *redis.Message {
+	return s.ch
+}
+
+func (s *Subscription) Close() error {
+	return s.pubsub.Close()
+}
@@ -0,0 +1,23 @@
+package extredis
+
+import (
+	"context"
+
+	redis "github.com/redis/go-redis/v9"
+)
+
+// XAdd appends an entry to the given stream and returns the entry id,
+// the stream is approximately trimmed to maxLen entries when maxLen > 0.
+func (impl *BigCache) XAdd(
+	ctx context.Context,
+	stream string,
+	maxLen int64,
+	values map[string]interface{},
+) (string, error) {
+	return impl.pool.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: maxLen > 0,
+		Values: values,
+	}).Result()
+}
@@ -0,0 +1,240 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/amazingchow/wechat-payment-callback-service/protos/payment_event.proto
+
+package proto_gens // import "github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+
+/*
+支付事件的消息格式, 不兼容的变更需要升级包名中的版本号
+*/
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type PaymentEvent struct {
+	// 消息格式版本号, 与包名中的版本号保持一致
+	SchemaVersion int32 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// 支付事件ID, 与Webhook投递的支付事件ID相同, 消费端可据此去重
+	EventId string `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// 支付事件类型, payment.succeeded/payment.closed/refund.succeeded
+	EventType string `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// 由微信官方给定的应用ID
+	AppId string `protobuf:"bytes,4,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 由系统生成的平台订单交易ID, 同时作为消息键
+	TradeId string `protobuf:"bytes,5,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 用户唯一标识
+	PayerUid string `protobuf:"bytes,6,opt,name=payer_uid,json=payerUid,proto3" json:"payer_uid,omitempty"`
+	// 由微信官方给定的支付订单号
+	TrxId string `protobuf:"bytes,7,opt,name=trx_id,json=trxId,proto3" json:"trx_id,omitempty"`
+	// 交易类型
+	TradeType string `protobuf:"bytes,8,opt,name=trade_type,json=tradeType,proto3" json:"trade_type,omitempty"`
+	// 平台订单业务状态
+	State string `protobuf:"bytes,9,opt,name=state,proto3" json:"state,omitempty"`
+	// 商品总金额, 单位（分）
+	ItemAmountTotal int64 `protobuf:"varint,10,opt,name=item_amount_total,json=itemAmountTotal,proto3" json:"item_amount_total,omitempty"`
+	// 用户实际支付金额, 单位（分）
+	PayerTotal int64 `protobuf:"varint,11,opt,name=payer_total,json=payerTotal,proto3" json:"payer_total,omitempty"`
+	// 支付完成时间
+	SuccessTime string `protobuf:"bytes,12,opt,name=success_time,json=successTime,proto3" json:"success_time,omitempty"`
+	// 平台订单元数据
+	Metadata map[string]string `protobuf:"bytes,13,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 支付事件发生时间, 单位（秒）
+	OccurTime int64 `protobuf:"varint,14,opt,name=occur_time,json=occurTime,proto3" json:"occur_time,omitempty"`
+	// 是否为重放的支付事件, 重放的支付事件ID与原支付事件相同
+	Replay bool `protobuf:"varint,15,opt,name=replay,proto3" json:"replay,omitempty"`
+	// 已退款总额, 单位（分）
+	RefundedAmountTotal  int64    `protobuf:"varint,16,opt,name=refunded_amount_total,json=refundedAmountTotal,proto3" json:"refunded_amount_total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PaymentEvent) Reset()         { *m = PaymentEvent{} }
+func (m *PaymentEvent) String() string { return proto.CompactTextString(m) }
+func (*PaymentEvent) ProtoMessage()    {}
+func (*PaymentEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_payment_event_d2cc86d2b1abb01e, []int{0}
+}
+func (m *PaymentEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaymentEvent.Unmarshal(m, b)
+}
+func (m *PaymentEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaymentEvent.Marshal(b, m, deterministic)
+}
+func (dst *PaymentEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaymentEvent.Merge(dst, src)
+}
+func (m *PaymentEvent) XXX_Size() int {
+	return xxx_messageInfo_PaymentEvent.Size(m)
+}
+func (m *PaymentEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaymentEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaymentEvent proto.InternalMessageInfo
+
+func (m *PaymentEvent) GetSchemaVersion() int32 {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return 0
+}
+
+func (m *PaymentEvent) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *PaymentEvent) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *PaymentEvent) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *PaymentEvent) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+func (m *PaymentEvent) GetPayerUid() string {
+	if m != nil {
+		return m.PayerUid
+	}
+	return ""
+}
+
+func (m *PaymentEvent) GetTrxId() string {
+	if m != nil {
+		return m.TrxId
+	}
+	return ""
+}
+
+func (m *PaymentEvent) GetTradeType() string {
+	if m != nil {
+		return m.TradeType
+	}
+	return ""
+}
+
+func (m *PaymentEvent) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *PaymentEvent) GetItemAmountTotal() int64 {
+	if m != nil {
+		return m.ItemAmountTotal
+	}
+	return 0
+}
+
+func (m *PaymentEvent) GetPayerTotal() int64 {
+	if m != nil {
+		return m.PayerTotal
+	}
+	return 0
+}
+
+func (m *PaymentEvent) GetSuccessTime() string {
+	if m != nil {
+		return m.SuccessTime
+	}
+	return ""
+}
+
+func (m *PaymentEvent) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *PaymentEvent) GetOccurTime() int64 {
+	if m != nil {
+		return m.OccurTime
+	}
+	return 0
+}
+
+func (m *PaymentEvent) GetReplay() bool {
+	if m != nil {
+		return m.Replay
+	}
+	return false
+}
+
+func (m *PaymentEvent) GetRefundedAmountTotal() int64 {
+	if m != nil {
+		return m.RefundedAmountTotal
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*PaymentEvent)(nil), "wechat_payment_callback_service.event.v1.PaymentEvent")
+	proto.RegisterMapType((map[string]string)(nil), "wechat_payment_callback_service.event.v1.PaymentEvent.MetadataEntry")
+}
+
+func init() {
+	proto.RegisterFile("github.com/amazingchow/wechat-payment-callback-service/protos/payment_event.proto", fileDescriptor_payment_event_d2cc86d2b1abb01e)
+}
+
+var fileDescriptor_payment_event_d2cc86d2b1abb01e = []byte{
+	// 477 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0x4f, 0x6f, 0xd3, 0x40,
+	0x10, 0xc5, 0xe5, 0x86, 0xa4, 0xf1, 0x26, 0x69, 0xcb, 0x42, 0x91, 0x01, 0x21, 0x02, 0x12, 0x92,
+	0x85, 0x14, 0x47, 0x94, 0x0b, 0x82, 0x13, 0x88, 0x1e, 0x82, 0x40, 0x02, 0x2b, 0x70, 0xe0, 0x62,
+	0x26, 0xbb, 0x43, 0xb2, 0xaa, 0xff, 0xac, 0xd6, 0x6b, 0xb7, 0xe6, 0xcc, 0x07, 0x47, 0x3b, 0xeb,
+	0xa0, 0xf6, 0x86, 0xb8, 0x79, 0x7e, 0x6f, 0xe6, 0xf9, 0x69, 0x76, 0x97, 0x7d, 0xd9, 0x2a, 0xbb,
+	0x6b, 0x36, 0x89, 0xa8, 0x8a, 0x25, 0x14, 0xf0, 0x4b, 0x95, 0x5b, 0xb1, 0xab, 0x2e, 0x97, 0x97,
+	0x28, 0x76, 0x60, 0x17, 0x1a, 0xba, 0x02, 0x4b, 0xbb, 0x10, 0x90, 0xe7, 0x1b, 0x10, 0x17, 0x8b,
+	0x1a, 0x4d, 0xab, 0x04, 0x2e, 0xb5, 0xa9, 0x6c, 0x55, 0x2f, 0x7b, 0x3d, 0xc3, 0x16, 0x4b, 0x9b,
+	0x10, 0xe4, 0xb1, 0x9f, 0xcd, 0xf6, 0xda, 0x7e, 0x36, 0xeb, 0x67, 0x13, 0xdf, 0xdc, 0xbe, 0x78,
+	0xfa, 0x7b, 0xc8, 0xa6, 0x9f, 0x7d, 0xd7, 0xb9, 0x63, 0xfc, 0x19, 0x3b, 0xaa, 0xc5, 0x0e, 0x0b,
+	0xc8, 0x5a, 0x34, 0xb5, 0xaa, 0xca, 0x28, 0x98, 0x07, 0xf1, 0x30, 0x9d, 0x79, 0xfa, 0xcd, 0x43,
+	0x7e, 0x9f, 0x8d, 0xc9, 0x23, 0x53, 0x32, 0x3a, 0x98, 0x07, 0x71, 0x98, 0x1e, 0x52, 0xbd, 0x92,
+	0xfc, 0x11, 0x63, 0x5e, 0xb2, 0x9d, 0xc6, 0x68, 0x40, 0x62, 0x48, 0x64, 0xdd, 0x69, 0xe4, 0xa7,
+	0x6c, 0x04, 0x5a, 0xbb, 0xb9, 0x5b, 0x24, 0x0d, 0x41, 0xeb, 0x95, 0x74, 0x86, 0xd6, 0x80, 0x44,
+	0x27, 0x0c, 0xbd, 0x21, 0xd5, 0x2b, 0xc9, 0x1f, 0xb2, 0x50, 0x43, 0x87, 0x26, 0x6b, 0x94, 0x8c,
+	0x46, 0xa4, 0x8d, 0x09, 0x7c, 0x55, 0xd2, 0xd9, 0x59, 0x73, 0xe5, 0xa6, 0x0e, 0xbd, 0x9d, 0x35,
+	0x57, 0x3e, 0x84, 0xb7, 0xa3, 0x10, 0x63, 0x1f, 0x82, 0x08, 0x85, 0xb8, 0xcb, 0x86, 0xb5, 0x05,
+	0x8b, 0x51, 0xe8, 0x87, 0xa8, 0xe0, 0xcf, 0xd9, 0x6d, 0x65, 0xb1, 0xc8, 0xa0, 0xa8, 0x1a, 0x97,
+	0xbf, 0xb2, 0x90, 0x47, 0x6c, 0x1e, 0xc4, 0x83, 0xf4, 0xd8, 0x09, 0x6f, 0x89, 0xaf, 0x1d, 0xe6,
+	0x8f, 0xd9, 0xc4, 0x87, 0xf2, 0x5d, 0x13, 0xea, 0x62, 0x84, 0x7c, 0xc3, 0x13, 0x36, 0xad, 0x1b,
+	0x21, 0xb0, 0xae, 0x33, 0xab, 0x0a, 0x8c, 0xa6, 0xf4, 0xa7, 0x49, 0xcf, 0xd6, 0xaa, 0x40, 0xfe,
+	0x83, 0x8d, 0x0b, 0xb4, 0x20, 0xc1, 0x42, 0x34, 0x9b, 0x0f, 0xe2, 0xc9, 0xd9, 0xfb, 0xe4, 0x5f,
+	0x4f, 0x2e, 0xb9, 0x7e, 0x6a, 0xc9, 0xa7, 0xde, 0xe6, 0xbc, 0xb4, 0xa6, 0x4b, 0xff, 0xba, 0xba,
+	0x35, 0x54, 0x42, 0x34, 0xc6, 0x47, 0x38, 0xa2, 0x90, 0x21, 0x11, 0x0a, 0x70, 0x8f, 0x8d, 0x0c,
+	0xea, 0x1c, 0xba, 0xe8, 0x78, 0x1e, 0xc4, 0xe3, 0xb4, 0xaf, 0xf8, 0x19, 0x3b, 0x35, 0xf8, 0xb3,
+	0x29, 0x25, 0xca, 0x9b, 0xcb, 0x38, 0x21, 0x87, 0x3b, 0x7b, 0xf1, 0xda, 0x42, 0x1e, 0xbc, 0x61,
+	0xb3, 0x1b, 0x29, 0xf8, 0x09, 0x1b, 0x5c, 0x60, 0x47, 0xd7, 0x27, 0x4c, 0xdd, 0xa7, 0xdb, 0x7a,
+	0x0b, 0x79, 0x83, 0xfd, 0x8d, 0xf1, 0xc5, 0xeb, 0x83, 0x57, 0xc1, 0xbb, 0x8f, 0xdf, 0x3f, 0xfc,
+	0xe7, 0x2b, 0x50, 0xa5, 0x45, 0x53, 0x42, 0xee, 0x9f, 0x43, 0xb6, 0xc5, 0xb2, 0xde, 0x8c, 0xe8,
+	0xfb, 0xe5, 0x9f, 0x01, 0x00, 0x94, 0xca, 0xc2, 0x6e, 0x5a, 0x03, 0x00, 0x00,
+}
@@ -33,7 +33,7 @@ func (m *PingRequest) Reset()         { *m = PingRequest{} }
 func (m *PingRequest) String() string { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()    {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{0}
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{0}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingRequest.Unmarshal(m, b)
@@ -63,7 +63,7 @@ func (m *PongResponse) Reset()         { *m = PongResponse{} }
 func (m *PongResponse) String() string { return proto.CompactTextString(m) }
 func (*PongResponse) ProtoMessage()    {}
 func (*PongResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{1}
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{1}
 }
 func (m *PongResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PongResponse.Unmarshal(m, b)
@@ -97,7 +97,7 @@ func (m *MakeNewPlatformTradeIdRequest) Reset()         { *m = MakeNewPlatformTr
 func (m *MakeNewPlatformTradeIdRequest) String() string { return proto.CompactTextString(m) }
 func (*MakeNewPlatformTradeIdRequest) ProtoMessage()    {}
 func (*MakeNewPlatformTradeIdRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{2}
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{2}
 }
 func (m *MakeNewPlatformTradeIdRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MakeNewPlatformTradeIdRequest.Unmarshal(m, b)
@@ -143,7 +143,7 @@ func (m *MakeNewPlatformTradeIdResponse) Reset()         { *m = MakeNewPlatformT
 func (m *MakeNewPlatformTradeIdResponse) String() string { return proto.CompactTextString(m) }
 func (*MakeNewPlatformTradeIdResponse) ProtoMessage()    {}
 func (*MakeNewPlatformTradeIdResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{3}
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{3}
 }
 func (m *MakeNewPlatformTradeIdResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MakeNewPlatformTradeIdResponse.Unmarshal(m, b)
@@ -170,6 +170,81 @@ func (m *MakeNewPlatformTradeIdResponse) GetTradeId() string {
 	return ""
 }
 
+type OrderItem struct {
+	// 商户侧商品编码, 只能由大小写字母、数字、中划线、下划线组成
+	SkuId string `protobuf:"bytes,1,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	// 商品名称
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// 商品单价, 单位（分）
+	UnitPrice int64 `protobuf:"varint,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	// 购买数量
+	Quantity int64 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// 微信支付定义的统一商品编号, 可选
+	WechatpayGoodsId     string   `protobuf:"bytes,5,opt,name=wechatpay_goods_id,json=wechatpayGoodsId,proto3" json:"wechatpay_goods_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderItem) Reset()         { *m = OrderItem{} }
+func (m *OrderItem) String() string { return proto.CompactTextString(m) }
+func (*OrderItem) ProtoMessage()    {}
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{4}
+}
+func (m *OrderItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderItem.Unmarshal(m, b)
+}
+func (m *OrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderItem.Marshal(b, m, deterministic)
+}
+func (dst *OrderItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderItem.Merge(dst, src)
+}
+func (m *OrderItem) XXX_Size() int {
+	return xxx_messageInfo_OrderItem.Size(m)
+}
+func (m *OrderItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderItem proto.InternalMessageInfo
+
+func (m *OrderItem) GetSkuId() string {
+	if m != nil {
+		return m.SkuId
+	}
+	return ""
+}
+
+func (m *OrderItem) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *OrderItem) GetUnitPrice() int64 {
+	if m != nil {
+		return m.UnitPrice
+	}
+	return 0
+}
+
+func (m *OrderItem) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *OrderItem) GetWechatpayGoodsId() string {
+	if m != nil {
+		return m.WechatpayGoodsId
+	}
+	return ""
+}
+
 type MakeNewWxPrepayOrderRequest struct {
 	// 由微信官方给定的应用ID
 	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
@@ -180,17 +255,21 @@ type MakeNewWxPrepayOrderRequest struct {
 	// 商品描述
 	ItemDescription string `protobuf:"bytes,4,opt,name=item_description,json=itemDescription,proto3" json:"item_description,omitempty"`
 	// 订单总额, 单位（分）
-	ItemAmountTotal      int64    `protobuf:"varint,5,opt,name=item_amount_total,json=itemAmountTotal,proto3" json:"item_amount_total,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ItemAmountTotal int64 `protobuf:"varint,5,opt,name=item_amount_total,json=itemAmountTotal,proto3" json:"item_amount_total,omitempty"`
+	// 商品明细, 可选, 各商品单价与数量之积的和必须等于订单总额
+	Items []*OrderItem `protobuf:"bytes,6,rep,name=items,proto3" json:"items,omitempty"`
+	// 平台订单元数据, 可选, 编码后不超过128个字符, 随支付通知和查询结果回传
+	Metadata             map[string]string `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *MakeNewWxPrepayOrderRequest) Reset()         { *m = MakeNewWxPrepayOrderRequest{} }
 func (m *MakeNewWxPrepayOrderRequest) String() string { return proto.CompactTextString(m) }
 func (*MakeNewWxPrepayOrderRequest) ProtoMessage()    {}
 func (*MakeNewWxPrepayOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{4}
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{5}
 }
 func (m *MakeNewWxPrepayOrderRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MakeNewWxPrepayOrderRequest.Unmarshal(m, b)
@@ -245,6 +324,20 @@ func (m *MakeNewWxPrepayOrderRequest) GetItemAmountTotal() int64 {
 	return 0
 }
 
+func (m *MakeNewWxPrepayOrderRequest) GetItems() []*OrderItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *MakeNewWxPrepayOrderRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type WxRequestPaymentParams struct {
 	// 当前的时间, 示例值: 1414561699
 	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
@@ -265,7 +358,7 @@ func (m *WxRequestPaymentParams) Reset()         { *m = WxRequestPaymentParams{}
 func (m *WxRequestPaymentParams) String() string { return proto.CompactTextString(m) }
 func (*WxRequestPaymentParams) ProtoMessage()    {}
 func (*WxRequestPaymentParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{5}
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{6}
 }
 func (m *WxRequestPaymentParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_WxRequestPaymentParams.Unmarshal(m, b)
@@ -331,7 +424,7 @@ func (m *MakeNewWxPrepayOrderResponse) Reset()         { *m = MakeNewWxPrepayOrd
 func (m *MakeNewWxPrepayOrderResponse) String() string { return proto.CompactTextString(m) }
 func (*MakeNewWxPrepayOrderResponse) ProtoMessage()    {}
 func (*MakeNewWxPrepayOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{6}
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{7}
 }
 func (m *MakeNewWxPrepayOrderResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MakeNewWxPrepayOrderResponse.Unmarshal(m, b)
@@ -358,282 +451,3218 @@ func (m *MakeNewWxPrepayOrderResponse) GetParams() *WxRequestPaymentParams {
 	return nil
 }
 
-type QueryWxPaymentStatusRequest struct {
+type MakeNewNativePrepayOrderRequest struct {
+	// 由微信官方给定的应用ID
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 由微信官方给定的用户唯一标识, 可选, Native支付在扫码前无法获知付款用户
+	PayerUid string `protobuf:"bytes,2,opt,name=payer_uid,json=payerUid,proto3" json:"payer_uid,omitempty"`
 	// 由系统生成的平台订单交易ID
-	TradeId              string   `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	TradeId string `protobuf:"bytes,3,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 商品描述
+	ItemDescription string `protobuf:"bytes,4,opt,name=item_description,json=itemDescription,proto3" json:"item_description,omitempty"`
+	// 订单总额, 单位（分）
+	ItemAmountTotal int64 `protobuf:"varint,5,opt,name=item_amount_total,json=itemAmountTotal,proto3" json:"item_amount_total,omitempty"`
+	// 是否同时返回渲染好的二维码图片
+	WithQrCodePng bool `protobuf:"varint,6,opt,name=with_qr_code_png,json=withQrCodePng,proto3" json:"with_qr_code_png,omitempty"`
+	// 二维码图片边长, 单位（像素）, 为0时使用默认值256, 最大为1024
+	QrCodeSize           int32    `protobuf:"varint,7,opt,name=qr_code_size,json=qrCodeSize,proto3" json:"qr_code_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *QueryWxPaymentStatusRequest) Reset()         { *m = QueryWxPaymentStatusRequest{} }
-func (m *QueryWxPaymentStatusRequest) String() string { return proto.CompactTextString(m) }
-func (*QueryWxPaymentStatusRequest) ProtoMessage()    {}
-func (*QueryWxPaymentStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{7}
+func (m *MakeNewNativePrepayOrderRequest) Reset()         { *m = MakeNewNativePrepayOrderRequest{} }
+func (m *MakeNewNativePrepayOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*MakeNewNativePrepayOrderRequest) ProtoMessage()    {}
+func (*MakeNewNativePrepayOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{8}
 }
-func (m *QueryWxPaymentStatusRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_QueryWxPaymentStatusRequest.Unmarshal(m, b)
+func (m *MakeNewNativePrepayOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MakeNewNativePrepayOrderRequest.Unmarshal(m, b)
 }
-func (m *QueryWxPaymentStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_QueryWxPaymentStatusRequest.Marshal(b, m, deterministic)
+func (m *MakeNewNativePrepayOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MakeNewNativePrepayOrderRequest.Marshal(b, m, deterministic)
 }
-func (dst *QueryWxPaymentStatusRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryWxPaymentStatusRequest.Merge(dst, src)
+func (dst *MakeNewNativePrepayOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MakeNewNativePrepayOrderRequest.Merge(dst, src)
 }
-func (m *QueryWxPaymentStatusRequest) XXX_Size() int {
-	return xxx_messageInfo_QueryWxPaymentStatusRequest.Size(m)
+func (m *MakeNewNativePrepayOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_MakeNewNativePrepayOrderRequest.Size(m)
 }
-func (m *QueryWxPaymentStatusRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryWxPaymentStatusRequest.DiscardUnknown(m)
+func (m *MakeNewNativePrepayOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MakeNewNativePrepayOrderRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_QueryWxPaymentStatusRequest proto.InternalMessageInfo
+var xxx_messageInfo_MakeNewNativePrepayOrderRequest proto.InternalMessageInfo
 
-func (m *QueryWxPaymentStatusRequest) GetTradeId() string {
+func (m *MakeNewNativePrepayOrderRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *MakeNewNativePrepayOrderRequest) GetPayerUid() string {
+	if m != nil {
+		return m.PayerUid
+	}
+	return ""
+}
+
+func (m *MakeNewNativePrepayOrderRequest) GetTradeId() string {
 	if m != nil {
 		return m.TradeId
 	}
 	return ""
 }
 
-type QueryWxPaymentStatusResponse struct {
-	// 由微信官方给定的应用ID
-	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	// 由系统生成的平台订单交易ID
-	TradeId string `protobuf:"bytes,2,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
-	// 由微信官方给定的支付订单号
-	TrxId string `protobuf:"bytes,3,opt,name=trx_id,json=trxId,proto3" json:"trx_id,omitempty"`
-	// 交易类型
-	TradeType string `protobuf:"bytes,4,opt,name=trade_type,json=tradeType,proto3" json:"trade_type,omitempty"`
-	// 交易状态
-	TradeState string `protobuf:"bytes,5,opt,name=trade_state,json=tradeState,proto3" json:"trade_state,omitempty"`
-	// 支付完成时间
-	SuccessTime          string   `protobuf:"bytes,6,opt,name=success_time,json=successTime,proto3" json:"success_time,omitempty"`
+func (m *MakeNewNativePrepayOrderRequest) GetItemDescription() string {
+	if m != nil {
+		return m.ItemDescription
+	}
+	return ""
+}
+
+func (m *MakeNewNativePrepayOrderRequest) GetItemAmountTotal() int64 {
+	if m != nil {
+		return m.ItemAmountTotal
+	}
+	return 0
+}
+
+func (m *MakeNewNativePrepayOrderRequest) GetWithQrCodePng() bool {
+	if m != nil {
+		return m.WithQrCodePng
+	}
+	return false
+}
+
+func (m *MakeNewNativePrepayOrderRequest) GetQrCodeSize() int32 {
+	if m != nil {
+		return m.QrCodeSize
+	}
+	return 0
+}
+
+type MakeNewNativePrepayOrderResponse struct {
+	// 二维码链接, 示例值: weixin://wxpay/bizpayurl/up?pr=NwY5Mz9&groupid=00
+	CodeUrl string `protobuf:"bytes,1,opt,name=code_url,json=codeUrl,proto3" json:"code_url,omitempty"`
+	// 二维码图片, PNG格式
+	QrCodePng            []byte   `protobuf:"bytes,2,opt,name=qr_code_png,json=qrCodePng,proto3" json:"qr_code_png,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *QueryWxPaymentStatusResponse) Reset()         { *m = QueryWxPaymentStatusResponse{} }
-func (m *QueryWxPaymentStatusResponse) String() string { return proto.CompactTextString(m) }
-func (*QueryWxPaymentStatusResponse) ProtoMessage()    {}
-func (*QueryWxPaymentStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{8}
+func (m *MakeNewNativePrepayOrderResponse) Reset()         { *m = MakeNewNativePrepayOrderResponse{} }
+func (m *MakeNewNativePrepayOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*MakeNewNativePrepayOrderResponse) ProtoMessage()    {}
+func (*MakeNewNativePrepayOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{9}
 }
-func (m *QueryWxPaymentStatusResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_QueryWxPaymentStatusResponse.Unmarshal(m, b)
+func (m *MakeNewNativePrepayOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MakeNewNativePrepayOrderResponse.Unmarshal(m, b)
 }
-func (m *QueryWxPaymentStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_QueryWxPaymentStatusResponse.Marshal(b, m, deterministic)
+func (m *MakeNewNativePrepayOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MakeNewNativePrepayOrderResponse.Marshal(b, m, deterministic)
 }
-func (dst *QueryWxPaymentStatusResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryWxPaymentStatusResponse.Merge(dst, src)
+func (dst *MakeNewNativePrepayOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MakeNewNativePrepayOrderResponse.Merge(dst, src)
 }
-func (m *QueryWxPaymentStatusResponse) XXX_Size() int {
-	return xxx_messageInfo_QueryWxPaymentStatusResponse.Size(m)
+func (m *MakeNewNativePrepayOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_MakeNewNativePrepayOrderResponse.Size(m)
 }
-func (m *QueryWxPaymentStatusResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryWxPaymentStatusResponse.DiscardUnknown(m)
+func (m *MakeNewNativePrepayOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MakeNewNativePrepayOrderResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_QueryWxPaymentStatusResponse proto.InternalMessageInfo
+var xxx_messageInfo_MakeNewNativePrepayOrderResponse proto.InternalMessageInfo
 
-func (m *QueryWxPaymentStatusResponse) GetAppId() string {
+func (m *MakeNewNativePrepayOrderResponse) GetCodeUrl() string {
 	if m != nil {
-		return m.AppId
+		return m.CodeUrl
 	}
 	return ""
 }
 
-func (m *QueryWxPaymentStatusResponse) GetTradeId() string {
+func (m *MakeNewNativePrepayOrderResponse) GetQrCodePng() []byte {
 	if m != nil {
-		return m.TradeId
+		return m.QrCodePng
+	}
+	return nil
+}
+
+type H5Info struct {
+	// 场景类型, 示例值: iOS, Android, Wap
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// 应用名称
+	AppName string `protobuf:"bytes,2,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	// 网站URL
+	AppUrl string `protobuf:"bytes,3,opt,name=app_url,json=appUrl,proto3" json:"app_url,omitempty"`
+	// iOS平台BundleID
+	BundleId string `protobuf:"bytes,4,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
+	// Android平台PackageName
+	PackageName          string   `protobuf:"bytes,5,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *H5Info) Reset()         { *m = H5Info{} }
+func (m *H5Info) String() string { return proto.CompactTextString(m) }
+func (*H5Info) ProtoMessage()    {}
+func (*H5Info) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{10}
+}
+func (m *H5Info) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_H5Info.Unmarshal(m, b)
+}
+func (m *H5Info) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_H5Info.Marshal(b, m, deterministic)
+}
+func (dst *H5Info) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_H5Info.Merge(dst, src)
+}
+func (m *H5Info) XXX_Size() int {
+	return xxx_messageInfo_H5Info.Size(m)
+}
+func (m *H5Info) XXX_DiscardUnknown() {
+	xxx_messageInfo_H5Info.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_H5Info proto.InternalMessageInfo
+
+func (m *H5Info) GetType() string {
+	if m != nil {
+		return m.Type
 	}
 	return ""
 }
 
-func (m *QueryWxPaymentStatusResponse) GetTrxId() string {
+func (m *H5Info) GetAppName() string {
 	if m != nil {
-		return m.TrxId
+		return m.AppName
 	}
 	return ""
 }
 
-func (m *QueryWxPaymentStatusResponse) GetTradeType() string {
+func (m *H5Info) GetAppUrl() string {
 	if m != nil {
-		return m.TradeType
+		return m.AppUrl
 	}
 	return ""
 }
 
-func (m *QueryWxPaymentStatusResponse) GetTradeState() string {
+func (m *H5Info) GetBundleId() string {
 	if m != nil {
-		return m.TradeState
+		return m.BundleId
 	}
 	return ""
 }
 
-func (m *QueryWxPaymentStatusResponse) GetSuccessTime() string {
+func (m *H5Info) GetPackageName() string {
 	if m != nil {
-		return m.SuccessTime
+		return m.PackageName
 	}
 	return ""
 }
 
-type CloseWxPrepayOrderRequest struct {
-	// 由系统生成的平台订单交易ID
-	TradeId              string   `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+type H5SceneInfo struct {
+	// 用户终端IP, 支持IPv4和IPv6两种格式的IP地址
+	PayerClientIp string `protobuf:"bytes,1,opt,name=payer_client_ip,json=payerClientIp,proto3" json:"payer_client_ip,omitempty"`
+	// H5场景信息
+	H5Info               *H5Info  `protobuf:"bytes,2,opt,name=h5_info,json=h5Info,proto3" json:"h5_info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CloseWxPrepayOrderRequest) Reset()         { *m = CloseWxPrepayOrderRequest{} }
-func (m *CloseWxPrepayOrderRequest) String() string { return proto.CompactTextString(m) }
-func (*CloseWxPrepayOrderRequest) ProtoMessage()    {}
-func (*CloseWxPrepayOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{9}
+func (m *H5SceneInfo) Reset()         { *m = H5SceneInfo{} }
+func (m *H5SceneInfo) String() string { return proto.CompactTextString(m) }
+func (*H5SceneInfo) ProtoMessage()    {}
+func (*H5SceneInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{11}
 }
-func (m *CloseWxPrepayOrderRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CloseWxPrepayOrderRequest.Unmarshal(m, b)
+func (m *H5SceneInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_H5SceneInfo.Unmarshal(m, b)
 }
-func (m *CloseWxPrepayOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CloseWxPrepayOrderRequest.Marshal(b, m, deterministic)
+func (m *H5SceneInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_H5SceneInfo.Marshal(b, m, deterministic)
 }
-func (dst *CloseWxPrepayOrderRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CloseWxPrepayOrderRequest.Merge(dst, src)
+func (dst *H5SceneInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_H5SceneInfo.Merge(dst, src)
 }
-func (m *CloseWxPrepayOrderRequest) XXX_Size() int {
-	return xxx_messageInfo_CloseWxPrepayOrderRequest.Size(m)
+func (m *H5SceneInfo) XXX_Size() int {
+	return xxx_messageInfo_H5SceneInfo.Size(m)
 }
-func (m *CloseWxPrepayOrderRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CloseWxPrepayOrderRequest.DiscardUnknown(m)
+func (m *H5SceneInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_H5SceneInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CloseWxPrepayOrderRequest proto.InternalMessageInfo
+var xxx_messageInfo_H5SceneInfo proto.InternalMessageInfo
 
-func (m *CloseWxPrepayOrderRequest) GetTradeId() string {
+func (m *H5SceneInfo) GetPayerClientIp() string {
 	if m != nil {
-		return m.TradeId
+		return m.PayerClientIp
 	}
 	return ""
 }
 
-type CloseWxPrepayOrderResponse struct {
+func (m *H5SceneInfo) GetH5Info() *H5Info {
+	if m != nil {
+		return m.H5Info
+	}
+	return nil
+}
+
+type MakeNewH5PrepayOrderRequest struct {
+	// 由微信官方给定的应用ID
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 由微信官方给定的用户唯一标识, 可选, H5支付在下单时无法获知付款用户
+	PayerUid string `protobuf:"bytes,2,opt,name=payer_uid,json=payerUid,proto3" json:"payer_uid,omitempty"`
+	// 由系统生成的平台订单交易ID
+	TradeId string `protobuf:"bytes,3,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 商品描述
+	ItemDescription string `protobuf:"bytes,4,opt,name=item_description,json=itemDescription,proto3" json:"item_description,omitempty"`
+	// 订单总额, 单位（分）
+	ItemAmountTotal int64 `protobuf:"varint,5,opt,name=item_amount_total,json=itemAmountTotal,proto3" json:"item_amount_total,omitempty"`
+	// 支付场景描述
+	SceneInfo *H5SceneInfo `protobuf:"bytes,6,opt,name=scene_info,json=sceneInfo,proto3" json:"scene_info,omitempty"`
+	// 支付完成后的回跳页面, 可选, 会经过URL编码后拼接到h5_url之后
+	RedirectUrl          string   `protobuf:"bytes,7,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CloseWxPrepayOrderResponse) Reset()         { *m = CloseWxPrepayOrderResponse{} }
-func (m *CloseWxPrepayOrderResponse) String() string { return proto.CompactTextString(m) }
-func (*CloseWxPrepayOrderResponse) ProtoMessage()    {}
-func (*CloseWxPrepayOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12, []int{10}
+func (m *MakeNewH5PrepayOrderRequest) Reset()         { *m = MakeNewH5PrepayOrderRequest{} }
+func (m *MakeNewH5PrepayOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*MakeNewH5PrepayOrderRequest) ProtoMessage()    {}
+func (*MakeNewH5PrepayOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{12}
 }
-func (m *CloseWxPrepayOrderResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CloseWxPrepayOrderResponse.Unmarshal(m, b)
+func (m *MakeNewH5PrepayOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MakeNewH5PrepayOrderRequest.Unmarshal(m, b)
 }
-func (m *CloseWxPrepayOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CloseWxPrepayOrderResponse.Marshal(b, m, deterministic)
+func (m *MakeNewH5PrepayOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MakeNewH5PrepayOrderRequest.Marshal(b, m, deterministic)
 }
-func (dst *CloseWxPrepayOrderResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CloseWxPrepayOrderResponse.Merge(dst, src)
+func (dst *MakeNewH5PrepayOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MakeNewH5PrepayOrderRequest.Merge(dst, src)
 }
-func (m *CloseWxPrepayOrderResponse) XXX_Size() int {
-	return xxx_messageInfo_CloseWxPrepayOrderResponse.Size(m)
+func (m *MakeNewH5PrepayOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_MakeNewH5PrepayOrderRequest.Size(m)
 }
-func (m *CloseWxPrepayOrderResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CloseWxPrepayOrderResponse.DiscardUnknown(m)
+func (m *MakeNewH5PrepayOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MakeNewH5PrepayOrderRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CloseWxPrepayOrderResponse proto.InternalMessageInfo
+var xxx_messageInfo_MakeNewH5PrepayOrderRequest proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*PingRequest)(nil), "wechat_payment_callback_service.PingRequest")
-	proto.RegisterType((*PongResponse)(nil), "wechat_payment_callback_service.PongResponse")
-	proto.RegisterType((*MakeNewPlatformTradeIdRequest)(nil), "wechat_payment_callback_service.MakeNewPlatformTradeIdRequest")
-	proto.RegisterType((*MakeNewPlatformTradeIdResponse)(nil), "wechat_payment_callback_service.MakeNewPlatformTradeIdResponse")
-	proto.RegisterType((*MakeNewWxPrepayOrderRequest)(nil), "wechat_payment_callback_service.MakeNewWxPrepayOrderRequest")
-	proto.RegisterType((*WxRequestPaymentParams)(nil), "wechat_payment_callback_service.WxRequestPaymentParams")
-	proto.RegisterType((*MakeNewWxPrepayOrderResponse)(nil), "wechat_payment_callback_service.MakeNewWxPrepayOrderResponse")
-	proto.RegisterType((*QueryWxPaymentStatusRequest)(nil), "wechat_payment_callback_service.QueryWxPaymentStatusRequest")
-	proto.RegisterType((*QueryWxPaymentStatusResponse)(nil), "wechat_payment_callback_service.QueryWxPaymentStatusResponse")
-	proto.RegisterType((*CloseWxPrepayOrderRequest)(nil), "wechat_payment_callback_service.CloseWxPrepayOrderRequest")
-	proto.RegisterType((*CloseWxPrepayOrderResponse)(nil), "wechat_payment_callback_service.CloseWxPrepayOrderResponse")
+func (m *MakeNewH5PrepayOrderRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *MakeNewH5PrepayOrderRequest) GetPayerUid() string {
+	if m != nil {
+		return m.PayerUid
+	}
+	return ""
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *MakeNewH5PrepayOrderRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
 
-// WechatPaymentCallbackServiceClient is the client API for WechatPaymentCallbackService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type WechatPaymentCallbackServiceClient interface {
-	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PongResponse, error)
-	// 生成平台订单交易ID
-	MakeNewPlatformTradeId(ctx context.Context, in *MakeNewPlatformTradeIdRequest, opts ...grpc.CallOption) (*MakeNewPlatformTradeIdResponse, error)
-	// 创建平台订单和微信预支付订单
+func (m *MakeNewH5PrepayOrderRequest) GetItemDescription() string {
+	if m != nil {
+		return m.ItemDescription
+	}
+	return ""
+}
+
+func (m *MakeNewH5PrepayOrderRequest) GetItemAmountTotal() int64 {
+	if m != nil {
+		return m.ItemAmountTotal
+	}
+	return 0
+}
+
+func (m *MakeNewH5PrepayOrderRequest) GetSceneInfo() *H5SceneInfo {
+	if m != nil {
+		return m.SceneInfo
+	}
+	return nil
+}
+
+func (m *MakeNewH5PrepayOrderRequest) GetRedirectUrl() string {
+	if m != nil {
+		return m.RedirectUrl
+	}
+	return ""
+}
+
+type MakeNewH5PrepayOrderResponse struct {
+	// 支付跳转链接, 有效期为5分钟
+	H5Url                string   `protobuf:"bytes,1,opt,name=h5_url,json=h5Url,proto3" json:"h5_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MakeNewH5PrepayOrderResponse) Reset()         { *m = MakeNewH5PrepayOrderResponse{} }
+func (m *MakeNewH5PrepayOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*MakeNewH5PrepayOrderResponse) ProtoMessage()    {}
+func (*MakeNewH5PrepayOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{13}
+}
+func (m *MakeNewH5PrepayOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MakeNewH5PrepayOrderResponse.Unmarshal(m, b)
+}
+func (m *MakeNewH5PrepayOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MakeNewH5PrepayOrderResponse.Marshal(b, m, deterministic)
+}
+func (dst *MakeNewH5PrepayOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MakeNewH5PrepayOrderResponse.Merge(dst, src)
+}
+func (m *MakeNewH5PrepayOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_MakeNewH5PrepayOrderResponse.Size(m)
+}
+func (m *MakeNewH5PrepayOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MakeNewH5PrepayOrderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MakeNewH5PrepayOrderResponse proto.InternalMessageInfo
+
+func (m *MakeNewH5PrepayOrderResponse) GetH5Url() string {
+	if m != nil {
+		return m.H5Url
+	}
+	return ""
+}
+
+type MakeNewAppPrepayOrderRequest struct {
+	// 由微信官方给定的应用ID, 须为移动应用的AppID
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 由微信官方给定的用户唯一标识, 可选, APP支付在下单时无法获知付款用户
+	PayerUid string `protobuf:"bytes,2,opt,name=payer_uid,json=payerUid,proto3" json:"payer_uid,omitempty"`
+	// 由系统生成的平台订单交易ID
+	TradeId string `protobuf:"bytes,3,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 商品描述
+	ItemDescription string `protobuf:"bytes,4,opt,name=item_description,json=itemDescription,proto3" json:"item_description,omitempty"`
+	// 订单总额, 单位（分）
+	ItemAmountTotal      int64    `protobuf:"varint,5,opt,name=item_amount_total,json=itemAmountTotal,proto3" json:"item_amount_total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MakeNewAppPrepayOrderRequest) Reset()         { *m = MakeNewAppPrepayOrderRequest{} }
+func (m *MakeNewAppPrepayOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*MakeNewAppPrepayOrderRequest) ProtoMessage()    {}
+func (*MakeNewAppPrepayOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{14}
+}
+func (m *MakeNewAppPrepayOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MakeNewAppPrepayOrderRequest.Unmarshal(m, b)
+}
+func (m *MakeNewAppPrepayOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MakeNewAppPrepayOrderRequest.Marshal(b, m, deterministic)
+}
+func (dst *MakeNewAppPrepayOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MakeNewAppPrepayOrderRequest.Merge(dst, src)
+}
+func (m *MakeNewAppPrepayOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_MakeNewAppPrepayOrderRequest.Size(m)
+}
+func (m *MakeNewAppPrepayOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MakeNewAppPrepayOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MakeNewAppPrepayOrderRequest proto.InternalMessageInfo
+
+func (m *MakeNewAppPrepayOrderRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *MakeNewAppPrepayOrderRequest) GetPayerUid() string {
+	if m != nil {
+		return m.PayerUid
+	}
+	return ""
+}
+
+func (m *MakeNewAppPrepayOrderRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+func (m *MakeNewAppPrepayOrderRequest) GetItemDescription() string {
+	if m != nil {
+		return m.ItemDescription
+	}
+	return ""
+}
+
+func (m *MakeNewAppPrepayOrderRequest) GetItemAmountTotal() int64 {
+	if m != nil {
+		return m.ItemAmountTotal
+	}
+	return 0
+}
+
+type WxAppPaymentParams struct {
+	// 由微信官方给定的应用ID
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 由微信官方给定的商户号
+	PartnerId string `protobuf:"bytes,2,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"`
+	// APP下单接口返回的prepay_id参数值
+	PrepayId string `protobuf:"bytes,3,opt,name=prepay_id,json=prepayId,proto3" json:"prepay_id,omitempty"`
+	// 订单详情扩展字符串, 固定值Sign=WXPay
+	Package string `protobuf:"bytes,4,opt,name=package,proto3" json:"package,omitempty"`
+	// 随机字符串, 不长于32位
+	Nonce string `protobuf:"bytes,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// 当前的时间, 示例值: 1414561699
+	Timestamp int64 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// 签名, 使用字段appId、timeStamp、nonceStr、prepayId计算得出的签名值
+	Sign                 string   `protobuf:"bytes,7,opt,name=sign,proto3" json:"sign,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WxAppPaymentParams) Reset()         { *m = WxAppPaymentParams{} }
+func (m *WxAppPaymentParams) String() string { return proto.CompactTextString(m) }
+func (*WxAppPaymentParams) ProtoMessage()    {}
+func (*WxAppPaymentParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{15}
+}
+func (m *WxAppPaymentParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WxAppPaymentParams.Unmarshal(m, b)
+}
+func (m *WxAppPaymentParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WxAppPaymentParams.Marshal(b, m, deterministic)
+}
+func (dst *WxAppPaymentParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WxAppPaymentParams.Merge(dst, src)
+}
+func (m *WxAppPaymentParams) XXX_Size() int {
+	return xxx_messageInfo_WxAppPaymentParams.Size(m)
+}
+func (m *WxAppPaymentParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_WxAppPaymentParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WxAppPaymentParams proto.InternalMessageInfo
+
+func (m *WxAppPaymentParams) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *WxAppPaymentParams) GetPartnerId() string {
+	if m != nil {
+		return m.PartnerId
+	}
+	return ""
+}
+
+func (m *WxAppPaymentParams) GetPrepayId() string {
+	if m != nil {
+		return m.PrepayId
+	}
+	return ""
+}
+
+func (m *WxAppPaymentParams) GetPackage() string {
+	if m != nil {
+		return m.Package
+	}
+	return ""
+}
+
+func (m *WxAppPaymentParams) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
+}
+
+func (m *WxAppPaymentParams) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *WxAppPaymentParams) GetSign() string {
+	if m != nil {
+		return m.Sign
+	}
+	return ""
+}
+
+type MakeNewAppPrepayOrderResponse struct {
+	Params               *WxAppPaymentParams `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *MakeNewAppPrepayOrderResponse) Reset()         { *m = MakeNewAppPrepayOrderResponse{} }
+func (m *MakeNewAppPrepayOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*MakeNewAppPrepayOrderResponse) ProtoMessage()    {}
+func (*MakeNewAppPrepayOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{16}
+}
+func (m *MakeNewAppPrepayOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MakeNewAppPrepayOrderResponse.Unmarshal(m, b)
+}
+func (m *MakeNewAppPrepayOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MakeNewAppPrepayOrderResponse.Marshal(b, m, deterministic)
+}
+func (dst *MakeNewAppPrepayOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MakeNewAppPrepayOrderResponse.Merge(dst, src)
+}
+func (m *MakeNewAppPrepayOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_MakeNewAppPrepayOrderResponse.Size(m)
+}
+func (m *MakeNewAppPrepayOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MakeNewAppPrepayOrderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MakeNewAppPrepayOrderResponse proto.InternalMessageInfo
+
+func (m *MakeNewAppPrepayOrderResponse) GetParams() *WxAppPaymentParams {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+type QueryWxPaymentStatusRequest struct {
+	// 按平台订单交易ID或微信支付订单号查询, 二选一
+	//
+	// Types that are valid to be assigned to Id:
+	//	*QueryWxPaymentStatusRequest_TradeId
+	//	*QueryWxPaymentStatusRequest_TransactionId
+	Id isQueryWxPaymentStatusRequest_Id `protobuf_oneof:"id"`
+	// 本地优先, 平台订单已处于终态(已支付/已关闭/退款中/已退款)时直接返回本地订单记录, 不请求微信支付
+	LocalFirst           bool     `protobuf:"varint,2,opt,name=local_first,json=localFirst,proto3" json:"local_first,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryWxPaymentStatusRequest) Reset()         { *m = QueryWxPaymentStatusRequest{} }
+func (m *QueryWxPaymentStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryWxPaymentStatusRequest) ProtoMessage()    {}
+func (*QueryWxPaymentStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{17}
+}
+func (m *QueryWxPaymentStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryWxPaymentStatusRequest.Unmarshal(m, b)
+}
+func (m *QueryWxPaymentStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryWxPaymentStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *QueryWxPaymentStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryWxPaymentStatusRequest.Merge(dst, src)
+}
+func (m *QueryWxPaymentStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_QueryWxPaymentStatusRequest.Size(m)
+}
+func (m *QueryWxPaymentStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryWxPaymentStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryWxPaymentStatusRequest proto.InternalMessageInfo
+
+type isQueryWxPaymentStatusRequest_Id interface {
+	isQueryWxPaymentStatusRequest_Id()
+}
+
+type QueryWxPaymentStatusRequest_TradeId struct {
+	TradeId string `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3,oneof"`
+}
+
+type QueryWxPaymentStatusRequest_TransactionId struct {
+	TransactionId string `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3,oneof"`
+}
+
+func (*QueryWxPaymentStatusRequest_TradeId) isQueryWxPaymentStatusRequest_Id() {}
+
+func (*QueryWxPaymentStatusRequest_TransactionId) isQueryWxPaymentStatusRequest_Id() {}
+
+func (m *QueryWxPaymentStatusRequest) GetId() isQueryWxPaymentStatusRequest_Id {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *QueryWxPaymentStatusRequest) GetTradeId() string {
+	if x, ok := m.GetId().(*QueryWxPaymentStatusRequest_TradeId); ok {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (m *QueryWxPaymentStatusRequest) GetTransactionId() string {
+	if x, ok := m.GetId().(*QueryWxPaymentStatusRequest_TransactionId); ok {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (m *QueryWxPaymentStatusRequest) GetLocalFirst() bool {
+	if m != nil {
+		return m.LocalFirst
+	}
+	return false
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*QueryWxPaymentStatusRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _QueryWxPaymentStatusRequest_OneofMarshaler, _QueryWxPaymentStatusRequest_OneofUnmarshaler, _QueryWxPaymentStatusRequest_OneofSizer, []interface{}{
+		(*QueryWxPaymentStatusRequest_TradeId)(nil),
+		(*QueryWxPaymentStatusRequest_TransactionId)(nil),
+	}
+}
+
+func _QueryWxPaymentStatusRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*QueryWxPaymentStatusRequest)
+	// id
+	switch x := m.Id.(type) {
+	case *QueryWxPaymentStatusRequest_TradeId:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.TradeId)
+	case *QueryWxPaymentStatusRequest_TransactionId:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.TransactionId)
+	case nil:
+	default:
+		return fmt.Errorf("QueryWxPaymentStatusRequest.Id has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _QueryWxPaymentStatusRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*QueryWxPaymentStatusRequest)
+	switch tag {
+	case 1: // id.trade_id
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Id = &QueryWxPaymentStatusRequest_TradeId{x}
+		return true, err
+	case 3: // id.transaction_id
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Id = &QueryWxPaymentStatusRequest_TransactionId{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _QueryWxPaymentStatusRequest_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*QueryWxPaymentStatusRequest)
+	// id
+	switch x := m.Id.(type) {
+	case *QueryWxPaymentStatusRequest_TradeId:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.TradeId)))
+		n += len(x.TradeId)
+	case *QueryWxPaymentStatusRequest_TransactionId:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.TransactionId)))
+		n += len(x.TransactionId)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type QueryWxPaymentStatusResponse struct {
+	// 由微信官方给定的应用ID
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 由系统生成的平台订单交易ID
+	TradeId string `protobuf:"bytes,2,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 由微信官方给定的支付订单号
+	TrxId string `protobuf:"bytes,3,opt,name=trx_id,json=trxId,proto3" json:"trx_id,omitempty"`
+	// 交易类型
+	TradeType string `protobuf:"bytes,4,opt,name=trade_type,json=tradeType,proto3" json:"trade_type,omitempty"`
+	// 交易状态
+	TradeState string `protobuf:"bytes,5,opt,name=trade_state,json=tradeState,proto3" json:"trade_state,omitempty"`
+	// 支付完成时间
+	SuccessTime string `protobuf:"bytes,6,opt,name=success_time,json=successTime,proto3" json:"success_time,omitempty"`
+	// 平台订单元数据, 从附加数据中解析
+	Metadata map[string]string `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 同步支付结果后的平台订单业务状态
+	State string `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`
+	// 支付结果是否来自本地订单记录
+	FromLocal            bool     `protobuf:"varint,9,opt,name=from_local,json=fromLocal,proto3" json:"from_local,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryWxPaymentStatusResponse) Reset()         { *m = QueryWxPaymentStatusResponse{} }
+func (m *QueryWxPaymentStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryWxPaymentStatusResponse) ProtoMessage()    {}
+func (*QueryWxPaymentStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{18}
+}
+func (m *QueryWxPaymentStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryWxPaymentStatusResponse.Unmarshal(m, b)
+}
+func (m *QueryWxPaymentStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryWxPaymentStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *QueryWxPaymentStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryWxPaymentStatusResponse.Merge(dst, src)
+}
+func (m *QueryWxPaymentStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryWxPaymentStatusResponse.Size(m)
+}
+func (m *QueryWxPaymentStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryWxPaymentStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryWxPaymentStatusResponse proto.InternalMessageInfo
+
+func (m *QueryWxPaymentStatusResponse) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *QueryWxPaymentStatusResponse) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+func (m *QueryWxPaymentStatusResponse) GetTrxId() string {
+	if m != nil {
+		return m.TrxId
+	}
+	return ""
+}
+
+func (m *QueryWxPaymentStatusResponse) GetTradeType() string {
+	if m != nil {
+		return m.TradeType
+	}
+	return ""
+}
+
+func (m *QueryWxPaymentStatusResponse) GetTradeState() string {
+	if m != nil {
+		return m.TradeState
+	}
+	return ""
+}
+
+func (m *QueryWxPaymentStatusResponse) GetSuccessTime() string {
+	if m != nil {
+		return m.SuccessTime
+	}
+	return ""
+}
+
+func (m *QueryWxPaymentStatusResponse) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *QueryWxPaymentStatusResponse) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *QueryWxPaymentStatusResponse) GetFromLocal() bool {
+	if m != nil {
+		return m.FromLocal
+	}
+	return false
+}
+
+type RefreshWxPaymentParamsRequest struct {
+	// 由系统生成的平台订单交易ID
+	TradeId              string   `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefreshWxPaymentParamsRequest) Reset()         { *m = RefreshWxPaymentParamsRequest{} }
+func (m *RefreshWxPaymentParamsRequest) String() string { return proto.CompactTextString(m) }
+func (*RefreshWxPaymentParamsRequest) ProtoMessage()    {}
+func (*RefreshWxPaymentParamsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{19}
+}
+func (m *RefreshWxPaymentParamsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefreshWxPaymentParamsRequest.Unmarshal(m, b)
+}
+func (m *RefreshWxPaymentParamsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefreshWxPaymentParamsRequest.Marshal(b, m, deterministic)
+}
+func (dst *RefreshWxPaymentParamsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshWxPaymentParamsRequest.Merge(dst, src)
+}
+func (m *RefreshWxPaymentParamsRequest) XXX_Size() int {
+	return xxx_messageInfo_RefreshWxPaymentParamsRequest.Size(m)
+}
+func (m *RefreshWxPaymentParamsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshWxPaymentParamsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefreshWxPaymentParamsRequest proto.InternalMessageInfo
+
+func (m *RefreshWxPaymentParamsRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+type RefreshWxPaymentParamsResponse struct {
+	// JSAPI调起支付所需的带签名支付信息, 仅JSAPI支付的平台订单返回
+	Params *WxRequestPaymentParams `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	// APP调起支付所需的带签名支付信息, 仅APP支付的平台订单返回
+	AppParams            *WxAppPaymentParams `protobuf:"bytes,2,opt,name=app_params,json=appParams,proto3" json:"app_params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RefreshWxPaymentParamsResponse) Reset()         { *m = RefreshWxPaymentParamsResponse{} }
+func (m *RefreshWxPaymentParamsResponse) String() string { return proto.CompactTextString(m) }
+func (*RefreshWxPaymentParamsResponse) ProtoMessage()    {}
+func (*RefreshWxPaymentParamsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{20}
+}
+func (m *RefreshWxPaymentParamsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefreshWxPaymentParamsResponse.Unmarshal(m, b)
+}
+func (m *RefreshWxPaymentParamsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefreshWxPaymentParamsResponse.Marshal(b, m, deterministic)
+}
+func (dst *RefreshWxPaymentParamsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshWxPaymentParamsResponse.Merge(dst, src)
+}
+func (m *RefreshWxPaymentParamsResponse) XXX_Size() int {
+	return xxx_messageInfo_RefreshWxPaymentParamsResponse.Size(m)
+}
+func (m *RefreshWxPaymentParamsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshWxPaymentParamsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefreshWxPaymentParamsResponse proto.InternalMessageInfo
+
+func (m *RefreshWxPaymentParamsResponse) GetParams() *WxRequestPaymentParams {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *RefreshWxPaymentParamsResponse) GetAppParams() *WxAppPaymentParams {
+	if m != nil {
+		return m.AppParams
+	}
+	return nil
+}
+
+type WatchPaymentStatusRequest struct {
+	// 由系统生成的平台订单交易ID
+	TradeId              string   `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchPaymentStatusRequest) Reset()         { *m = WatchPaymentStatusRequest{} }
+func (m *WatchPaymentStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchPaymentStatusRequest) ProtoMessage()    {}
+func (*WatchPaymentStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{21}
+}
+func (m *WatchPaymentStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchPaymentStatusRequest.Unmarshal(m, b)
+}
+func (m *WatchPaymentStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchPaymentStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *WatchPaymentStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPaymentStatusRequest.Merge(dst, src)
+}
+func (m *WatchPaymentStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchPaymentStatusRequest.Size(m)
+}
+func (m *WatchPaymentStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPaymentStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchPaymentStatusRequest proto.InternalMessageInfo
+
+func (m *WatchPaymentStatusRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+type WatchPaymentStatusResponse struct {
+	// 由系统生成的平台订单交易ID
+	TradeId string `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 平台订单业务状态
+	State string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// 平台订单处理步骤
+	Status int32 `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	// 平台订单版本号
+	Version int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	// 由微信官方给定的支付订单号
+	TrxId string `protobuf:"bytes,5,opt,name=trx_id,json=trxId,proto3" json:"trx_id,omitempty"`
+	// 支付完成时间
+	SuccessTime string `protobuf:"bytes,6,opt,name=success_time,json=successTime,proto3" json:"success_time,omitempty"`
+	// 更新时间, 单位（秒）
+	UpdateTime           int64    `protobuf:"varint,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchPaymentStatusResponse) Reset()         { *m = WatchPaymentStatusResponse{} }
+func (m *WatchPaymentStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchPaymentStatusResponse) ProtoMessage()    {}
+func (*WatchPaymentStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{22}
+}
+func (m *WatchPaymentStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchPaymentStatusResponse.Unmarshal(m, b)
+}
+func (m *WatchPaymentStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchPaymentStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *WatchPaymentStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPaymentStatusResponse.Merge(dst, src)
+}
+func (m *WatchPaymentStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchPaymentStatusResponse.Size(m)
+}
+func (m *WatchPaymentStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPaymentStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchPaymentStatusResponse proto.InternalMessageInfo
+
+func (m *WatchPaymentStatusResponse) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+func (m *WatchPaymentStatusResponse) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *WatchPaymentStatusResponse) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *WatchPaymentStatusResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *WatchPaymentStatusResponse) GetTrxId() string {
+	if m != nil {
+		return m.TrxId
+	}
+	return ""
+}
+
+func (m *WatchPaymentStatusResponse) GetSuccessTime() string {
+	if m != nil {
+		return m.SuccessTime
+	}
+	return ""
+}
+
+func (m *WatchPaymentStatusResponse) GetUpdateTime() int64 {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return 0
+}
+
+type CloseWxPrepayOrderRequest struct {
+	// 由系统生成的平台订单交易ID
+	TradeId              string   `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseWxPrepayOrderRequest) Reset()         { *m = CloseWxPrepayOrderRequest{} }
+func (m *CloseWxPrepayOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*CloseWxPrepayOrderRequest) ProtoMessage()    {}
+func (*CloseWxPrepayOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{23}
+}
+func (m *CloseWxPrepayOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseWxPrepayOrderRequest.Unmarshal(m, b)
+}
+func (m *CloseWxPrepayOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseWxPrepayOrderRequest.Marshal(b, m, deterministic)
+}
+func (dst *CloseWxPrepayOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseWxPrepayOrderRequest.Merge(dst, src)
+}
+func (m *CloseWxPrepayOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_CloseWxPrepayOrderRequest.Size(m)
+}
+func (m *CloseWxPrepayOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseWxPrepayOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseWxPrepayOrderRequest proto.InternalMessageInfo
+
+func (m *CloseWxPrepayOrderRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+type CloseWxPrepayOrderResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseWxPrepayOrderResponse) Reset()         { *m = CloseWxPrepayOrderResponse{} }
+func (m *CloseWxPrepayOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*CloseWxPrepayOrderResponse) ProtoMessage()    {}
+func (*CloseWxPrepayOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{24}
+}
+func (m *CloseWxPrepayOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseWxPrepayOrderResponse.Unmarshal(m, b)
+}
+func (m *CloseWxPrepayOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseWxPrepayOrderResponse.Marshal(b, m, deterministic)
+}
+func (dst *CloseWxPrepayOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseWxPrepayOrderResponse.Merge(dst, src)
+}
+func (m *CloseWxPrepayOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_CloseWxPrepayOrderResponse.Size(m)
+}
+func (m *CloseWxPrepayOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseWxPrepayOrderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseWxPrepayOrderResponse proto.InternalMessageInfo
+
+type NotificationSummary struct {
+	// 通知的唯一ID, 主动查询合成的支付通知为空
+	NotifyId string `protobuf:"bytes,1,opt,name=notify_id,json=notifyId,proto3" json:"notify_id,omitempty"`
+	// 通知的事件类型
+	EventType string `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// 由微信官方给定的支付订单号
+	TrxId string `protobuf:"bytes,3,opt,name=trx_id,json=trxId,proto3" json:"trx_id,omitempty"`
+	// 交易类型
+	TradeType string `protobuf:"bytes,4,opt,name=trade_type,json=tradeType,proto3" json:"trade_type,omitempty"`
+	// 交易状态
+	TradeState string `protobuf:"bytes,5,opt,name=trade_state,json=tradeState,proto3" json:"trade_state,omitempty"`
+	// 交易状态描述
+	TradeStateDesc string `protobuf:"bytes,6,opt,name=trade_state_desc,json=tradeStateDesc,proto3" json:"trade_state_desc,omitempty"`
+	// 支付完成时间
+	SuccessTime string `protobuf:"bytes,7,opt,name=success_time,json=successTime,proto3" json:"success_time,omitempty"`
+	// 用户支付金额, 单位（分）
+	PayerTotal int64 `protobuf:"varint,8,opt,name=payer_total,json=payerTotal,proto3" json:"payer_total,omitempty"`
+	// 支付结果的来源, notify: 支付通知; query: 主动查询
+	Source string `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
+	// 平台订单元数据, 从附加数据中解析
+	Metadata             map[string]string `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NotificationSummary) Reset()         { *m = NotificationSummary{} }
+func (m *NotificationSummary) String() string { return proto.CompactTextString(m) }
+func (*NotificationSummary) ProtoMessage()    {}
+func (*NotificationSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{25}
+}
+func (m *NotificationSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationSummary.Unmarshal(m, b)
+}
+func (m *NotificationSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationSummary.Marshal(b, m, deterministic)
+}
+func (dst *NotificationSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationSummary.Merge(dst, src)
+}
+func (m *NotificationSummary) XXX_Size() int {
+	return xxx_messageInfo_NotificationSummary.Size(m)
+}
+func (m *NotificationSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotificationSummary proto.InternalMessageInfo
+
+func (m *NotificationSummary) GetNotifyId() string {
+	if m != nil {
+		return m.NotifyId
+	}
+	return ""
+}
+
+func (m *NotificationSummary) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *NotificationSummary) GetTrxId() string {
+	if m != nil {
+		return m.TrxId
+	}
+	return ""
+}
+
+func (m *NotificationSummary) GetTradeType() string {
+	if m != nil {
+		return m.TradeType
+	}
+	return ""
+}
+
+func (m *NotificationSummary) GetTradeState() string {
+	if m != nil {
+		return m.TradeState
+	}
+	return ""
+}
+
+func (m *NotificationSummary) GetTradeStateDesc() string {
+	if m != nil {
+		return m.TradeStateDesc
+	}
+	return ""
+}
+
+func (m *NotificationSummary) GetSuccessTime() string {
+	if m != nil {
+		return m.SuccessTime
+	}
+	return ""
+}
+
+func (m *NotificationSummary) GetPayerTotal() int64 {
+	if m != nil {
+		return m.PayerTotal
+	}
+	return 0
+}
+
+func (m *NotificationSummary) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *NotificationSummary) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type PlatformOrderInfo struct {
+	// 由微信官方给定的应用ID
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 由微信官方给定的商户号
+	MerchantId string `protobuf:"bytes,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	// 由系统生成的平台订单交易ID
+	TradeId string `protobuf:"bytes,3,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 由微信官方给定的用户唯一标识
+	PayerUid string `protobuf:"bytes,4,opt,name=payer_uid,json=payerUid,proto3" json:"payer_uid,omitempty"`
+	// 交易类型
+	TradeType string `protobuf:"bytes,5,opt,name=trade_type,json=tradeType,proto3" json:"trade_type,omitempty"`
+	// 商品描述
+	ItemDescription string `protobuf:"bytes,6,opt,name=item_description,json=itemDescription,proto3" json:"item_description,omitempty"`
+	// 订单总额, 单位（分）
+	ItemAmountTotal int64 `protobuf:"varint,7,opt,name=item_amount_total,json=itemAmountTotal,proto3" json:"item_amount_total,omitempty"`
+	// 平台订单处理步骤
+	Status int32 `protobuf:"varint,8,opt,name=status,proto3" json:"status,omitempty"`
+	// 已退款总额, 单位（分）
+	RefundedAmountTotal int64 `protobuf:"varint,9,opt,name=refunded_amount_total,json=refundedAmountTotal,proto3" json:"refunded_amount_total,omitempty"`
+	// 过期时间, 单位（秒）
+	ExpireTime int64 `protobuf:"varint,10,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// 创建时间, 单位（秒）
+	CreateTime int64 `protobuf:"varint,11,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// 更新时间, 单位（秒）
+	UpdateTime int64 `protobuf:"varint,12,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// 最近一条支付通知的摘要, 未收到支付通知时为空
+	LatestNotification *NotificationSummary `protobuf:"bytes,13,opt,name=latest_notification,json=latestNotification,proto3" json:"latest_notification,omitempty"`
+	// 平台订单业务状态, CREATED/PREPAID/PAID/CLOSED/REFUNDING/REFUNDED/FAILED
+	State string `protobuf:"bytes,14,opt,name=state,proto3" json:"state,omitempty"`
+	// 平台订单版本号, 每次推进状态加一
+	Version int64 `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`
+	// 商品明细
+	Items []*OrderItem `protobuf:"bytes,16,rep,name=items,proto3" json:"items,omitempty"`
+	// 平台订单元数据
+	Metadata map[string]string `protobuf:"bytes,17,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 由微信官方给定的支付订单号
+	TrxId string `protobuf:"bytes,18,opt,name=trx_id,json=trxId,proto3" json:"trx_id,omitempty"`
+	// 支付完成时间
+	SuccessTime string `protobuf:"bytes,19,opt,name=success_time,json=successTime,proto3" json:"success_time,omitempty"`
+	// 履约状态, PENDING/CONFIRMED/OVERDUE, 为空表示不跟踪履约
+	FulfillmentState string `protobuf:"bytes,20,opt,name=fulfillment_state,json=fulfillmentState,proto3" json:"fulfillment_state,omitempty"`
+	// 确认履约的期限, 单位（秒）
+	FulfillmentDeadline int64 `protobuf:"varint,21,opt,name=fulfillment_deadline,json=fulfillmentDeadline,proto3" json:"fulfillment_deadline,omitempty"`
+	// 确认履约的时间, 单位（秒）
+	FulfillmentConfirmTime int64 `protobuf:"varint,22,opt,name=fulfillment_confirm_time,json=fulfillmentConfirmTime,proto3" json:"fulfillment_confirm_time,omitempty"`
+	// 履约超时后执行的策略, alert/refund
+	FulfillmentOverduePolicy string `protobuf:"bytes,23,opt,name=fulfillment_overdue_policy,json=fulfillmentOverduePolicy,proto3" json:"fulfillment_overdue_policy,omitempty"`
+	// 履约超时自动退款的商户退款单号
+	FulfillmentOutRefundNo string   `protobuf:"bytes,24,opt,name=fulfillment_out_refund_no,json=fulfillmentOutRefundNo,proto3" json:"fulfillment_out_refund_no,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *PlatformOrderInfo) Reset()         { *m = PlatformOrderInfo{} }
+func (m *PlatformOrderInfo) String() string { return proto.CompactTextString(m) }
+func (*PlatformOrderInfo) ProtoMessage()    {}
+func (*PlatformOrderInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{26}
+}
+func (m *PlatformOrderInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlatformOrderInfo.Unmarshal(m, b)
+}
+func (m *PlatformOrderInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlatformOrderInfo.Marshal(b, m, deterministic)
+}
+func (dst *PlatformOrderInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlatformOrderInfo.Merge(dst, src)
+}
+func (m *PlatformOrderInfo) XXX_Size() int {
+	return xxx_messageInfo_PlatformOrderInfo.Size(m)
+}
+func (m *PlatformOrderInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlatformOrderInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlatformOrderInfo proto.InternalMessageInfo
+
+func (m *PlatformOrderInfo) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetMerchantId() string {
+	if m != nil {
+		return m.MerchantId
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetPayerUid() string {
+	if m != nil {
+		return m.PayerUid
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetTradeType() string {
+	if m != nil {
+		return m.TradeType
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetItemDescription() string {
+	if m != nil {
+		return m.ItemDescription
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetItemAmountTotal() int64 {
+	if m != nil {
+		return m.ItemAmountTotal
+	}
+	return 0
+}
+
+func (m *PlatformOrderInfo) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *PlatformOrderInfo) GetRefundedAmountTotal() int64 {
+	if m != nil {
+		return m.RefundedAmountTotal
+	}
+	return 0
+}
+
+func (m *PlatformOrderInfo) GetExpireTime() int64 {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return 0
+}
+
+func (m *PlatformOrderInfo) GetCreateTime() int64 {
+	if m != nil {
+		return m.CreateTime
+	}
+	return 0
+}
+
+func (m *PlatformOrderInfo) GetUpdateTime() int64 {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return 0
+}
+
+func (m *PlatformOrderInfo) GetLatestNotification() *NotificationSummary {
+	if m != nil {
+		return m.LatestNotification
+	}
+	return nil
+}
+
+func (m *PlatformOrderInfo) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *PlatformOrderInfo) GetItems() []*OrderItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *PlatformOrderInfo) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *PlatformOrderInfo) GetTrxId() string {
+	if m != nil {
+		return m.TrxId
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetSuccessTime() string {
+	if m != nil {
+		return m.SuccessTime
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetFulfillmentState() string {
+	if m != nil {
+		return m.FulfillmentState
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetFulfillmentDeadline() int64 {
+	if m != nil {
+		return m.FulfillmentDeadline
+	}
+	return 0
+}
+
+func (m *PlatformOrderInfo) GetFulfillmentConfirmTime() int64 {
+	if m != nil {
+		return m.FulfillmentConfirmTime
+	}
+	return 0
+}
+
+func (m *PlatformOrderInfo) GetFulfillmentOverduePolicy() string {
+	if m != nil {
+		return m.FulfillmentOverduePolicy
+	}
+	return ""
+}
+
+func (m *PlatformOrderInfo) GetFulfillmentOutRefundNo() string {
+	if m != nil {
+		return m.FulfillmentOutRefundNo
+	}
+	return ""
+}
+
+type GetPlatformOrderRequest struct {
+	// 由系统生成的平台订单交易ID
+	TradeId              string   `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPlatformOrderRequest) Reset()         { *m = GetPlatformOrderRequest{} }
+func (m *GetPlatformOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPlatformOrderRequest) ProtoMessage()    {}
+func (*GetPlatformOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{27}
+}
+func (m *GetPlatformOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPlatformOrderRequest.Unmarshal(m, b)
+}
+func (m *GetPlatformOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPlatformOrderRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetPlatformOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPlatformOrderRequest.Merge(dst, src)
+}
+func (m *GetPlatformOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPlatformOrderRequest.Size(m)
+}
+func (m *GetPlatformOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPlatformOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPlatformOrderRequest proto.InternalMessageInfo
+
+func (m *GetPlatformOrderRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+type GetPlatformOrderResponse struct {
+	Order                *PlatformOrderInfo `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetPlatformOrderResponse) Reset()         { *m = GetPlatformOrderResponse{} }
+func (m *GetPlatformOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPlatformOrderResponse) ProtoMessage()    {}
+func (*GetPlatformOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{28}
+}
+func (m *GetPlatformOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPlatformOrderResponse.Unmarshal(m, b)
+}
+func (m *GetPlatformOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPlatformOrderResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetPlatformOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPlatformOrderResponse.Merge(dst, src)
+}
+func (m *GetPlatformOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPlatformOrderResponse.Size(m)
+}
+func (m *GetPlatformOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPlatformOrderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPlatformOrderResponse proto.InternalMessageInfo
+
+func (m *GetPlatformOrderResponse) GetOrder() *PlatformOrderInfo {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+type ListPlatformOrdersRequest struct {
+	// 按应用ID过滤, 可选
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 按用户唯一标识过滤, 可选
+	PayerUid string `protobuf:"bytes,2,opt,name=payer_uid,json=payerUid,proto3" json:"payer_uid,omitempty"`
+	// 按平台订单处理步骤过滤, 可选
+	Status []int32 `protobuf:"varint,3,rep,packed,name=status,proto3" json:"status,omitempty"`
+	// 按创建时间过滤, 左闭区间, 单位（秒）, 可选
+	CreateTimeBegin int64 `protobuf:"varint,4,opt,name=create_time_begin,json=createTimeBegin,proto3" json:"create_time_begin,omitempty"`
+	// 按创建时间过滤, 右开区间, 单位（秒）, 可选
+	CreateTimeEnd int64 `protobuf:"varint,5,opt,name=create_time_end,json=createTimeEnd,proto3" json:"create_time_end,omitempty"`
+	// 分页游标, 首页为空, 后续页使用上一页返回的next_cursor
+	Cursor string `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// 每页数量, 默认20, 最大100
+	PageSize int32 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 按平台订单业务状态过滤, 可选
+	State                []string `protobuf:"bytes,8,rep,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPlatformOrdersRequest) Reset()         { *m = ListPlatformOrdersRequest{} }
+func (m *ListPlatformOrdersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPlatformOrdersRequest) ProtoMessage()    {}
+func (*ListPlatformOrdersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{29}
+}
+func (m *ListPlatformOrdersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPlatformOrdersRequest.Unmarshal(m, b)
+}
+func (m *ListPlatformOrdersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPlatformOrdersRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListPlatformOrdersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPlatformOrdersRequest.Merge(dst, src)
+}
+func (m *ListPlatformOrdersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPlatformOrdersRequest.Size(m)
+}
+func (m *ListPlatformOrdersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPlatformOrdersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPlatformOrdersRequest proto.InternalMessageInfo
+
+func (m *ListPlatformOrdersRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *ListPlatformOrdersRequest) GetPayerUid() string {
+	if m != nil {
+		return m.PayerUid
+	}
+	return ""
+}
+
+func (m *ListPlatformOrdersRequest) GetStatus() []int32 {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ListPlatformOrdersRequest) GetCreateTimeBegin() int64 {
+	if m != nil {
+		return m.CreateTimeBegin
+	}
+	return 0
+}
+
+func (m *ListPlatformOrdersRequest) GetCreateTimeEnd() int64 {
+	if m != nil {
+		return m.CreateTimeEnd
+	}
+	return 0
+}
+
+func (m *ListPlatformOrdersRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *ListPlatformOrdersRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListPlatformOrdersRequest) GetState() []string {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+type ListPlatformOrdersResponse struct {
+	// 平台订单列表, 按创建时间从晚到早排序
+	Orders []*PlatformOrderInfo `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// 下一页的分页游标, 为空表示没有更多数据
+	NextCursor           string   `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPlatformOrdersResponse) Reset()         { *m = ListPlatformOrdersResponse{} }
+func (m *ListPlatformOrdersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPlatformOrdersResponse) ProtoMessage()    {}
+func (*ListPlatformOrdersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{30}
+}
+func (m *ListPlatformOrdersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPlatformOrdersResponse.Unmarshal(m, b)
+}
+func (m *ListPlatformOrdersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPlatformOrdersResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListPlatformOrdersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPlatformOrdersResponse.Merge(dst, src)
+}
+func (m *ListPlatformOrdersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPlatformOrdersResponse.Size(m)
+}
+func (m *ListPlatformOrdersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPlatformOrdersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPlatformOrdersResponse proto.InternalMessageInfo
+
+func (m *ListPlatformOrdersResponse) GetOrders() []*PlatformOrderInfo {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+func (m *ListPlatformOrdersResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+type RefundInfo struct {
+	// 由系统生成的平台订单交易ID
+	TradeId string `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 商户退款单号
+	OutRefundNo string `protobuf:"bytes,2,opt,name=out_refund_no,json=outRefundNo,proto3" json:"out_refund_no,omitempty"`
+	// 由微信官方给定的退款单号
+	RefundId string `protobuf:"bytes,3,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	// 退款原因
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// 订单总额, 单位（分）
+	AmountTotal int64 `protobuf:"varint,5,opt,name=amount_total,json=amountTotal,proto3" json:"amount_total,omitempty"`
+	// 退款金额, 单位（分）
+	AmountRefund int64 `protobuf:"varint,6,opt,name=amount_refund,json=amountRefund,proto3" json:"amount_refund,omitempty"`
+	// 退款单状态, 0: 创建退款单; 1: 申请退款失败; 2: 退款处理中; 3: 退款成功; 4: 退款异常; 5: 退款关闭
+	Status int32 `protobuf:"varint,7,opt,name=status,proto3" json:"status,omitempty"`
+	// 微信退款状态, SUCCESS/CLOSED/PROCESSING/ABNORMAL
+	WxRefundStatus string `protobuf:"bytes,8,opt,name=wx_refund_status,json=wxRefundStatus,proto3" json:"wx_refund_status,omitempty"`
+	// 退款入账账户
+	UserReceivedAccount string `protobuf:"bytes,9,opt,name=user_received_account,json=userReceivedAccount,proto3" json:"user_received_account,omitempty"`
+	// 退款成功时间
+	SuccessTime string `protobuf:"bytes,10,opt,name=success_time,json=successTime,proto3" json:"success_time,omitempty"`
+	// 创建时间, 单位（秒）
+	CreateTime int64 `protobuf:"varint,11,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// 更新时间, 单位（秒）
+	UpdateTime           int64    `protobuf:"varint,12,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefundInfo) Reset()         { *m = RefundInfo{} }
+func (m *RefundInfo) String() string { return proto.CompactTextString(m) }
+func (*RefundInfo) ProtoMessage()    {}
+func (*RefundInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{31}
+}
+func (m *RefundInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundInfo.Unmarshal(m, b)
+}
+func (m *RefundInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundInfo.Marshal(b, m, deterministic)
+}
+func (dst *RefundInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundInfo.Merge(dst, src)
+}
+func (m *RefundInfo) XXX_Size() int {
+	return xxx_messageInfo_RefundInfo.Size(m)
+}
+func (m *RefundInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundInfo proto.InternalMessageInfo
+
+func (m *RefundInfo) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+func (m *RefundInfo) GetOutRefundNo() string {
+	if m != nil {
+		return m.OutRefundNo
+	}
+	return ""
+}
+
+func (m *RefundInfo) GetRefundId() string {
+	if m != nil {
+		return m.RefundId
+	}
+	return ""
+}
+
+func (m *RefundInfo) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RefundInfo) GetAmountTotal() int64 {
+	if m != nil {
+		return m.AmountTotal
+	}
+	return 0
+}
+
+func (m *RefundInfo) GetAmountRefund() int64 {
+	if m != nil {
+		return m.AmountRefund
+	}
+	return 0
+}
+
+func (m *RefundInfo) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *RefundInfo) GetWxRefundStatus() string {
+	if m != nil {
+		return m.WxRefundStatus
+	}
+	return ""
+}
+
+func (m *RefundInfo) GetUserReceivedAccount() string {
+	if m != nil {
+		return m.UserReceivedAccount
+	}
+	return ""
+}
+
+func (m *RefundInfo) GetSuccessTime() string {
+	if m != nil {
+		return m.SuccessTime
+	}
+	return ""
+}
+
+func (m *RefundInfo) GetCreateTime() int64 {
+	if m != nil {
+		return m.CreateTime
+	}
+	return 0
+}
+
+func (m *RefundInfo) GetUpdateTime() int64 {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return 0
+}
+
+type CreateRefundRequest struct {
+	// 由系统生成的平台订单交易ID
+	TradeId string `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 商户退款单号, 为空时由系统生成, 重试时务必传入首次返回(或失败时错误详情中)的商户退款单号
+	OutRefundNo string `protobuf:"bytes,2,opt,name=out_refund_no,json=outRefundNo,proto3" json:"out_refund_no,omitempty"`
+	// 退款金额, 单位（分）, 支持部分退款
+	RefundAmount int64 `protobuf:"varint,3,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"`
+	// 退款原因
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRefundRequest) Reset()         { *m = CreateRefundRequest{} }
+func (m *CreateRefundRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRefundRequest) ProtoMessage()    {}
+func (*CreateRefundRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{32}
+}
+func (m *CreateRefundRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRefundRequest.Unmarshal(m, b)
+}
+func (m *CreateRefundRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRefundRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateRefundRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRefundRequest.Merge(dst, src)
+}
+func (m *CreateRefundRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRefundRequest.Size(m)
+}
+func (m *CreateRefundRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRefundRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRefundRequest proto.InternalMessageInfo
+
+func (m *CreateRefundRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+func (m *CreateRefundRequest) GetOutRefundNo() string {
+	if m != nil {
+		return m.OutRefundNo
+	}
+	return ""
+}
+
+func (m *CreateRefundRequest) GetRefundAmount() int64 {
+	if m != nil {
+		return m.RefundAmount
+	}
+	return 0
+}
+
+func (m *CreateRefundRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type CreateRefundResponse struct {
+	Refund               *RefundInfo `protobuf:"bytes,1,opt,name=refund,proto3" json:"refund,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CreateRefundResponse) Reset()         { *m = CreateRefundResponse{} }
+func (m *CreateRefundResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateRefundResponse) ProtoMessage()    {}
+func (*CreateRefundResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{33}
+}
+func (m *CreateRefundResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRefundResponse.Unmarshal(m, b)
+}
+func (m *CreateRefundResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRefundResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateRefundResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRefundResponse.Merge(dst, src)
+}
+func (m *CreateRefundResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateRefundResponse.Size(m)
+}
+func (m *CreateRefundResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRefundResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRefundResponse proto.InternalMessageInfo
+
+func (m *CreateRefundResponse) GetRefund() *RefundInfo {
+	if m != nil {
+		return m.Refund
+	}
+	return nil
+}
+
+type QueryRefundRequest struct {
+	// 商户退款单号
+	OutRefundNo          string   `protobuf:"bytes,1,opt,name=out_refund_no,json=outRefundNo,proto3" json:"out_refund_no,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryRefundRequest) Reset()         { *m = QueryRefundRequest{} }
+func (m *QueryRefundRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryRefundRequest) ProtoMessage()    {}
+func (*QueryRefundRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{34}
+}
+func (m *QueryRefundRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryRefundRequest.Unmarshal(m, b)
+}
+func (m *QueryRefundRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryRefundRequest.Marshal(b, m, deterministic)
+}
+func (dst *QueryRefundRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRefundRequest.Merge(dst, src)
+}
+func (m *QueryRefundRequest) XXX_Size() int {
+	return xxx_messageInfo_QueryRefundRequest.Size(m)
+}
+func (m *QueryRefundRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRefundRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryRefundRequest proto.InternalMessageInfo
+
+func (m *QueryRefundRequest) GetOutRefundNo() string {
+	if m != nil {
+		return m.OutRefundNo
+	}
+	return ""
+}
+
+type QueryRefundResponse struct {
+	Refund               *RefundInfo `protobuf:"bytes,1,opt,name=refund,proto3" json:"refund,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *QueryRefundResponse) Reset()         { *m = QueryRefundResponse{} }
+func (m *QueryRefundResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryRefundResponse) ProtoMessage()    {}
+func (*QueryRefundResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{35}
+}
+func (m *QueryRefundResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryRefundResponse.Unmarshal(m, b)
+}
+func (m *QueryRefundResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryRefundResponse.Marshal(b, m, deterministic)
+}
+func (dst *QueryRefundResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRefundResponse.Merge(dst, src)
+}
+func (m *QueryRefundResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryRefundResponse.Size(m)
+}
+func (m *QueryRefundResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRefundResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryRefundResponse proto.InternalMessageInfo
+
+func (m *QueryRefundResponse) GetRefund() *RefundInfo {
+	if m != nil {
+		return m.Refund
+	}
+	return nil
+}
+
+type ListRefundsRequest struct {
+	// 由系统生成的平台订单交易ID
+	TradeId              string   `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRefundsRequest) Reset()         { *m = ListRefundsRequest{} }
+func (m *ListRefundsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRefundsRequest) ProtoMessage()    {}
+func (*ListRefundsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{36}
+}
+func (m *ListRefundsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRefundsRequest.Unmarshal(m, b)
+}
+func (m *ListRefundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRefundsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListRefundsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRefundsRequest.Merge(dst, src)
+}
+func (m *ListRefundsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRefundsRequest.Size(m)
+}
+func (m *ListRefundsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRefundsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRefundsRequest proto.InternalMessageInfo
+
+func (m *ListRefundsRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+type ListRefundsResponse struct {
+	Refunds              []*RefundInfo `protobuf:"bytes,1,rep,name=refunds,proto3" json:"refunds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListRefundsResponse) Reset()         { *m = ListRefundsResponse{} }
+func (m *ListRefundsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRefundsResponse) ProtoMessage()    {}
+func (*ListRefundsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{37}
+}
+func (m *ListRefundsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRefundsResponse.Unmarshal(m, b)
+}
+func (m *ListRefundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRefundsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListRefundsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRefundsResponse.Merge(dst, src)
+}
+func (m *ListRefundsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRefundsResponse.Size(m)
+}
+func (m *ListRefundsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRefundsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRefundsResponse proto.InternalMessageInfo
+
+func (m *ListRefundsResponse) GetRefunds() []*RefundInfo {
+	if m != nil {
+		return m.Refunds
+	}
+	return nil
+}
+
+type OrderEvent struct {
+	// 迁移前的业务状态, 新建平台订单时为空
+	FromState string `protobuf:"bytes,1,opt,name=from_state,json=fromState,proto3" json:"from_state,omitempty"`
+	// 迁移前的处理步骤
+	FromStatus int32 `protobuf:"varint,2,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	// 迁移后的业务状态
+	ToState string `protobuf:"bytes,3,opt,name=to_state,json=toState,proto3" json:"to_state,omitempty"`
+	// 迁移后的处理步骤
+	ToStatus int32 `protobuf:"varint,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	// 迁移后的平台订单版本号
+	Version int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// 触发迁移的请求链路ID
+	TraceId string `protobuf:"bytes,6,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// 触发迁移的来源, rpc/notify/job/admin
+	Source string `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	// 导致迁移的微信支付错误码, 可为空
+	WxErrorCode string `protobuf:"bytes,8,opt,name=wx_error_code,json=wxErrorCode,proto3" json:"wx_error_code,omitempty"`
+	// 迁移时间, 单位（秒）
+	CreateTime           int64    `protobuf:"varint,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderEvent) Reset()         { *m = OrderEvent{} }
+func (m *OrderEvent) String() string { return proto.CompactTextString(m) }
+func (*OrderEvent) ProtoMessage()    {}
+func (*OrderEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{38}
+}
+func (m *OrderEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderEvent.Unmarshal(m, b)
+}
+func (m *OrderEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderEvent.Marshal(b, m, deterministic)
+}
+func (dst *OrderEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderEvent.Merge(dst, src)
+}
+func (m *OrderEvent) XXX_Size() int {
+	return xxx_messageInfo_OrderEvent.Size(m)
+}
+func (m *OrderEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderEvent proto.InternalMessageInfo
+
+func (m *OrderEvent) GetFromState() string {
+	if m != nil {
+		return m.FromState
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetFromStatus() int32 {
+	if m != nil {
+		return m.FromStatus
+	}
+	return 0
+}
+
+func (m *OrderEvent) GetToState() string {
+	if m != nil {
+		return m.ToState
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetToStatus() int32 {
+	if m != nil {
+		return m.ToStatus
+	}
+	return 0
+}
+
+func (m *OrderEvent) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *OrderEvent) GetTraceId() string {
+	if m != nil {
+		return m.TraceId
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetWxErrorCode() string {
+	if m != nil {
+		return m.WxErrorCode
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetCreateTime() int64 {
+	if m != nil {
+		return m.CreateTime
+	}
+	return 0
+}
+
+type GetOrderTimelineRequest struct {
+	// 由系统生成的平台订单交易ID
+	TradeId              string   `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrderTimelineRequest) Reset()         { *m = GetOrderTimelineRequest{} }
+func (m *GetOrderTimelineRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrderTimelineRequest) ProtoMessage()    {}
+func (*GetOrderTimelineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{39}
+}
+func (m *GetOrderTimelineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderTimelineRequest.Unmarshal(m, b)
+}
+func (m *GetOrderTimelineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderTimelineRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetOrderTimelineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderTimelineRequest.Merge(dst, src)
+}
+func (m *GetOrderTimelineRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrderTimelineRequest.Size(m)
+}
+func (m *GetOrderTimelineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderTimelineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderTimelineRequest proto.InternalMessageInfo
+
+func (m *GetOrderTimelineRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+type GetOrderTimelineResponse struct {
+	// 状态迁移记录, 按迁移顺序从早到晚排序
+	Events               []*OrderEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetOrderTimelineResponse) Reset()         { *m = GetOrderTimelineResponse{} }
+func (m *GetOrderTimelineResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOrderTimelineResponse) ProtoMessage()    {}
+func (*GetOrderTimelineResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{40}
+}
+func (m *GetOrderTimelineResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderTimelineResponse.Unmarshal(m, b)
+}
+func (m *GetOrderTimelineResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderTimelineResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetOrderTimelineResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderTimelineResponse.Merge(dst, src)
+}
+func (m *GetOrderTimelineResponse) XXX_Size() int {
+	return xxx_messageInfo_GetOrderTimelineResponse.Size(m)
+}
+func (m *GetOrderTimelineResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderTimelineResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderTimelineResponse proto.InternalMessageInfo
+
+func (m *GetOrderTimelineResponse) GetEvents() []*OrderEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type WebhookDelivery struct {
+	// Webhook名称
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Webhook地址
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// 投递状态, PENDING/DELIVERED/DEAD
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// 已投递次数
+	Attempts int32 `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// 最近一次投递失败的原因
+	LastError string `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// 最近一次投递时间, 单位（秒）
+	LastAttemptTime int64 `protobuf:"varint,6,opt,name=last_attempt_time,json=lastAttemptTime,proto3" json:"last_attempt_time,omitempty"`
+	// 投递成功时间, 单位（秒）
+	DeliveredTime        int64    `protobuf:"varint,7,opt,name=delivered_time,json=deliveredTime,proto3" json:"delivered_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{41}
+}
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (dst *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(dst, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetLastAttemptTime() int64 {
+	if m != nil {
+		return m.LastAttemptTime
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetDeliveredTime() int64 {
+	if m != nil {
+		return m.DeliveredTime
+	}
+	return 0
+}
+
+type OutboxEventInfo struct {
+	// 支付事件ID
+	EventId string `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// 支付事件类型, payment.succeeded/payment.closed/refund.succeeded
+	EventType string `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// 由系统生成的平台订单交易ID
+	TradeId string `protobuf:"bytes,3,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 迁移后的平台订单业务状态
+	State string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	// 迁移后的平台订单处理步骤
+	Status int32 `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	// 迁移后的平台订单版本号
+	Version int64 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// 投递状态, PENDING/DELIVERED/DEAD, 任一Webhook进入死信即为DEAD
+	DeliveryStatus string `protobuf:"bytes,7,opt,name=delivery_status,json=deliveryStatus,proto3" json:"delivery_status,omitempty"`
+	// 各Webhook的投递状态
+	Deliveries []*WebhookDelivery `protobuf:"bytes,8,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	// 下一次投递时间, 单位（秒）
+	NextAttemptTime int64 `protobuf:"varint,9,opt,name=next_attempt_time,json=nextAttemptTime,proto3" json:"next_attempt_time,omitempty"`
+	// 创建时间, 单位（秒）
+	CreateTime int64 `protobuf:"varint,10,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// 更新时间, 单位（秒）
+	UpdateTime           int64    `protobuf:"varint,11,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OutboxEventInfo) Reset()         { *m = OutboxEventInfo{} }
+func (m *OutboxEventInfo) String() string { return proto.CompactTextString(m) }
+func (*OutboxEventInfo) ProtoMessage()    {}
+func (*OutboxEventInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{42}
+}
+func (m *OutboxEventInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutboxEventInfo.Unmarshal(m, b)
+}
+func (m *OutboxEventInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutboxEventInfo.Marshal(b, m, deterministic)
+}
+func (dst *OutboxEventInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutboxEventInfo.Merge(dst, src)
+}
+func (m *OutboxEventInfo) XXX_Size() int {
+	return xxx_messageInfo_OutboxEventInfo.Size(m)
+}
+func (m *OutboxEventInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutboxEventInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutboxEventInfo proto.InternalMessageInfo
+
+func (m *OutboxEventInfo) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *OutboxEventInfo) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *OutboxEventInfo) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+func (m *OutboxEventInfo) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *OutboxEventInfo) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *OutboxEventInfo) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *OutboxEventInfo) GetDeliveryStatus() string {
+	if m != nil {
+		return m.DeliveryStatus
+	}
+	return ""
+}
+
+func (m *OutboxEventInfo) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+func (m *OutboxEventInfo) GetNextAttemptTime() int64 {
+	if m != nil {
+		return m.NextAttemptTime
+	}
+	return 0
+}
+
+func (m *OutboxEventInfo) GetCreateTime() int64 {
+	if m != nil {
+		return m.CreateTime
+	}
+	return 0
+}
+
+func (m *OutboxEventInfo) GetUpdateTime() int64 {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return 0
+}
+
+type ListOutboxEventsRequest struct {
+	// 按平台订单交易ID过滤, 可选
+	TradeId string `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// 按投递状态过滤, 可选
+	DeliveryStatus string `protobuf:"bytes,2,opt,name=delivery_status,json=deliveryStatus,proto3" json:"delivery_status,omitempty"`
+	// 分页游标, 首页为空, 后续页使用上一页返回的next_cursor
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// 每页数量, 默认20, 最大100
+	PageSize             int32    `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOutboxEventsRequest) Reset()         { *m = ListOutboxEventsRequest{} }
+func (m *ListOutboxEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOutboxEventsRequest) ProtoMessage()    {}
+func (*ListOutboxEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{43}
+}
+func (m *ListOutboxEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOutboxEventsRequest.Unmarshal(m, b)
+}
+func (m *ListOutboxEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOutboxEventsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListOutboxEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOutboxEventsRequest.Merge(dst, src)
+}
+func (m *ListOutboxEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOutboxEventsRequest.Size(m)
+}
+func (m *ListOutboxEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOutboxEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOutboxEventsRequest proto.InternalMessageInfo
+
+func (m *ListOutboxEventsRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+func (m *ListOutboxEventsRequest) GetDeliveryStatus() string {
+	if m != nil {
+		return m.DeliveryStatus
+	}
+	return ""
+}
+
+func (m *ListOutboxEventsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *ListOutboxEventsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type ListOutboxEventsResponse struct {
+	// 支付事件列表, 按创建时间从晚到早排序
+	Events []*OutboxEventInfo `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// 下一页的分页游标, 为空表示没有更多数据
+	NextCursor           string   `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOutboxEventsResponse) Reset()         { *m = ListOutboxEventsResponse{} }
+func (m *ListOutboxEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOutboxEventsResponse) ProtoMessage()    {}
+func (*ListOutboxEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{44}
+}
+func (m *ListOutboxEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOutboxEventsResponse.Unmarshal(m, b)
+}
+func (m *ListOutboxEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOutboxEventsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListOutboxEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOutboxEventsResponse.Merge(dst, src)
+}
+func (m *ListOutboxEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOutboxEventsResponse.Size(m)
+}
+func (m *ListOutboxEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOutboxEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOutboxEventsResponse proto.InternalMessageInfo
+
+func (m *ListOutboxEventsResponse) GetEvents() []*OutboxEventInfo {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ListOutboxEventsResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+type ReplayPaymentEventsRequest struct {
+	// 按平台订单交易ID列表重放, 最多100个, 与创建时间窗口二选一
+	TradeIds []string `protobuf:"bytes,1,rep,name=trade_ids,json=tradeIds,proto3" json:"trade_ids,omitempty"`
+	// 平台订单创建时间窗口的起始时间（含）, 单位（秒）
+	CreateTimeBegin int64 `protobuf:"varint,2,opt,name=create_time_begin,json=createTimeBegin,proto3" json:"create_time_begin,omitempty"`
+	// 平台订单创建时间窗口的结束时间（不含）, 单位（秒）
+	CreateTimeEnd int64 `protobuf:"varint,3,opt,name=create_time_end,json=createTimeEnd,proto3" json:"create_time_end,omitempty"`
+	// 按创建时间窗口重放时按应用ID过滤, 可选
+	AppId string `protobuf:"bytes,4,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// 重放目标, webhook/redis/file
+	Sink string `protobuf:"bytes,5,opt,name=sink,proto3" json:"sink,omitempty"`
+	// 重放目标地址, webhook为已配置的Webhook地址, redis为流名称, file为重放目录下的文件名
+	Target string `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	// 按创建时间窗口重放时的分页游标, 首页为空, 后续页使用上一页返回的next_cursor
+	Cursor string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// 按创建时间窗口重放时的每页数量, 默认20, 最大100
+	PageSize             int32    `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayPaymentEventsRequest) Reset()         { *m = ReplayPaymentEventsRequest{} }
+func (m *ReplayPaymentEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplayPaymentEventsRequest) ProtoMessage()    {}
+func (*ReplayPaymentEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{45}
+}
+func (m *ReplayPaymentEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayPaymentEventsRequest.Unmarshal(m, b)
+}
+func (m *ReplayPaymentEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayPaymentEventsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReplayPaymentEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayPaymentEventsRequest.Merge(dst, src)
+}
+func (m *ReplayPaymentEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplayPaymentEventsRequest.Size(m)
+}
+func (m *ReplayPaymentEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayPaymentEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayPaymentEventsRequest proto.InternalMessageInfo
+
+func (m *ReplayPaymentEventsRequest) GetTradeIds() []string {
+	if m != nil {
+		return m.TradeIds
+	}
+	return nil
+}
+
+func (m *ReplayPaymentEventsRequest) GetCreateTimeBegin() int64 {
+	if m != nil {
+		return m.CreateTimeBegin
+	}
+	return 0
+}
+
+func (m *ReplayPaymentEventsRequest) GetCreateTimeEnd() int64 {
+	if m != nil {
+		return m.CreateTimeEnd
+	}
+	return 0
+}
+
+func (m *ReplayPaymentEventsRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *ReplayPaymentEventsRequest) GetSink() string {
+	if m != nil {
+		return m.Sink
+	}
+	return ""
+}
+
+func (m *ReplayPaymentEventsRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *ReplayPaymentEventsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *ReplayPaymentEventsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type ReplayPaymentEventsResponse struct {
+	// 本次重放的支付事件数量
+	Replayed int32 `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	// 未重放的平台订单交易ID, 平台订单不存在或尚未支付/关闭
+	SkippedTradeIds []string `protobuf:"bytes,2,rep,name=skipped_trade_ids,json=skippedTradeIds,proto3" json:"skipped_trade_ids,omitempty"`
+	// 下一页的分页游标, 为空表示没有更多数据
+	NextCursor           string   `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayPaymentEventsResponse) Reset()         { *m = ReplayPaymentEventsResponse{} }
+func (m *ReplayPaymentEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplayPaymentEventsResponse) ProtoMessage()    {}
+func (*ReplayPaymentEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{46}
+}
+func (m *ReplayPaymentEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayPaymentEventsResponse.Unmarshal(m, b)
+}
+func (m *ReplayPaymentEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayPaymentEventsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReplayPaymentEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayPaymentEventsResponse.Merge(dst, src)
+}
+func (m *ReplayPaymentEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ReplayPaymentEventsResponse.Size(m)
+}
+func (m *ReplayPaymentEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayPaymentEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayPaymentEventsResponse proto.InternalMessageInfo
+
+func (m *ReplayPaymentEventsResponse) GetReplayed() int32 {
+	if m != nil {
+		return m.Replayed
+	}
+	return 0
+}
+
+func (m *ReplayPaymentEventsResponse) GetSkippedTradeIds() []string {
+	if m != nil {
+		return m.SkippedTradeIds
+	}
+	return nil
+}
+
+func (m *ReplayPaymentEventsResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+type ConfirmFulfillmentRequest struct {
+	// 由系统生成的平台订单交易ID
+	TradeId              string   `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmFulfillmentRequest) Reset()         { *m = ConfirmFulfillmentRequest{} }
+func (m *ConfirmFulfillmentRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfirmFulfillmentRequest) ProtoMessage()    {}
+func (*ConfirmFulfillmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{47}
+}
+func (m *ConfirmFulfillmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmFulfillmentRequest.Unmarshal(m, b)
+}
+func (m *ConfirmFulfillmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmFulfillmentRequest.Marshal(b, m, deterministic)
+}
+func (dst *ConfirmFulfillmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmFulfillmentRequest.Merge(dst, src)
+}
+func (m *ConfirmFulfillmentRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfirmFulfillmentRequest.Size(m)
+}
+func (m *ConfirmFulfillmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmFulfillmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmFulfillmentRequest proto.InternalMessageInfo
+
+func (m *ConfirmFulfillmentRequest) GetTradeId() string {
+	if m != nil {
+		return m.TradeId
+	}
+	return ""
+}
+
+type ConfirmFulfillmentResponse struct {
+	// 履约状态, 确认成功后为CONFIRMED
+	FulfillmentState string `protobuf:"bytes,1,opt,name=fulfillment_state,json=fulfillmentState,proto3" json:"fulfillment_state,omitempty"`
+	// 确认履约的时间, 单位（秒）
+	FulfillmentConfirmTime int64    `protobuf:"varint,2,opt,name=fulfillment_confirm_time,json=fulfillmentConfirmTime,proto3" json:"fulfillment_confirm_time,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *ConfirmFulfillmentResponse) Reset()         { *m = ConfirmFulfillmentResponse{} }
+func (m *ConfirmFulfillmentResponse) String() string { return proto.CompactTextString(m) }
+func (*ConfirmFulfillmentResponse) ProtoMessage()    {}
+func (*ConfirmFulfillmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e, []int{48}
+}
+func (m *ConfirmFulfillmentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmFulfillmentResponse.Unmarshal(m, b)
+}
+func (m *ConfirmFulfillmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmFulfillmentResponse.Marshal(b, m, deterministic)
+}
+func (dst *ConfirmFulfillmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmFulfillmentResponse.Merge(dst, src)
+}
+func (m *ConfirmFulfillmentResponse) XXX_Size() int {
+	return xxx_messageInfo_ConfirmFulfillmentResponse.Size(m)
+}
+func (m *ConfirmFulfillmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmFulfillmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmFulfillmentResponse proto.InternalMessageInfo
+
+func (m *ConfirmFulfillmentResponse) GetFulfillmentState() string {
+	if m != nil {
+		return m.FulfillmentState
+	}
+	return ""
+}
+
+func (m *ConfirmFulfillmentResponse) GetFulfillmentConfirmTime() int64 {
+	if m != nil {
+		return m.FulfillmentConfirmTime
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*PingRequest)(nil), "wechat_payment_callback_service.PingRequest")
+	proto.RegisterType((*PongResponse)(nil), "wechat_payment_callback_service.PongResponse")
+	proto.RegisterType((*MakeNewPlatformTradeIdRequest)(nil), "wechat_payment_callback_service.MakeNewPlatformTradeIdRequest")
+	proto.RegisterType((*MakeNewPlatformTradeIdResponse)(nil), "wechat_payment_callback_service.MakeNewPlatformTradeIdResponse")
+	proto.RegisterType((*OrderItem)(nil), "wechat_payment_callback_service.OrderItem")
+	proto.RegisterType((*MakeNewWxPrepayOrderRequest)(nil), "wechat_payment_callback_service.MakeNewWxPrepayOrderRequest")
+	proto.RegisterMapType((map[string]string)(nil), "wechat_payment_callback_service.MakeNewWxPrepayOrderRequest.MetadataEntry")
+	proto.RegisterType((*WxRequestPaymentParams)(nil), "wechat_payment_callback_service.WxRequestPaymentParams")
+	proto.RegisterType((*MakeNewWxPrepayOrderResponse)(nil), "wechat_payment_callback_service.MakeNewWxPrepayOrderResponse")
+	proto.RegisterType((*MakeNewNativePrepayOrderRequest)(nil), "wechat_payment_callback_service.MakeNewNativePrepayOrderRequest")
+	proto.RegisterType((*MakeNewNativePrepayOrderResponse)(nil), "wechat_payment_callback_service.MakeNewNativePrepayOrderResponse")
+	proto.RegisterType((*H5Info)(nil), "wechat_payment_callback_service.H5Info")
+	proto.RegisterType((*H5SceneInfo)(nil), "wechat_payment_callback_service.H5SceneInfo")
+	proto.RegisterType((*MakeNewH5PrepayOrderRequest)(nil), "wechat_payment_callback_service.MakeNewH5PrepayOrderRequest")
+	proto.RegisterType((*MakeNewH5PrepayOrderResponse)(nil), "wechat_payment_callback_service.MakeNewH5PrepayOrderResponse")
+	proto.RegisterType((*MakeNewAppPrepayOrderRequest)(nil), "wechat_payment_callback_service.MakeNewAppPrepayOrderRequest")
+	proto.RegisterType((*WxAppPaymentParams)(nil), "wechat_payment_callback_service.WxAppPaymentParams")
+	proto.RegisterType((*MakeNewAppPrepayOrderResponse)(nil), "wechat_payment_callback_service.MakeNewAppPrepayOrderResponse")
+	proto.RegisterType((*QueryWxPaymentStatusRequest)(nil), "wechat_payment_callback_service.QueryWxPaymentStatusRequest")
+	proto.RegisterType((*QueryWxPaymentStatusResponse)(nil), "wechat_payment_callback_service.QueryWxPaymentStatusResponse")
+	proto.RegisterMapType((map[string]string)(nil), "wechat_payment_callback_service.QueryWxPaymentStatusResponse.MetadataEntry")
+	proto.RegisterType((*RefreshWxPaymentParamsRequest)(nil), "wechat_payment_callback_service.RefreshWxPaymentParamsRequest")
+	proto.RegisterType((*RefreshWxPaymentParamsResponse)(nil), "wechat_payment_callback_service.RefreshWxPaymentParamsResponse")
+	proto.RegisterType((*WatchPaymentStatusRequest)(nil), "wechat_payment_callback_service.WatchPaymentStatusRequest")
+	proto.RegisterType((*WatchPaymentStatusResponse)(nil), "wechat_payment_callback_service.WatchPaymentStatusResponse")
+	proto.RegisterType((*CloseWxPrepayOrderRequest)(nil), "wechat_payment_callback_service.CloseWxPrepayOrderRequest")
+	proto.RegisterType((*CloseWxPrepayOrderResponse)(nil), "wechat_payment_callback_service.CloseWxPrepayOrderResponse")
+	proto.RegisterType((*NotificationSummary)(nil), "wechat_payment_callback_service.NotificationSummary")
+	proto.RegisterMapType((map[string]string)(nil), "wechat_payment_callback_service.NotificationSummary.MetadataEntry")
+	proto.RegisterType((*PlatformOrderInfo)(nil), "wechat_payment_callback_service.PlatformOrderInfo")
+	proto.RegisterMapType((map[string]string)(nil), "wechat_payment_callback_service.PlatformOrderInfo.MetadataEntry")
+	proto.RegisterType((*GetPlatformOrderRequest)(nil), "wechat_payment_callback_service.GetPlatformOrderRequest")
+	proto.RegisterType((*GetPlatformOrderResponse)(nil), "wechat_payment_callback_service.GetPlatformOrderResponse")
+	proto.RegisterType((*ListPlatformOrdersRequest)(nil), "wechat_payment_callback_service.ListPlatformOrdersRequest")
+	proto.RegisterType((*ListPlatformOrdersResponse)(nil), "wechat_payment_callback_service.ListPlatformOrdersResponse")
+	proto.RegisterType((*RefundInfo)(nil), "wechat_payment_callback_service.RefundInfo")
+	proto.RegisterType((*CreateRefundRequest)(nil), "wechat_payment_callback_service.CreateRefundRequest")
+	proto.RegisterType((*CreateRefundResponse)(nil), "wechat_payment_callback_service.CreateRefundResponse")
+	proto.RegisterType((*QueryRefundRequest)(nil), "wechat_payment_callback_service.QueryRefundRequest")
+	proto.RegisterType((*QueryRefundResponse)(nil), "wechat_payment_callback_service.QueryRefundResponse")
+	proto.RegisterType((*ListRefundsRequest)(nil), "wechat_payment_callback_service.ListRefundsRequest")
+	proto.RegisterType((*ListRefundsResponse)(nil), "wechat_payment_callback_service.ListRefundsResponse")
+	proto.RegisterType((*OrderEvent)(nil), "wechat_payment_callback_service.OrderEvent")
+	proto.RegisterType((*GetOrderTimelineRequest)(nil), "wechat_payment_callback_service.GetOrderTimelineRequest")
+	proto.RegisterType((*GetOrderTimelineResponse)(nil), "wechat_payment_callback_service.GetOrderTimelineResponse")
+	proto.RegisterType((*WebhookDelivery)(nil), "wechat_payment_callback_service.WebhookDelivery")
+	proto.RegisterType((*OutboxEventInfo)(nil), "wechat_payment_callback_service.OutboxEventInfo")
+	proto.RegisterType((*ListOutboxEventsRequest)(nil), "wechat_payment_callback_service.ListOutboxEventsRequest")
+	proto.RegisterType((*ListOutboxEventsResponse)(nil), "wechat_payment_callback_service.ListOutboxEventsResponse")
+	proto.RegisterType((*ReplayPaymentEventsRequest)(nil), "wechat_payment_callback_service.ReplayPaymentEventsRequest")
+	proto.RegisterType((*ReplayPaymentEventsResponse)(nil), "wechat_payment_callback_service.ReplayPaymentEventsResponse")
+	proto.RegisterType((*ConfirmFulfillmentRequest)(nil), "wechat_payment_callback_service.ConfirmFulfillmentRequest")
+	proto.RegisterType((*ConfirmFulfillmentResponse)(nil), "wechat_payment_callback_service.ConfirmFulfillmentResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WechatPaymentCallbackServiceClient is the client API for WechatPaymentCallbackService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WechatPaymentCallbackServiceClient interface {
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PongResponse, error)
+	// 生成平台订单交易ID
+	MakeNewPlatformTradeId(ctx context.Context, in *MakeNewPlatformTradeIdRequest, opts ...grpc.CallOption) (*MakeNewPlatformTradeIdResponse, error)
+	// 创建平台订单和微信预支付订单
 	MakeNewWxPrepayOrder(ctx context.Context, in *MakeNewWxPrepayOrderRequest, opts ...grpc.CallOption) (*MakeNewWxPrepayOrderResponse, error)
+	// 创建平台订单和微信Native预支付订单
+	MakeNewNativePrepayOrder(ctx context.Context, in *MakeNewNativePrepayOrderRequest, opts ...grpc.CallOption) (*MakeNewNativePrepayOrderResponse, error)
+	// 创建平台订单和微信H5预支付订单
+	MakeNewH5PrepayOrder(ctx context.Context, in *MakeNewH5PrepayOrderRequest, opts ...grpc.CallOption) (*MakeNewH5PrepayOrderResponse, error)
+	// 创建平台订单和微信APP预支付订单
+	MakeNewAppPrepayOrder(ctx context.Context, in *MakeNewAppPrepayOrderRequest, opts ...grpc.CallOption) (*MakeNewAppPrepayOrderResponse, error)
 	// 查询微信支付状态
 	QueryWxPaymentStatus(ctx context.Context, in *QueryWxPaymentStatusRequest, opts ...grpc.CallOption) (*QueryWxPaymentStatusResponse, error)
+	// 重新生成带签名支付信息
+	RefreshWxPaymentParams(ctx context.Context, in *RefreshWxPaymentParamsRequest, opts ...grpc.CallOption) (*RefreshWxPaymentParamsResponse, error)
+	// 订阅平台订单支付状态, 首先返回当前状态, 平台订单进入已支付/已关闭等终态后结束
+	WatchPaymentStatus(ctx context.Context, in *WatchPaymentStatusRequest, opts ...grpc.CallOption) (WechatPaymentCallbackService_WatchPaymentStatusClient, error)
 	// 关闭微信预支付订单
 	CloseWxPrepayOrder(ctx context.Context, in *CloseWxPrepayOrderRequest, opts ...grpc.CallOption) (*CloseWxPrepayOrderResponse, error)
+	// 查询平台订单
+	GetPlatformOrder(ctx context.Context, in *GetPlatformOrderRequest, opts ...grpc.CallOption) (*GetPlatformOrderResponse, error)
+	// 分页查询平台订单列表
+	ListPlatformOrders(ctx context.Context, in *ListPlatformOrdersRequest, opts ...grpc.CallOption) (*ListPlatformOrdersResponse, error)
+	// 查询平台订单的状态迁移历史
+	GetOrderTimeline(ctx context.Context, in *GetOrderTimelineRequest, opts ...grpc.CallOption) (*GetOrderTimelineResponse, error)
+	// 申请退款
+	CreateRefund(ctx context.Context, in *CreateRefundRequest, opts ...grpc.CallOption) (*CreateRefundResponse, error)
+	// 查询单笔退款
+	QueryRefund(ctx context.Context, in *QueryRefundRequest, opts ...grpc.CallOption) (*QueryRefundResponse, error)
+	// 查询平台订单的全部退款
+	ListRefunds(ctx context.Context, in *ListRefundsRequest, opts ...grpc.CallOption) (*ListRefundsResponse, error)
+	// 分页查询支付事件及其Webhook投递状态
+	ListOutboxEvents(ctx context.Context, in *ListOutboxEventsRequest, opts ...grpc.CallOption) (*ListOutboxEventsResponse, error)
+	// 由 outbox_events 中的支付事件(没有时由平台订单当前状态)重建支付事件并重放到指定目标, 支付事件ID不变并带有重放标记
+	ReplayPaymentEvents(ctx context.Context, in *ReplayPaymentEventsRequest, opts ...grpc.CallOption) (*ReplayPaymentEventsResponse, error)
+	// 确认平台订单已履约, 超过期限未确认的平台订单按应用策略告警或自动退款
+	ConfirmFulfillment(ctx context.Context, in *ConfirmFulfillmentRequest, opts ...grpc.CallOption) (*ConfirmFulfillmentResponse, error)
+}
+
+type wechatPaymentCallbackServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWechatPaymentCallbackServiceClient(cc *grpc.ClientConn) WechatPaymentCallbackServiceClient {
+	return &wechatPaymentCallbackServiceClient{cc}
+}
+
+func (c *wechatPaymentCallbackServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PongResponse, error) {
+	out := new(PongResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) MakeNewPlatformTradeId(ctx context.Context, in *MakeNewPlatformTradeIdRequest, opts ...grpc.CallOption) (*MakeNewPlatformTradeIdResponse, error) {
+	out := new(MakeNewPlatformTradeIdResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewPlatformTradeId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) MakeNewWxPrepayOrder(ctx context.Context, in *MakeNewWxPrepayOrderRequest, opts ...grpc.CallOption) (*MakeNewWxPrepayOrderResponse, error) {
+	out := new(MakeNewWxPrepayOrderResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewWxPrepayOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) MakeNewNativePrepayOrder(ctx context.Context, in *MakeNewNativePrepayOrderRequest, opts ...grpc.CallOption) (*MakeNewNativePrepayOrderResponse, error) {
+	out := new(MakeNewNativePrepayOrderResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewNativePrepayOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) MakeNewH5PrepayOrder(ctx context.Context, in *MakeNewH5PrepayOrderRequest, opts ...grpc.CallOption) (*MakeNewH5PrepayOrderResponse, error) {
+	out := new(MakeNewH5PrepayOrderResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewH5PrepayOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) MakeNewAppPrepayOrder(ctx context.Context, in *MakeNewAppPrepayOrderRequest, opts ...grpc.CallOption) (*MakeNewAppPrepayOrderResponse, error) {
+	out := new(MakeNewAppPrepayOrderResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewAppPrepayOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) QueryWxPaymentStatus(ctx context.Context, in *QueryWxPaymentStatusRequest, opts ...grpc.CallOption) (*QueryWxPaymentStatusResponse, error) {
+	out := new(QueryWxPaymentStatusResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/QueryWxPaymentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) RefreshWxPaymentParams(ctx context.Context, in *RefreshWxPaymentParamsRequest, opts ...grpc.CallOption) (*RefreshWxPaymentParamsResponse, error) {
+	out := new(RefreshWxPaymentParamsResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/RefreshWxPaymentParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) WatchPaymentStatus(ctx context.Context, in *WatchPaymentStatusRequest, opts ...grpc.CallOption) (WechatPaymentCallbackService_WatchPaymentStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WechatPaymentCallbackService_serviceDesc.Streams[0], "/wechat_payment_callback_service.WechatPaymentCallbackService/WatchPaymentStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wechatPaymentCallbackServiceWatchPaymentStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WechatPaymentCallbackService_WatchPaymentStatusClient interface {
+	Recv() (*WatchPaymentStatusResponse, error)
+	grpc.ClientStream
+}
+
+type wechatPaymentCallbackServiceWatchPaymentStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *wechatPaymentCallbackServiceWatchPaymentStatusClient) Recv() (*WatchPaymentStatusResponse, error) {
+	m := new(WatchPaymentStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) CloseWxPrepayOrder(ctx context.Context, in *CloseWxPrepayOrderRequest, opts ...grpc.CallOption) (*CloseWxPrepayOrderResponse, error) {
+	out := new(CloseWxPrepayOrderResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/CloseWxPrepayOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type wechatPaymentCallbackServiceClient struct {
-	cc *grpc.ClientConn
+func (c *wechatPaymentCallbackServiceClient) GetPlatformOrder(ctx context.Context, in *GetPlatformOrderRequest, opts ...grpc.CallOption) (*GetPlatformOrderResponse, error) {
+	out := new(GetPlatformOrderResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/GetPlatformOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewWechatPaymentCallbackServiceClient(cc *grpc.ClientConn) WechatPaymentCallbackServiceClient {
-	return &wechatPaymentCallbackServiceClient{cc}
+func (c *wechatPaymentCallbackServiceClient) ListPlatformOrders(ctx context.Context, in *ListPlatformOrdersRequest, opts ...grpc.CallOption) (*ListPlatformOrdersResponse, error) {
+	out := new(ListPlatformOrdersResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/ListPlatformOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *wechatPaymentCallbackServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PongResponse, error) {
-	out := new(PongResponse)
-	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/Ping", in, out, opts...)
+func (c *wechatPaymentCallbackServiceClient) GetOrderTimeline(ctx context.Context, in *GetOrderTimelineRequest, opts ...grpc.CallOption) (*GetOrderTimelineResponse, error) {
+	out := new(GetOrderTimelineResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/GetOrderTimeline", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *wechatPaymentCallbackServiceClient) MakeNewPlatformTradeId(ctx context.Context, in *MakeNewPlatformTradeIdRequest, opts ...grpc.CallOption) (*MakeNewPlatformTradeIdResponse, error) {
-	out := new(MakeNewPlatformTradeIdResponse)
-	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewPlatformTradeId", in, out, opts...)
+func (c *wechatPaymentCallbackServiceClient) CreateRefund(ctx context.Context, in *CreateRefundRequest, opts ...grpc.CallOption) (*CreateRefundResponse, error) {
+	out := new(CreateRefundResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/CreateRefund", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *wechatPaymentCallbackServiceClient) MakeNewWxPrepayOrder(ctx context.Context, in *MakeNewWxPrepayOrderRequest, opts ...grpc.CallOption) (*MakeNewWxPrepayOrderResponse, error) {
-	out := new(MakeNewWxPrepayOrderResponse)
-	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewWxPrepayOrder", in, out, opts...)
+func (c *wechatPaymentCallbackServiceClient) QueryRefund(ctx context.Context, in *QueryRefundRequest, opts ...grpc.CallOption) (*QueryRefundResponse, error) {
+	out := new(QueryRefundResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/QueryRefund", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *wechatPaymentCallbackServiceClient) QueryWxPaymentStatus(ctx context.Context, in *QueryWxPaymentStatusRequest, opts ...grpc.CallOption) (*QueryWxPaymentStatusResponse, error) {
-	out := new(QueryWxPaymentStatusResponse)
-	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/QueryWxPaymentStatus", in, out, opts...)
+func (c *wechatPaymentCallbackServiceClient) ListRefunds(ctx context.Context, in *ListRefundsRequest, opts ...grpc.CallOption) (*ListRefundsResponse, error) {
+	out := new(ListRefundsResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/ListRefunds", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *wechatPaymentCallbackServiceClient) CloseWxPrepayOrder(ctx context.Context, in *CloseWxPrepayOrderRequest, opts ...grpc.CallOption) (*CloseWxPrepayOrderResponse, error) {
-	out := new(CloseWxPrepayOrderResponse)
-	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/CloseWxPrepayOrder", in, out, opts...)
+func (c *wechatPaymentCallbackServiceClient) ListOutboxEvents(ctx context.Context, in *ListOutboxEventsRequest, opts ...grpc.CallOption) (*ListOutboxEventsResponse, error) {
+	out := new(ListOutboxEventsResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/ListOutboxEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) ReplayPaymentEvents(ctx context.Context, in *ReplayPaymentEventsRequest, opts ...grpc.CallOption) (*ReplayPaymentEventsResponse, error) {
+	out := new(ReplayPaymentEventsResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/ReplayPaymentEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wechatPaymentCallbackServiceClient) ConfirmFulfillment(ctx context.Context, in *ConfirmFulfillmentRequest, opts ...grpc.CallOption) (*ConfirmFulfillmentResponse, error) {
+	out := new(ConfirmFulfillmentResponse)
+	err := c.cc.Invoke(ctx, "/wechat_payment_callback_service.WechatPaymentCallbackService/ConfirmFulfillment", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -647,10 +3676,38 @@ type WechatPaymentCallbackServiceServer interface {
 	MakeNewPlatformTradeId(context.Context, *MakeNewPlatformTradeIdRequest) (*MakeNewPlatformTradeIdResponse, error)
 	// 创建平台订单和微信预支付订单
 	MakeNewWxPrepayOrder(context.Context, *MakeNewWxPrepayOrderRequest) (*MakeNewWxPrepayOrderResponse, error)
+	// 创建平台订单和微信Native预支付订单
+	MakeNewNativePrepayOrder(context.Context, *MakeNewNativePrepayOrderRequest) (*MakeNewNativePrepayOrderResponse, error)
+	// 创建平台订单和微信H5预支付订单
+	MakeNewH5PrepayOrder(context.Context, *MakeNewH5PrepayOrderRequest) (*MakeNewH5PrepayOrderResponse, error)
+	// 创建平台订单和微信APP预支付订单
+	MakeNewAppPrepayOrder(context.Context, *MakeNewAppPrepayOrderRequest) (*MakeNewAppPrepayOrderResponse, error)
 	// 查询微信支付状态
 	QueryWxPaymentStatus(context.Context, *QueryWxPaymentStatusRequest) (*QueryWxPaymentStatusResponse, error)
+	// 重新生成带签名支付信息
+	RefreshWxPaymentParams(context.Context, *RefreshWxPaymentParamsRequest) (*RefreshWxPaymentParamsResponse, error)
+	// 订阅平台订单支付状态, 首先返回当前状态, 平台订单进入已支付/已关闭等终态后结束
+	WatchPaymentStatus(*WatchPaymentStatusRequest, WechatPaymentCallbackService_WatchPaymentStatusServer) error
 	// 关闭微信预支付订单
 	CloseWxPrepayOrder(context.Context, *CloseWxPrepayOrderRequest) (*CloseWxPrepayOrderResponse, error)
+	// 查询平台订单
+	GetPlatformOrder(context.Context, *GetPlatformOrderRequest) (*GetPlatformOrderResponse, error)
+	// 分页查询平台订单列表
+	ListPlatformOrders(context.Context, *ListPlatformOrdersRequest) (*ListPlatformOrdersResponse, error)
+	// 查询平台订单的状态迁移历史
+	GetOrderTimeline(context.Context, *GetOrderTimelineRequest) (*GetOrderTimelineResponse, error)
+	// 申请退款
+	CreateRefund(context.Context, *CreateRefundRequest) (*CreateRefundResponse, error)
+	// 查询单笔退款
+	QueryRefund(context.Context, *QueryRefundRequest) (*QueryRefundResponse, error)
+	// 查询平台订单的全部退款
+	ListRefunds(context.Context, *ListRefundsRequest) (*ListRefundsResponse, error)
+	// 分页查询支付事件及其Webhook投递状态
+	ListOutboxEvents(context.Context, *ListOutboxEventsRequest) (*ListOutboxEventsResponse, error)
+	// 由 outbox_events 中的支付事件(没有时由平台订单当前状态)重建支付事件并重放到指定目标, 支付事件ID不变并带有重放标记
+	ReplayPaymentEvents(context.Context, *ReplayPaymentEventsRequest) (*ReplayPaymentEventsResponse, error)
+	// 确认平台订单已履约, 超过期限未确认的平台订单按应用策略告警或自动退款
+	ConfirmFulfillment(context.Context, *ConfirmFulfillmentRequest) (*ConfirmFulfillmentResponse, error)
 }
 
 func RegisterWechatPaymentCallbackServiceServer(s *grpc.Server, srv WechatPaymentCallbackServiceServer) {
@@ -711,6 +3768,60 @@ func _WechatPaymentCallbackService_MakeNewWxPrepayOrder_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WechatPaymentCallbackService_MakeNewNativePrepayOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MakeNewNativePrepayOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).MakeNewNativePrepayOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewNativePrepayOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).MakeNewNativePrepayOrder(ctx, req.(*MakeNewNativePrepayOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_MakeNewH5PrepayOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MakeNewH5PrepayOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).MakeNewH5PrepayOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewH5PrepayOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).MakeNewH5PrepayOrder(ctx, req.(*MakeNewH5PrepayOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_MakeNewAppPrepayOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MakeNewAppPrepayOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).MakeNewAppPrepayOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/MakeNewAppPrepayOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).MakeNewAppPrepayOrder(ctx, req.(*MakeNewAppPrepayOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WechatPaymentCallbackService_QueryWxPaymentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryWxPaymentStatusRequest)
 	if err := dec(in); err != nil {
@@ -729,6 +3840,45 @@ func _WechatPaymentCallbackService_QueryWxPaymentStatus_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WechatPaymentCallbackService_RefreshWxPaymentParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshWxPaymentParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).RefreshWxPaymentParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/RefreshWxPaymentParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).RefreshWxPaymentParams(ctx, req.(*RefreshWxPaymentParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_WatchPaymentStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPaymentStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WechatPaymentCallbackServiceServer).WatchPaymentStatus(m, &wechatPaymentCallbackServiceWatchPaymentStatusServer{stream})
+}
+
+type WechatPaymentCallbackService_WatchPaymentStatusServer interface {
+	Send(*WatchPaymentStatusResponse) error
+	grpc.ServerStream
+}
+
+type wechatPaymentCallbackServiceWatchPaymentStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *wechatPaymentCallbackServiceWatchPaymentStatusServer) Send(m *WatchPaymentStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _WechatPaymentCallbackService_CloseWxPrepayOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CloseWxPrepayOrderRequest)
 	if err := dec(in); err != nil {
@@ -747,6 +3897,168 @@ func _WechatPaymentCallbackService_CloseWxPrepayOrder_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WechatPaymentCallbackService_GetPlatformOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlatformOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).GetPlatformOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/GetPlatformOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).GetPlatformOrder(ctx, req.(*GetPlatformOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_ListPlatformOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlatformOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).ListPlatformOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/ListPlatformOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).ListPlatformOrders(ctx, req.(*ListPlatformOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_GetOrderTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).GetOrderTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/GetOrderTimeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).GetOrderTimeline(ctx, req.(*GetOrderTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_CreateRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).CreateRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/CreateRefund",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).CreateRefund(ctx, req.(*CreateRefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_QueryRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).QueryRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/QueryRefund",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).QueryRefund(ctx, req.(*QueryRefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_ListRefunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRefundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).ListRefunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/ListRefunds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).ListRefunds(ctx, req.(*ListRefundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_ListOutboxEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOutboxEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).ListOutboxEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/ListOutboxEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).ListOutboxEvents(ctx, req.(*ListOutboxEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_ReplayPaymentEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayPaymentEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).ReplayPaymentEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/ReplayPaymentEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).ReplayPaymentEvents(ctx, req.(*ReplayPaymentEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WechatPaymentCallbackService_ConfirmFulfillment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmFulfillmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WechatPaymentCallbackServiceServer).ConfirmFulfillment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wechat_payment_callback_service.WechatPaymentCallbackService/ConfirmFulfillment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WechatPaymentCallbackServiceServer).ConfirmFulfillment(ctx, req.(*ConfirmFulfillmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WechatPaymentCallbackService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "wechat_payment_callback_service.WechatPaymentCallbackService",
 	HandlerType: (*WechatPaymentCallbackServiceServer)(nil),
@@ -763,66 +4075,268 @@ var _WechatPaymentCallbackService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "MakeNewWxPrepayOrder",
 			Handler:    _WechatPaymentCallbackService_MakeNewWxPrepayOrder_Handler,
 		},
+		{
+			MethodName: "MakeNewNativePrepayOrder",
+			Handler:    _WechatPaymentCallbackService_MakeNewNativePrepayOrder_Handler,
+		},
+		{
+			MethodName: "MakeNewH5PrepayOrder",
+			Handler:    _WechatPaymentCallbackService_MakeNewH5PrepayOrder_Handler,
+		},
+		{
+			MethodName: "MakeNewAppPrepayOrder",
+			Handler:    _WechatPaymentCallbackService_MakeNewAppPrepayOrder_Handler,
+		},
 		{
 			MethodName: "QueryWxPaymentStatus",
 			Handler:    _WechatPaymentCallbackService_QueryWxPaymentStatus_Handler,
 		},
+		{
+			MethodName: "RefreshWxPaymentParams",
+			Handler:    _WechatPaymentCallbackService_RefreshWxPaymentParams_Handler,
+		},
 		{
 			MethodName: "CloseWxPrepayOrder",
 			Handler:    _WechatPaymentCallbackService_CloseWxPrepayOrder_Handler,
 		},
+		{
+			MethodName: "GetPlatformOrder",
+			Handler:    _WechatPaymentCallbackService_GetPlatformOrder_Handler,
+		},
+		{
+			MethodName: "ListPlatformOrders",
+			Handler:    _WechatPaymentCallbackService_ListPlatformOrders_Handler,
+		},
+		{
+			MethodName: "GetOrderTimeline",
+			Handler:    _WechatPaymentCallbackService_GetOrderTimeline_Handler,
+		},
+		{
+			MethodName: "CreateRefund",
+			Handler:    _WechatPaymentCallbackService_CreateRefund_Handler,
+		},
+		{
+			MethodName: "QueryRefund",
+			Handler:    _WechatPaymentCallbackService_QueryRefund_Handler,
+		},
+		{
+			MethodName: "ListRefunds",
+			Handler:    _WechatPaymentCallbackService_ListRefunds_Handler,
+		},
+		{
+			MethodName: "ListOutboxEvents",
+			Handler:    _WechatPaymentCallbackService_ListOutboxEvents_Handler,
+		},
+		{
+			MethodName: "ReplayPaymentEvents",
+			Handler:    _WechatPaymentCallbackService_ReplayPaymentEvents_Handler,
+		},
+		{
+			MethodName: "ConfirmFulfillment",
+			Handler:    _WechatPaymentCallbackService_ConfirmFulfillment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPaymentStatus",
+			Handler:       _WechatPaymentCallbackService_WatchPaymentStatus_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/amazingchow/wechat-payment-callback-service/protos/wechat_payment_callback_service.proto",
 }
 
 func init() {
-	proto.RegisterFile("github.com/amazingchow/wechat-payment-callback-service/protos/wechat_payment_callback_service.proto", fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12)
-}
-
-var fileDescriptor_wechat_payment_callback_service_524a1a52b0d32c12 = []byte{
-	// 673 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x5d, 0x6f, 0xd3, 0x30,
-	0x14, 0x5d, 0xb6, 0xb5, 0x5b, 0x6f, 0xc7, 0x97, 0xb5, 0x4d, 0x5d, 0xd7, 0xb1, 0x91, 0xa7, 0x81,
-	0xe8, 0x26, 0x0d, 0x09, 0x10, 0xe3, 0x43, 0x30, 0x5e, 0x86, 0x80, 0x95, 0xb6, 0xa8, 0x12, 0x2f,
-	0x91, 0x97, 0x5c, 0x32, 0x6b, 0x4d, 0x62, 0x6c, 0x87, 0x35, 0xfc, 0x09, 0x9e, 0x78, 0x42, 0xe2,
-	0xcf, 0xf0, 0xc6, 0xff, 0xe0, 0x7f, 0xa0, 0x38, 0x2e, 0x6d, 0x45, 0xd6, 0x56, 0xe5, 0xad, 0x3e,
-	0x39, 0xe7, 0xfa, 0xf8, 0x9e, 0x6b, 0x17, 0x5c, 0x9f, 0xa9, 0xb3, 0xf8, 0x74, 0xcf, 0x8d, 0x82,
-	0x7d, 0x1a, 0xd0, 0x2f, 0x2c, 0xf4, 0xdd, 0xb3, 0xe8, 0x62, 0xff, 0x02, 0xdd, 0x33, 0xaa, 0xea,
-	0x9c, 0x26, 0x01, 0x86, 0xaa, 0xee, 0xd2, 0x6e, 0xf7, 0x94, 0xba, 0xe7, 0x75, 0x89, 0xe2, 0x33,
-	0x73, 0x71, 0x9f, 0x8b, 0x48, 0x45, 0xd2, 0xd0, 0x1c, 0x43, 0x73, 0xfa, 0x34, 0xc7, 0xd0, 0xf6,
-	0x34, 0x8d, 0x6c, 0x4f, 0xa0, 0xd9, 0x57, 0xa0, 0xdc, 0x60, 0xa1, 0xdf, 0xc4, 0x4f, 0x31, 0x4a,
-	0x65, 0x5f, 0x85, 0x95, 0x46, 0x94, 0x2e, 0x25, 0x8f, 0x42, 0x89, 0x76, 0x0b, 0xb6, 0xde, 0xd0,
-	0x73, 0x7c, 0x8b, 0x17, 0x8d, 0x2e, 0x55, 0x1f, 0x23, 0x11, 0xb4, 0x05, 0xf5, 0xf0, 0xd8, 0x33,
-	0x02, 0xb2, 0x06, 0x45, 0xca, 0xb9, 0xc3, 0xbc, 0x8a, 0xb5, 0x63, 0xed, 0x96, 0x9a, 0x05, 0xca,
-	0xf9, 0xb1, 0x47, 0x36, 0xa1, 0xc4, 0x69, 0x82, 0xc2, 0x89, 0x99, 0x57, 0x99, 0xd7, 0x5f, 0x96,
-	0x35, 0xf0, 0x9e, 0x79, 0xf6, 0x21, 0xdc, 0xbc, 0xac, 0x68, 0xb6, 0x2d, 0xd9, 0x80, 0x65, 0x95,
-	0x42, 0x83, 0xba, 0x4b, 0x2a, 0xa3, 0xd8, 0x3f, 0x2d, 0xd8, 0x34, 0xea, 0x4e, 0xaf, 0x21, 0x90,
-	0xd3, 0xe4, 0x44, 0x78, 0x28, 0xfe, 0xc3, 0xd0, 0xc8, 0x76, 0x0b, 0x23, 0xdb, 0x91, 0xdb, 0x70,
-	0x9d, 0x29, 0x0c, 0x1c, 0x0f, 0xa5, 0x2b, 0x18, 0x57, 0x2c, 0x0a, 0x2b, 0x8b, 0x9a, 0x72, 0x2d,
-	0xc5, 0x5f, 0x0e, 0x60, 0x72, 0x07, 0x6e, 0x68, 0x2a, 0x0d, 0xa2, 0x38, 0x54, 0x8e, 0x8a, 0x14,
-	0xed, 0x56, 0x0a, 0x3b, 0xd6, 0xee, 0x42, 0xc6, 0x7d, 0xae, 0xf1, 0x76, 0x0a, 0xdb, 0x3f, 0x2c,
-	0x58, 0xef, 0xf4, 0x8c, 0xe7, 0x46, 0x16, 0x4e, 0x83, 0x0a, 0x1a, 0x48, 0x52, 0x83, 0x92, 0x62,
-	0x01, 0x4a, 0x45, 0x03, 0xae, 0xcf, 0xb0, 0xd0, 0x1c, 0x00, 0x64, 0x15, 0x0a, 0x61, 0x14, 0xba,
-	0x68, 0xce, 0x90, 0x2d, 0x48, 0x05, 0x96, 0x38, 0x75, 0xcf, 0xa9, 0x8f, 0x7d, 0xff, 0x66, 0x99,
-	0x9e, 0x5b, 0x32, 0x3f, 0x74, 0x54, 0xc2, 0xd1, 0x18, 0x5f, 0x4e, 0x81, 0x76, 0xc2, 0x75, 0x9b,
-	0x39, 0x4d, 0x9c, 0x74, 0xad, 0x8d, 0x6a, 0x5d, 0xd2, 0x62, 0x7e, 0x68, 0x47, 0x50, 0xcb, 0xef,
-	0xb2, 0x49, 0xe8, 0x04, 0x8a, 0x5c, 0xfb, 0xd5, 0x16, 0xcb, 0x07, 0x0f, 0xf6, 0x26, 0x0d, 0x64,
-	0xfe, 0x71, 0x9b, 0xa6, 0x8c, 0xfd, 0x10, 0x36, 0xdf, 0xc5, 0x28, 0x92, 0x4e, 0xcf, 0x7c, 0x6f,
-	0x29, 0xaa, 0x62, 0xd9, 0x8f, 0x75, 0xcc, 0x44, 0xfc, 0xb2, 0xa0, 0x96, 0x2f, 0x35, 0x5e, 0x2f,
-	0x19, 0x89, 0xe1, 0x92, 0xf3, 0xa3, 0xa9, 0xaf, 0x41, 0x51, 0x89, 0xde, 0x60, 0x1c, 0x0a, 0x4a,
-	0xf4, 0x8e, 0x3d, 0xb2, 0x05, 0x90, 0x29, 0x86, 0xba, 0x59, 0xd2, 0x88, 0x6e, 0xe7, 0x36, 0x94,
-	0xb3, 0xcf, 0x52, 0x51, 0x85, 0xa6, 0xa3, 0x99, 0x22, 0x75, 0x84, 0xe4, 0x16, 0xac, 0xc8, 0xd8,
-	0x75, 0x51, 0x4a, 0x27, 0x4d, 0xb4, 0x52, 0xd4, 0x8c, 0xb2, 0xc1, 0xda, 0x2c, 0x40, 0xfb, 0x3e,
-	0x6c, 0x1c, 0x75, 0x23, 0x89, 0xb9, 0xb3, 0x3d, 0xa6, 0x09, 0x35, 0xa8, 0xe6, 0xe9, 0xb2, 0x0e,
-	0x1c, 0xfc, 0x2e, 0x40, 0xad, 0xa3, 0xf3, 0x31, 0x1d, 0x3a, 0x32, 0xe9, 0xb4, 0xb2, 0x70, 0x08,
-	0xc2, 0x62, 0xfa, 0x0c, 0x90, 0xbb, 0x13, 0x63, 0x1c, 0x7a, 0x2d, 0xaa, 0xf5, 0xc9, 0xec, 0xe1,
-	0xc7, 0x64, 0x8e, 0x7c, 0xb7, 0x60, 0x3d, 0xff, 0xea, 0x93, 0xa7, 0x13, 0x6b, 0x8d, 0x7d, 0x88,
-	0xaa, 0xcf, 0x66, 0xd6, 0xff, 0x75, 0xf7, 0xcd, 0x82, 0xd5, 0xbc, 0xa1, 0x27, 0x8f, 0xa7, 0xad,
-	0x9d, 0x97, 0x5a, 0xf5, 0xc9, 0x8c, 0xea, 0x11, 0x5f, 0x79, 0x03, 0x3e, 0x85, 0xaf, 0x31, 0x57,
-	0x6a, 0x0a, 0x5f, 0xe3, 0x6e, 0x95, 0x3d, 0x47, 0xbe, 0x5a, 0x40, 0xfe, 0x1d, 0x3a, 0xf2, 0x68,
-	0x62, 0xdd, 0x4b, 0x27, 0xbc, 0x7a, 0x38, 0x93, 0xb6, 0xef, 0xe8, 0xc5, 0xeb, 0x0f, 0xaf, 0x66,
-	0xfc, 0x57, 0x65, 0xa1, 0x42, 0x11, 0xd2, 0x6e, 0xf6, 0xf7, 0xea, 0xf8, 0x18, 0xca, 0xd3, 0xa2,
-	0xfe, 0x7d, 0xef, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xab, 0x85, 0x39, 0x9c, 0xaa, 0x07, 0x00,
-	0x00,
+	proto.RegisterFile("github.com/amazingchow/wechat-payment-callback-service/protos/wechat_payment_callback_service.proto", fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e)
+}
+
+var fileDescriptor_wechat_payment_callback_service_439d503e6a4bc56e = []byte{
+	// 2978 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0x5b, 0x6f, 0xdc, 0xc6,
+	0x15, 0x36, 0x77, 0xb5, 0xb7, 0xb3, 0x92, 0x25, 0x53, 0x96, 0xbd, 0xa6, 0xec, 0x48, 0x61, 0xd0,
+	0x5a, 0x4d, 0x62, 0x3b, 0x55, 0xac, 0xd6, 0x8e, 0x72, 0xb1, 0xad, 0x38, 0x91, 0x72, 0xb1, 0x15,
+	0xca, 0x81, 0x80, 0x34, 0x2d, 0x31, 0x22, 0x47, 0xbb, 0x84, 0x76, 0x49, 0x9a, 0x1c, 0x4a, 0xbb,
+	0x06, 0xfa, 0x54, 0x24, 0x40, 0xfa, 0xd2, 0x26, 0x40, 0x2f, 0x40, 0x81, 0x16, 0x45, 0xd1, 0x97,
+	0xbe, 0xf6, 0x1f, 0x14, 0xed, 0x43, 0xd1, 0x7f, 0x50, 0xa0, 0x3f, 0xa0, 0xff, 0xa2, 0x98, 0x0b,
+	0xc9, 0xe1, 0x2e, 0xf7, 0xa2, 0x55, 0xf3, 0xe0, 0xa7, 0x5d, 0x9e, 0x99, 0x73, 0xe6, 0xf0, 0x3b,
+	0x97, 0x39, 0x67, 0x86, 0x60, 0x35, 0x1d, 0xd2, 0x8a, 0x0e, 0x6e, 0x5a, 0x5e, 0xe7, 0x16, 0xea,
+	0xa0, 0x67, 0x8e, 0xdb, 0xb4, 0x5a, 0xde, 0xc9, 0xad, 0x13, 0x6c, 0xb5, 0x10, 0xb9, 0xe1, 0xa3,
+	0x5e, 0x07, 0xbb, 0xe4, 0x86, 0x85, 0xda, 0xed, 0x03, 0x64, 0x1d, 0xdd, 0x08, 0x71, 0x70, 0xec,
+	0x58, 0xf8, 0x96, 0x1f, 0x78, 0xc4, 0x0b, 0xc5, 0x34, 0x53, 0x4c, 0x33, 0xe3, 0x69, 0xa6, 0x98,
+	0x76, 0x93, 0x4d, 0x53, 0x57, 0xc6, 0x4c, 0xd3, 0xe7, 0xa0, 0xbe, 0xeb, 0xb8, 0x4d, 0x03, 0x3f,
+	0x8d, 0x70, 0x48, 0xf4, 0xf3, 0x30, 0xbb, 0xeb, 0xd1, 0xc7, 0xd0, 0xf7, 0xdc, 0x10, 0xeb, 0x7b,
+	0x70, 0xed, 0x63, 0x74, 0x84, 0x1f, 0xe1, 0x93, 0xdd, 0x36, 0x22, 0x87, 0x5e, 0xd0, 0x79, 0x12,
+	0x20, 0x1b, 0xef, 0xd8, 0x82, 0x41, 0x5d, 0x82, 0x32, 0xf2, 0x7d, 0xd3, 0xb1, 0x1b, 0xca, 0xaa,
+	0xb2, 0x56, 0x33, 0x4a, 0xc8, 0xf7, 0x77, 0x6c, 0x75, 0x19, 0x6a, 0x3e, 0xea, 0xe1, 0xc0, 0x8c,
+	0x1c, 0xbb, 0x51, 0x60, 0x23, 0x55, 0x46, 0xf8, 0xd4, 0xb1, 0xf5, 0x4d, 0x78, 0x61, 0x98, 0x50,
+	0xbe, 0xac, 0x7a, 0x05, 0xaa, 0x84, 0x92, 0x52, 0xb9, 0x15, 0xc2, 0xa7, 0xe8, 0x7f, 0x50, 0xa0,
+	0xf6, 0x38, 0xb0, 0x71, 0xb0, 0x43, 0x70, 0x87, 0x2e, 0x1f, 0x1e, 0x45, 0xd2, 0xf2, 0xe1, 0x51,
+	0xb4, 0x63, 0xab, 0x2a, 0xcc, 0xb8, 0xa8, 0x83, 0xc5, 0xca, 0xec, 0xbf, 0x7a, 0x0d, 0x20, 0x72,
+	0x1d, 0x62, 0xfa, 0x81, 0x63, 0xe1, 0x46, 0x71, 0x55, 0x59, 0x2b, 0x1a, 0x35, 0x4a, 0xd9, 0xa5,
+	0x04, 0x55, 0x83, 0xea, 0xd3, 0x08, 0xb9, 0xc4, 0x21, 0xbd, 0xc6, 0x0c, 0x1b, 0x4c, 0x9e, 0xd5,
+	0x57, 0x41, 0xe5, 0x38, 0xfa, 0xa8, 0x67, 0x36, 0x3d, 0xcf, 0x0e, 0xe9, 0x8a, 0x25, 0x26, 0x7c,
+	0x21, 0x19, 0x79, 0x9f, 0x0e, 0xec, 0xd8, 0xfa, 0x5f, 0x8a, 0xb0, 0x2c, 0xde, 0x6f, 0xbf, 0xbb,
+	0x1b, 0x60, 0x1f, 0xf5, 0x98, 0xc2, 0x67, 0x80, 0x2c, 0x03, 0x48, 0x31, 0x03, 0x88, 0xfa, 0x3d,
+	0x58, 0x70, 0x08, 0xee, 0x98, 0x36, 0x0e, 0xad, 0xc0, 0xf1, 0x89, 0xe3, 0xb9, 0xec, 0x05, 0x6a,
+	0xc6, 0x3c, 0xa5, 0xbf, 0x9b, 0x92, 0xd5, 0x97, 0xe1, 0x02, 0x9b, 0x8a, 0x3a, 0x5e, 0xe4, 0x12,
+	0x93, 0x78, 0x04, 0xb5, 0xd9, 0x6b, 0x14, 0xf9, 0xdc, 0xfb, 0x8c, 0xfe, 0x84, 0x92, 0xd5, 0x7b,
+	0x50, 0xa2, 0xa4, 0xb0, 0x51, 0x5e, 0x2d, 0xae, 0xd5, 0xd7, 0x5f, 0xbe, 0x39, 0xce, 0xe1, 0x12,
+	0xa3, 0x18, 0x9c, 0x51, 0x3d, 0x84, 0x6a, 0x07, 0x13, 0x64, 0x23, 0x82, 0x1a, 0x15, 0x26, 0xe4,
+	0x83, 0xb1, 0x42, 0x46, 0xe0, 0x76, 0xf3, 0x63, 0x21, 0xec, 0xa1, 0x4b, 0x82, 0x9e, 0x91, 0xc8,
+	0xd6, 0x36, 0x61, 0x2e, 0x33, 0xa4, 0x2e, 0x40, 0xf1, 0x08, 0xf7, 0x04, 0xba, 0xf4, 0xaf, 0x7a,
+	0x11, 0x4a, 0xc7, 0xa8, 0x1d, 0xc5, 0x0e, 0xc1, 0x1f, 0xde, 0x28, 0xdc, 0x51, 0xf4, 0xdf, 0x2b,
+	0x70, 0x69, 0xbf, 0x2b, 0x96, 0xd8, 0xe5, 0x6a, 0xed, 0xa2, 0x00, 0x75, 0x42, 0xf5, 0x2a, 0xd4,
+	0x88, 0xd3, 0xc1, 0x21, 0x41, 0x1d, 0x9f, 0x09, 0x2b, 0x1a, 0x29, 0x81, 0x8a, 0x74, 0x3d, 0xd7,
+	0x4a, 0x44, 0xb2, 0x07, 0xb5, 0x01, 0x15, 0x1f, 0x59, 0x47, 0xa8, 0x89, 0x63, 0x33, 0x89, 0x47,
+	0x6a, 0xde, 0xd0, 0x69, 0xba, 0x26, 0xe9, 0xf9, 0x58, 0xd8, 0xa7, 0x4a, 0x09, 0x4f, 0x7a, 0x3e,
+	0xf3, 0x77, 0xea, 0x5a, 0xf4, 0x59, 0xb8, 0x55, 0xc5, 0x47, 0xbd, 0x3d, 0xa7, 0xe9, 0xea, 0x1e,
+	0x5c, 0xcd, 0x07, 0x45, 0x84, 0xca, 0x63, 0x28, 0xfb, 0x4c, 0x5f, 0xa6, 0x62, 0x7d, 0xfd, 0x87,
+	0x63, 0x31, 0xce, 0x7f, 0x5d, 0x43, 0x88, 0xd1, 0xbf, 0x29, 0xc0, 0x8a, 0x58, 0xf1, 0x11, 0x22,
+	0xce, 0x31, 0x7e, 0x6e, 0x5d, 0xf8, 0x3a, 0x2c, 0x9c, 0x38, 0xa4, 0x65, 0x3e, 0x0d, 0x4c, 0xcb,
+	0xb3, 0xb1, 0xe9, 0xbb, 0xcd, 0x46, 0x79, 0x55, 0x59, 0xab, 0x1a, 0x73, 0x94, 0xfe, 0x49, 0xb0,
+	0xe5, 0xd9, 0x78, 0xd7, 0x6d, 0xaa, 0xab, 0x30, 0x1b, 0xcf, 0x09, 0x9d, 0x67, 0xb8, 0x51, 0x59,
+	0x55, 0xd6, 0x4a, 0x06, 0x3c, 0x65, 0x13, 0xf6, 0x9c, 0x67, 0x58, 0xff, 0x31, 0xac, 0x0e, 0xc7,
+	0x24, 0x4d, 0x5a, 0x4c, 0x44, 0x14, 0xb4, 0xe3, 0xa4, 0x45, 0x9f, 0x3f, 0x0d, 0xda, 0xea, 0x0b,
+	0x50, 0x97, 0x95, 0xa0, 0xd0, 0xcc, 0x1a, 0xb5, 0xa7, 0xb1, 0x02, 0xfa, 0x2f, 0x15, 0x28, 0x6f,
+	0x6f, 0xec, 0xb8, 0x87, 0x1e, 0x4d, 0x5d, 0xcc, 0x45, 0xb8, 0x04, 0xf6, 0x9f, 0x4a, 0xa6, 0x70,
+	0x4b, 0x29, 0xad, 0x82, 0x7c, 0xff, 0x11, 0xcd, 0x6a, 0x97, 0x81, 0xfe, 0x65, 0x6b, 0x72, 0x50,
+	0xa9, 0x61, 0xe8, 0x92, 0xcb, 0x50, 0x3b, 0x88, 0x5c, 0xbb, 0xcd, 0xf0, 0x16, 0xfe, 0xc6, 0x09,
+	0x3b, 0xb6, 0xfa, 0x22, 0xcc, 0x0a, 0xbf, 0xe4, 0x42, 0xb9, 0xcf, 0xd5, 0x05, 0x8d, 0x0a, 0xd6,
+	0x4f, 0xa0, 0xbe, 0xbd, 0xb1, 0x67, 0x61, 0x17, 0x33, 0xb5, 0xbe, 0x0b, 0xf3, 0xdc, 0xb4, 0x56,
+	0xdb, 0xa1, 0x5e, 0xe5, 0xf8, 0x42, 0xc3, 0x39, 0x46, 0xde, 0x62, 0xd4, 0x1d, 0x5f, 0xbd, 0x07,
+	0x95, 0xd6, 0x86, 0xe9, 0xb8, 0x87, 0x1e, 0xd3, 0xb4, 0xbe, 0x7e, 0x7d, 0xac, 0x3f, 0xf2, 0x17,
+	0x37, 0xca, 0x2d, 0xf6, 0xab, 0xff, 0xb5, 0x90, 0xa4, 0xcf, 0xed, 0x8d, 0xe7, 0xd6, 0xf7, 0x3e,
+	0x04, 0x08, 0x29, 0x78, 0x1c, 0x8a, 0x32, 0x83, 0xe2, 0xd5, 0x09, 0xa0, 0x48, 0x10, 0x37, 0x6a,
+	0x61, 0x02, 0xfe, 0x8b, 0x30, 0x1b, 0x60, 0xdb, 0x09, 0xb0, 0x45, 0x98, 0xa5, 0x2b, 0xdc, 0x5c,
+	0x31, 0xed, 0xd3, 0xa0, 0xad, 0x6f, 0xc0, 0xd5, 0x7c, 0xd0, 0x84, 0x73, 0x2e, 0x41, 0xb9, 0xb5,
+	0x21, 0xb9, 0x66, 0xa9, 0xb5, 0x41, 0xd9, 0xfe, 0xae, 0x24, 0x7c, 0xf7, 0x7d, 0xff, 0x79, 0x45,
+	0x5b, 0xff, 0xa7, 0x02, 0xea, 0x7e, 0x97, 0xbe, 0x41, 0x26, 0x83, 0x0f, 0x51, 0xfe, 0x1a, 0x80,
+	0x8f, 0x02, 0xe2, 0xe2, 0xc0, 0x4c, 0xb4, 0xaf, 0x09, 0x8a, 0x78, 0x37, 0x06, 0x44, 0xaa, 0x7f,
+	0x95, 0x13, 0x76, 0x6c, 0x39, 0xc1, 0xcf, 0x64, 0x13, 0x7c, 0xb2, 0x21, 0x94, 0xe4, 0x0d, 0x21,
+	0xb3, 0x89, 0x94, 0xfb, 0x37, 0x11, 0x15, 0x66, 0x58, 0xce, 0xe7, 0x06, 0x65, 0xff, 0xf5, 0x76,
+	0x52, 0x72, 0xf5, 0x5b, 0x44, 0x98, 0xf2, 0xc3, 0xbe, 0x8c, 0xff, 0xfa, 0x04, 0x19, 0xbf, 0x1f,
+	0x9a, 0x24, 0xdb, 0xff, 0x5c, 0x81, 0xe5, 0x4f, 0x22, 0x1c, 0xf4, 0xf6, 0xbb, 0x62, 0xc2, 0x1e,
+	0x41, 0x24, 0x0a, 0x63, 0xfb, 0x2f, 0xf7, 0x57, 0x62, 0xdb, 0xe7, 0x52, 0x6b, 0x5e, 0x87, 0xf3,
+	0x24, 0x40, 0x6e, 0x88, 0x2c, 0x6a, 0xb1, 0x04, 0xae, 0xed, 0x73, 0xc6, 0x9c, 0x44, 0xdf, 0xb1,
+	0xd5, 0x15, 0xa8, 0xb7, 0x3d, 0x0b, 0xb5, 0xcd, 0x43, 0x27, 0x08, 0x09, 0x83, 0xbc, 0x6a, 0x00,
+	0x23, 0xbd, 0x47, 0x29, 0x0f, 0x66, 0xa0, 0xe0, 0xd8, 0xfa, 0x9f, 0x8b, 0x70, 0x35, 0x5f, 0x99,
+	0xd4, 0x8b, 0xf3, 0x0c, 0x2a, 0x3b, 0x5c, 0x21, 0xeb, 0x70, 0x4b, 0x50, 0x26, 0x41, 0x37, 0xb5,
+	0x64, 0x89, 0x04, 0x5d, 0xee, 0x02, 0x9c, 0x43, 0xda, 0x8e, 0x6b, 0x8c, 0xc2, 0xf6, 0xe3, 0x15,
+	0xa8, 0xf3, 0xe1, 0x90, 0x20, 0x12, 0x5b, 0x94, 0x73, 0x50, 0x8d, 0x30, 0x8d, 0xc8, 0x30, 0xb2,
+	0x2c, 0x1c, 0x86, 0x26, 0xb5, 0x26, 0xb3, 0x6c, 0xcd, 0xa8, 0x0b, 0xda, 0x13, 0xa7, 0x83, 0xd5,
+	0xe6, 0x40, 0xf9, 0xf3, 0xe1, 0x58, 0x43, 0x8d, 0x7a, 0xf9, 0x61, 0xf5, 0x0f, 0x75, 0x3c, 0xae,
+	0x66, 0x55, 0x94, 0xc0, 0x4c, 0xc3, 0x6b, 0x00, 0x87, 0x81, 0xd7, 0x31, 0x19, 0xc8, 0x8d, 0x1a,
+	0x43, 0xbc, 0x46, 0x29, 0x1f, 0x51, 0xc2, 0xd9, 0x8a, 0xa6, 0x37, 0xe0, 0x9a, 0x81, 0x0f, 0x03,
+	0x1c, 0xb6, 0xf6, 0xbb, 0x59, 0xb7, 0x12, 0x5e, 0x33, 0xa2, 0x7e, 0xff, 0x87, 0x02, 0x2f, 0x0c,
+	0x63, 0xfe, 0x96, 0x4a, 0x1a, 0xd5, 0x00, 0xa0, 0x6e, 0x23, 0x84, 0x16, 0xa6, 0x8f, 0x9a, 0x1a,
+	0xf2, 0x7d, 0xfe, 0x57, 0xff, 0x01, 0x5c, 0xd9, 0x47, 0xc4, 0x6a, 0xe5, 0x46, 0xcd, 0x88, 0xf7,
+	0xff, 0xb7, 0x02, 0x5a, 0x1e, 0xe3, 0xd8, 0xce, 0x27, 0xb5, 0x73, 0x41, 0xb6, 0xf3, 0x25, 0x28,
+	0x87, 0x4c, 0x04, 0x73, 0xf0, 0x92, 0x21, 0x9e, 0x68, 0xa2, 0x3a, 0xc6, 0x41, 0x18, 0x27, 0xd8,
+	0xa2, 0x11, 0x3f, 0x4a, 0x21, 0x51, 0x92, 0x43, 0x62, 0x02, 0x97, 0x5e, 0x81, 0x7a, 0xe4, 0xdb,
+	0x88, 0x60, 0x3e, 0xa3, 0xc2, 0xe4, 0x02, 0x27, 0xd1, 0x09, 0x14, 0x94, 0xad, 0xb6, 0x17, 0xe2,
+	0xdc, 0xbe, 0x67, 0x04, 0x28, 0x57, 0x41, 0xcb, 0xe3, 0x13, 0x4d, 0xe8, 0xbf, 0x8a, 0xb0, 0xf8,
+	0xc8, 0x23, 0xce, 0xa1, 0x63, 0x21, 0x9a, 0x50, 0xf6, 0xa2, 0x4e, 0x07, 0x05, 0x3d, 0x9a, 0xa8,
+	0x5d, 0x4a, 0xee, 0xa5, 0x12, 0xab, 0x9c, 0xc0, 0x23, 0x1c, 0x1f, 0x53, 0xbb, 0xb2, 0x08, 0x17,
+	0x49, 0x9e, 0x51, 0x58, 0x84, 0x7f, 0x4b, 0x79, 0x61, 0x0d, 0x16, 0xa4, 0x09, 0x6c, 0x9b, 0x13,
+	0x40, 0x9e, 0x4f, 0x67, 0xd1, 0x5d, 0x6e, 0x00, 0xee, 0x4a, 0x2e, 0xdc, 0x7c, 0x93, 0xe5, 0x7b,
+	0x5f, 0x95, 0xc3, 0xcd, 0x48, 0xbc, 0xc8, 0xa0, 0xb6, 0xf7, 0xa2, 0xc0, 0xc2, 0x2c, 0xbe, 0x6b,
+	0x86, 0x78, 0x52, 0x7f, 0x22, 0xa5, 0x1e, 0x60, 0xa9, 0xe7, 0xc1, 0x58, 0x6f, 0xcf, 0x01, 0xf8,
+	0xdb, 0xe9, 0xb8, 0xfe, 0x5b, 0x85, 0x0b, 0x71, 0xdf, 0xcf, 0x7b, 0x46, 0x5a, 0xe2, 0x0c, 0xc9,
+	0xec, 0x2b, 0x50, 0xef, 0xe0, 0xc0, 0x6a, 0x21, 0x97, 0xa4, 0xc9, 0x1d, 0x62, 0xd2, 0xce, 0xc8,
+	0x5a, 0x23, 0x53, 0xa3, 0xcc, 0xf4, 0xd5, 0x28, 0x59, 0x43, 0x97, 0xfa, 0x0d, 0x9d, 0x57, 0xa7,
+	0x94, 0x4f, 0x51, 0xa7, 0x54, 0xf2, 0xab, 0xc2, 0x34, 0x58, 0xab, 0x99, 0x60, 0x5d, 0x87, 0xa5,
+	0x00, 0x1f, 0x46, 0xae, 0x8d, 0xed, 0xac, 0x9c, 0x1a, 0x93, 0xb3, 0x18, 0x0f, 0xca, 0xb2, 0x56,
+	0xa0, 0x8e, 0xbb, 0xbe, 0x13, 0x88, 0x60, 0x04, 0xee, 0x1d, 0x9c, 0x14, 0xbb, 0x8f, 0x15, 0xe0,
+	0x24, 0x5a, 0xeb, 0x7c, 0x02, 0x27, 0xe5, 0x85, 0xf3, 0x6c, 0x7f, 0x38, 0xab, 0x18, 0x16, 0xdb,
+	0x88, 0xe0, 0x90, 0x98, 0xae, 0xe4, 0x1d, 0x8d, 0x39, 0x96, 0x40, 0x6f, 0x4f, 0xe3, 0x52, 0x86,
+	0xca, 0x05, 0xca, 0x43, 0x69, 0x62, 0x3b, 0x2f, 0x27, 0x36, 0x29, 0x81, 0xcd, 0x67, 0x13, 0x58,
+	0x72, 0x34, 0xb1, 0x30, 0xed, 0xd1, 0xc4, 0xe7, 0x52, 0x80, 0x5c, 0x60, 0x42, 0xee, 0x8d, 0x15,
+	0x32, 0xe0, 0xb3, 0x43, 0x37, 0xe4, 0x34, 0xb7, 0xa8, 0xa3, 0x12, 0xec, 0xe2, 0x60, 0xc4, 0xbf,
+	0x02, 0x17, 0x0e, 0xa3, 0xf6, 0xa1, 0xd3, 0x6e, 0x33, 0x1d, 0x38, 0x2a, 0x17, 0xf9, 0x39, 0x93,
+	0x34, 0xc0, 0x73, 0xcd, 0xf7, 0xe1, 0xa2, 0x3c, 0xd9, 0xc6, 0xc8, 0x6e, 0x3b, 0x2e, 0x6e, 0x2c,
+	0x71, 0x9f, 0x91, 0xc6, 0xde, 0x15, 0x43, 0xea, 0x1d, 0x68, 0xc8, 0x2c, 0x96, 0xe7, 0x1e, 0x3a,
+	0x41, 0x87, 0xab, 0x73, 0x89, 0xb1, 0x5d, 0x92, 0xc6, 0xb7, 0xf8, 0x30, 0xd3, 0xec, 0x4d, 0xd0,
+	0x64, 0x4e, 0xef, 0x18, 0x07, 0x76, 0x84, 0x4d, 0xdf, 0x6b, 0x3b, 0x56, 0xaf, 0x71, 0x99, 0xa9,
+	0x28, 0xcb, 0x7e, 0xcc, 0x27, 0xec, 0xb2, 0x71, 0xf5, 0x2e, 0x5c, 0xc9, 0x70, 0x47, 0xc4, 0xe4,
+	0x2e, 0x6d, 0xba, 0x5e, 0xa3, 0xc1, 0x98, 0xe5, 0x85, 0x1f, 0x47, 0xc4, 0x60, 0xc3, 0x8f, 0xbc,
+	0xb3, 0xe5, 0x9a, 0xdb, 0x70, 0xf9, 0x7d, 0x4c, 0x32, 0x96, 0x9b, 0x60, 0x37, 0xb2, 0xa1, 0x31,
+	0xc8, 0x25, 0xf6, 0xe7, 0x6d, 0x28, 0x79, 0x94, 0x20, 0x4a, 0x93, 0xf5, 0xd3, 0xbb, 0x8d, 0xc1,
+	0x05, 0xe8, 0x5f, 0x16, 0xe0, 0xca, 0x47, 0x4e, 0x98, 0x5d, 0x27, 0x3c, 0x4b, 0xdf, 0x25, 0x97,
+	0x02, 0x45, 0x29, 0xbb, 0xbc, 0x0c, 0x17, 0xa4, 0x44, 0x60, 0x1e, 0xe0, 0xa6, 0x13, 0x17, 0x05,
+	0xf3, 0x69, 0x3a, 0x78, 0x40, 0xc9, 0xb4, 0xcf, 0x97, 0xe7, 0x62, 0xd7, 0x16, 0x3d, 0xd7, 0x5c,
+	0x3a, 0xf3, 0xa1, 0xcb, 0xd6, 0xb2, 0xa2, 0x20, 0xf4, 0x02, 0x91, 0x16, 0xc5, 0x13, 0x57, 0xb0,
+	0x99, 0x39, 0x47, 0xa9, 0x52, 0x02, 0x3d, 0x45, 0x91, 0x2b, 0xd5, 0x62, 0x12, 0xe8, 0xfa, 0x57,
+	0x0a, 0x68, 0x79, 0x40, 0x08, 0xc4, 0x3f, 0x80, 0x32, 0x03, 0x8c, 0x56, 0x83, 0xc5, 0x29, 0x21,
+	0x17, 0x12, 0x68, 0xc6, 0x73, 0x71, 0x97, 0x98, 0x42, 0x75, 0xb1, 0x9d, 0x50, 0xd2, 0x16, 0xa3,
+	0xe8, 0x7f, 0x2a, 0x02, 0x70, 0xd7, 0x63, 0xbb, 0xd2, 0x88, 0x6a, 0x4c, 0x87, 0xb9, 0xac, 0x1b,
+	0x73, 0x61, 0x75, 0x2f, 0xf5, 0x5d, 0x0a, 0x86, 0x18, 0x4f, 0x3b, 0x49, 0x4e, 0xd8, 0x61, 0x08,
+	0x06, 0x18, 0x85, 0x49, 0x03, 0x2c, 0x9e, 0x68, 0x9a, 0xc8, 0x69, 0x79, 0xeb, 0x48, 0x4a, 0xfd,
+	0x2f, 0xc1, 0x9c, 0x98, 0xc2, 0xa5, 0x89, 0xc6, 0x52, 0xf0, 0xf1, 0xe5, 0x25, 0x6f, 0xa8, 0x64,
+	0xf6, 0x9a, 0x35, 0x58, 0x38, 0xe9, 0xc6, 0x7a, 0x4b, 0xbb, 0x51, 0xcd, 0x38, 0x7f, 0xd2, 0xe5,
+	0xbc, 0x7b, 0xc9, 0xae, 0x14, 0x85, 0x38, 0x30, 0x03, 0x6c, 0x61, 0xe7, 0x98, 0x6e, 0x4d, 0x96,
+	0x45, 0x17, 0x10, 0xd5, 0xc6, 0x22, 0x1d, 0x34, 0xc4, 0xd8, 0x7d, 0x3e, 0x34, 0x90, 0xe4, 0x20,
+	0xb7, 0xac, 0x39, 0xdb, 0xbe, 0xa4, 0x7f, 0xad, 0xc0, 0xe2, 0x16, 0x9b, 0xcf, 0xf5, 0x1d, 0x1f,
+	0xd3, 0x13, 0x99, 0xeb, 0x25, 0x98, 0x13, 0xe3, 0x1c, 0x48, 0x71, 0x49, 0x30, 0xcb, 0x89, 0x7c,
+	0xef, 0x1d, 0x66, 0x36, 0xfd, 0x47, 0x70, 0x31, 0xab, 0x92, 0x70, 0xdf, 0x2d, 0x3a, 0x9f, 0x19,
+	0x89, 0x67, 0x8c, 0x57, 0xc6, 0xba, 0x6f, 0xea, 0x7f, 0x86, 0x60, 0xd5, 0xef, 0x80, 0xca, 0x5a,
+	0xc3, 0xec, 0xeb, 0x0e, 0xbc, 0x93, 0x32, 0xf0, 0x4e, 0xfa, 0x67, 0xb0, 0x98, 0xe1, 0xfc, 0x7f,
+	0x6a, 0x75, 0x0b, 0x54, 0x1a, 0xb7, 0x7c, 0x64, 0x92, 0xde, 0xe7, 0x73, 0x58, 0xcc, 0x30, 0x08,
+	0x65, 0x1e, 0x42, 0x85, 0x4b, 0x8c, 0x43, 0xfc, 0x54, 0xda, 0xc4, 0xbc, 0xfa, 0xaf, 0x0b, 0x00,
+	0x2c, 0xe4, 0x1f, 0xd2, 0x2a, 0x3f, 0x69, 0x80, 0x79, 0xc6, 0xe1, 0x9a, 0xb0, 0x06, 0x98, 0xef,
+	0x9e, 0x2b, 0x50, 0x4f, 0x86, 0x23, 0xde, 0x14, 0x96, 0x0c, 0x88, 0xc7, 0xa3, 0x90, 0xbd, 0x87,
+	0x27, 0xb8, 0xe3, 0xca, 0xd2, 0xe3, 0xbc, 0xcb, 0x50, 0x13, 0x43, 0x51, 0xc8, 0xdc, 0xa0, 0x64,
+	0x54, 0xf9, 0x58, 0xb6, 0xf1, 0x2a, 0x65, 0xeb, 0x16, 0x8e, 0x8c, 0xc5, 0x90, 0x29, 0x27, 0xc8,
+	0x58, 0x98, 0x27, 0x03, 0x51, 0xc9, 0x57, 0x32, 0x95, 0xbc, 0x0e, 0x73, 0x27, 0x5d, 0x13, 0x07,
+	0x81, 0xc7, 0x8f, 0x8f, 0x45, 0xa4, 0xd6, 0x4f, 0xba, 0x0f, 0x29, 0x8d, 0x9e, 0x1f, 0xf7, 0xc7,
+	0x53, 0xad, 0x3f, 0x9e, 0xc4, 0x2e, 0xc8, 0xa0, 0xa1, 0xcf, 0xb4, 0x12, 0x98, 0xc0, 0x58, 0x26,
+	0x34, 0x06, 0xb9, 0x52, 0xf7, 0x61, 0xad, 0xd4, 0xe4, 0x06, 0x4b, 0x0d, 0x63, 0x08, 0x56, 0xfd,
+	0x3f, 0x0a, 0xcc, 0xef, 0xe3, 0x83, 0x96, 0xe7, 0x1d, 0xbd, 0x8b, 0xdb, 0xce, 0x31, 0x0e, 0x7a,
+	0xc9, 0xc5, 0x9d, 0x22, 0x5d, 0xdc, 0x2d, 0x40, 0x91, 0x9e, 0x5b, 0xf2, 0x80, 0xa5, 0x7f, 0xfb,
+	0x7a, 0xde, 0x5a, 0x92, 0xda, 0x34, 0xa8, 0x22, 0x42, 0x70, 0xc7, 0x27, 0x89, 0x59, 0xe2, 0x67,
+	0xea, 0x0e, 0x6d, 0x14, 0x12, 0x8e, 0x65, 0x5c, 0xf0, 0x53, 0x0a, 0x03, 0x92, 0xee, 0x91, 0x6c,
+	0x58, 0xcc, 0x4f, 0x5b, 0xe0, 0xa2, 0x31, 0x4f, 0x07, 0xee, 0x73, 0x3a, 0xcb, 0x4f, 0xdf, 0x81,
+	0xf3, 0x36, 0x57, 0x18, 0xdb, 0x72, 0x27, 0x3c, 0x97, 0x50, 0x19, 0xec, 0xbf, 0x29, 0xc2, 0xfc,
+	0xe3, 0x88, 0x1c, 0x78, 0x5d, 0xf6, 0xde, 0xf1, 0x86, 0xc2, 0xbb, 0xd2, 0x14, 0x6f, 0xf6, 0x3c,
+	0xbe, 0x61, 0x1d, 0xd1, 0xe8, 0x24, 0xdb, 0xea, 0x4c, 0xfe, 0xc1, 0x40, 0x69, 0xd8, 0xc1, 0x40,
+	0x39, 0xeb, 0x9f, 0xd7, 0x61, 0x5e, 0xbc, 0x41, 0xcf, 0x94, 0xb6, 0x8e, 0x9a, 0x11, 0xbf, 0x6e,
+	0x4f, 0xb8, 0xf8, 0x2e, 0x80, 0xa0, 0x38, 0x38, 0x64, 0x9b, 0x79, 0x7d, 0xfd, 0xb5, 0xf1, 0xe7,
+	0x29, 0x59, 0x5b, 0x1b, 0x92, 0x0c, 0x0a, 0x3f, 0xdb, 0x98, 0x33, 0xf0, 0x73, 0x4f, 0x9e, 0xa7,
+	0x03, 0x32, 0xfc, 0x7d, 0xfe, 0x0e, 0xe3, 0xf6, 0x8f, 0xfa, 0xc0, 0xfe, 0xf1, 0x8d, 0x02, 0x97,
+	0x69, 0x22, 0x92, 0xac, 0x33, 0x41, 0xfa, 0xca, 0xc3, 0xa7, 0x90, 0x8b, 0x4f, 0x5a, 0x1c, 0x15,
+	0x87, 0x17, 0x47, 0x33, 0xd9, 0xe2, 0x48, 0xff, 0x42, 0x81, 0xc6, 0xa0, 0x52, 0x49, 0xd9, 0x99,
+	0x0d, 0xb8, 0xf1, 0x68, 0xf7, 0x79, 0x5e, 0x1c, 0x75, 0xe3, 0x4b, 0xa0, 0x2f, 0x0a, 0xa0, 0x19,
+	0xd8, 0x6f, 0xa3, 0x9e, 0x38, 0xa1, 0xca, 0xe2, 0x43, 0x73, 0x9f, 0xc0, 0x87, 0x2b, 0x53, 0x33,
+	0xaa, 0x02, 0xa0, 0x21, 0x95, 0x66, 0x61, 0xe2, 0x4a, 0xb3, 0x98, 0x57, 0x69, 0xa6, 0x95, 0xf0,
+	0x8c, 0x5c, 0x09, 0xb3, 0xa3, 0x73, 0xf7, 0x48, 0x44, 0x32, 0xfb, 0x4f, 0x71, 0x27, 0x28, 0x68,
+	0x62, 0x12, 0x17, 0xa5, 0xfc, 0x49, 0xb2, 0x47, 0x65, 0xb8, 0x3d, 0xaa, 0x7d, 0xf6, 0xf8, 0x52,
+	0x81, 0xe5, 0x5c, 0x1c, 0x84, 0x49, 0x34, 0xa8, 0x06, 0x6c, 0x18, 0x73, 0x47, 0x29, 0x19, 0xc9,
+	0x33, 0xc5, 0x21, 0x3c, 0x72, 0x7c, 0x9f, 0xe6, 0x87, 0x04, 0xac, 0x02, 0x03, 0x6b, 0x5e, 0x0c,
+	0x3c, 0x89, 0x31, 0xeb, 0x33, 0x48, 0x71, 0xc0, 0x20, 0xf4, 0x50, 0x8d, 0x77, 0x62, 0xef, 0xa5,
+	0x2d, 0xd2, 0x04, 0x09, 0xfc, 0x67, 0x0a, 0x68, 0x79, 0x8c, 0x42, 0xff, 0xdc, 0x5e, 0x53, 0x19,
+	0xd2, 0x6b, 0x8e, 0x6a, 0x1c, 0x0b, 0xa3, 0x1a, 0xc7, 0xf5, 0xbf, 0x2d, 0xc1, 0xd5, 0x7d, 0xe6,
+	0xab, 0x02, 0xc6, 0x2d, 0xe1, 0xa9, 0x7b, 0xdc, 0x51, 0x55, 0x0c, 0x33, 0xf4, 0x0b, 0x14, 0x75,
+	0xfc, 0xed, 0x98, 0xf4, 0xa1, 0x8a, 0x76, 0x63, 0xfc, 0x6c, 0xf9, 0x3b, 0x96, 0x73, 0xea, 0xef,
+	0x14, 0xb8, 0x94, 0xff, 0xd5, 0x89, 0xfa, 0xf6, 0xa4, 0x9f, 0x25, 0xe4, 0x7f, 0x03, 0xa3, 0xbd,
+	0x33, 0x35, 0x7f, 0xa2, 0xdd, 0xaf, 0x14, 0xb8, 0x98, 0x77, 0xcd, 0xaf, 0xbe, 0x79, 0x96, 0x4f,
+	0x26, 0xb4, 0xb7, 0xa6, 0xe4, 0x4e, 0xf4, 0xfa, 0xa3, 0x02, 0x8d, 0x61, 0x17, 0xdf, 0xea, 0xbd,
+	0x49, 0xa5, 0x0f, 0xfb, 0x8e, 0x40, 0xbb, 0x7f, 0x06, 0x09, 0x79, 0xd8, 0x6d, 0x6f, 0x4c, 0x85,
+	0xdd, 0xf6, 0xc6, 0x59, 0xb0, 0xcb, 0xbd, 0x70, 0xd5, 0xcf, 0xa9, 0xbf, 0x55, 0x60, 0x29, 0xf7,
+	0x26, 0x4f, 0x9d, 0x58, 0x74, 0xee, 0x9d, 0xac, 0xf6, 0xf6, 0xb4, 0xec, 0x19, 0xc8, 0xf2, 0xee,
+	0x9a, 0x26, 0x80, 0x6c, 0xc4, 0x65, 0xa1, 0xf6, 0xd6, 0x94, 0xdc, 0x99, 0x20, 0xcd, 0xbf, 0x1c,
+	0x9a, 0x20, 0x48, 0x47, 0x5e, 0x49, 0x69, 0xef, 0x4c, 0xcd, 0x9f, 0x68, 0xf7, 0x35, 0xbd, 0x65,
+	0x1e, 0xb8, 0xba, 0x51, 0xdf, 0x18, 0x5f, 0xf9, 0x0c, 0xbb, 0x28, 0xd2, 0x36, 0xa7, 0xe2, 0x8d,
+	0x35, 0x7a, 0x4d, 0x51, 0x7f, 0xa1, 0x80, 0x3a, 0x78, 0x75, 0x32, 0x81, 0x4e, 0x43, 0xef, 0x69,
+	0xb4, 0xcd, 0xa9, 0x78, 0x13, 0x94, 0xbe, 0x52, 0x60, 0xa1, 0xff, 0xf8, 0x4c, 0xbd, 0x33, 0x56,
+	0xe6, 0x90, 0x73, 0x3a, 0xed, 0xee, 0x14, 0x9c, 0x89, 0x2e, 0x14, 0x9d, 0xc1, 0xa3, 0xa5, 0x09,
+	0xd0, 0x19, 0x7a, 0x30, 0xa7, 0x6d, 0x4e, 0xc5, 0xdb, 0x8f, 0x4e, 0xa6, 0xad, 0x9a, 0x0c, 0x9d,
+	0xbc, 0xfe, 0x4d, 0xbb, 0x3b, 0x05, 0x67, 0xa2, 0xcb, 0x4f, 0x61, 0x56, 0x3e, 0xb2, 0x50, 0xc7,
+	0x9f, 0xe8, 0xe7, 0x1c, 0xba, 0x68, 0x1b, 0xa7, 0xe4, 0x4a, 0x96, 0x7f, 0x06, 0x75, 0xe9, 0x68,
+	0x42, 0x7d, 0x7d, 0xb2, 0xe4, 0x91, 0x5d, 0xfc, 0xf6, 0xe9, 0x98, 0xe4, 0xb5, 0xa5, 0x93, 0x88,
+	0x09, 0xd6, 0x1e, 0x3c, 0xe8, 0xd0, 0x6e, 0x9f, 0x8e, 0x29, 0xe3, 0x02, 0xfd, 0x85, 0xfe, 0x04,
+	0x2e, 0x30, 0xa4, 0x61, 0xd1, 0xee, 0x4e, 0xc1, 0x99, 0xe8, 0xf2, 0x8d, 0x02, 0x8b, 0x39, 0x45,
+	0xae, 0xba, 0x39, 0x41, 0xb6, 0x1c, 0xd6, 0x22, 0x68, 0x6f, 0x4e, 0xc7, 0x9c, 0x89, 0xda, 0xc1,
+	0xc2, 0x75, 0x92, 0x9c, 0x36, 0xac, 0x4c, 0xd6, 0x36, 0xa7, 0xe2, 0x8d, 0x35, 0x7a, 0xf0, 0xd1,
+	0x67, 0x1f, 0x4c, 0xf9, 0xb5, 0xb6, 0xe3, 0x12, 0x1c, 0xb8, 0xa8, 0xcd, 0x3f, 0xdb, 0x36, 0x9b,
+	0xd8, 0x0d, 0x0f, 0xca, 0xec, 0xff, 0xeb, 0xff, 0x1b, 0x00, 0xd8, 0x2b, 0x17, 0xff, 0x02, 0x2e,
+	0x00, 0x00,
 }
@@ -13,6 +13,17 @@ type ContextKey string
 const (
 	ContextKeyTraceId ContextKey = "ctx-key-trace-id"
 	ContextKeySpanId  ContextKey = "ctx-key-span-id"
+	// 触发平台订单状态迁移的来源, 见 EventSourceXXX
+	ContextKeyEventSource ContextKey = "ctx-key-event-source"
+	// 导致平台订单状态迁移的微信支付错误码
+	ContextKeyWxErrorCode ContextKey = "ctx-key-wx-error-code"
+)
+
+const (
+	EventSourceRPC    string = "rpc"
+	EventSourceNotify string = "notify"
+	EventSourceJob    string = "job"
+	EventSourceAdmin  string = "admin"
 )
 
 const (
@@ -40,3 +51,25 @@ func SpanId(ctx context.Context) string {
 	}
 	return ""
 }
+
+func NewContextWithEventSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, ContextKeyEventSource, source)
+}
+
+func EventSource(ctx context.Context) string {
+	if v, ok := ctx.Value(ContextKeyEventSource).(string); ok {
+		return v
+	}
+	return ""
+}
+
+func NewContextWithWxErrorCode(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, ContextKeyWxErrorCode, code)
+}
+
+func WxErrorCode(ctx context.Context) string {
+	if v, ok := ctx.Value(ContextKeyWxErrorCode).(string); ok {
+		return v
+	}
+	return ""
+}
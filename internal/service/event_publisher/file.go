@@ -0,0 +1,53 @@
+package eventpublisher
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+)
+
+// jsonLinesPublisher 将支付事件以JSON Lines格式追加到本地文件, 每行一条支付事件.
+type jsonLinesPublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewJSONLinesPublisher 创建追加写入 path 文件的支付事件发布器, 文件不存在时自动创建.
+func NewJSONLinesPublisher(path string) (EventPublisher, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonLinesPublisher{file: file}, nil
+}
+
+func (p *jsonLinesPublisher) Publish(_ context.Context, event *proto_gens.PaymentEvent) error {
+	line, err := MarshalJSON(event)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.file.Write(append(line, '\n'))
+	return err
+}
+
+func (p *jsonLinesPublisher) Close(_ context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.file.Sync(); err != nil {
+		_ = p.file.Close()
+		return err
+	}
+	return p.file.Close()
+}
+
+// MarshalJSON 将支付事件编码为单行JSON, 字段名与proto定义保持一致.
+func MarshalJSON(event *proto_gens.PaymentEvent) ([]byte, error) {
+	return protojson.MarshalOptions{UseProtoNames: true}.Marshal(protoadapt.MessageV2Of(event))
+}
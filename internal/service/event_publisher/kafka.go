@@ -0,0 +1,68 @@
+package eventpublisher
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+)
+
+const KafkaDefaultTimeout = 5 * time.Second
+
+// kafkaPublisher 将支付事件同步写入Kafka, 按消息键哈希分区, 等待全部同步副本确认.
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func newKafkaPublisher(conf *config.EventPublisherKafka) *kafkaPublisher {
+	timeout := time.Duration(conf.TimeoutInSecond) * time.Second
+	if timeout <= 0 {
+		timeout = KafkaDefaultTimeout
+	}
+	return &kafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(conf.Brokers...),
+			Topic:        conf.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchSize:    1,
+			WriteTimeout: timeout,
+			ReadTimeout:  timeout,
+		},
+	}
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, event *proto_gens.PaymentEvent) error {
+	msg, err := kafkaMessage(event)
+	if err != nil {
+		return err
+	}
+	return p.writer.WriteMessages(ctx, msg)
+}
+
+func (p *kafkaPublisher) Close(_ context.Context) error {
+	return p.writer.Close()
+}
+
+func kafkaMessage(event *proto_gens.PaymentEvent) (kafka.Message, error) {
+	payload, err := proto.Marshal(protoadapt.MessageV2Of(event))
+	if err != nil {
+		return kafka.Message{}, err
+	}
+	return kafka.Message{
+		Key:   []byte(event.TradeId),
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: FieldEventId, Value: []byte(event.EventId)},
+			{Key: FieldEventType, Value: []byte(event.EventType)},
+			{Key: FieldSchemaVersion, Value: []byte(strconv.Itoa(int(event.SchemaVersion)))},
+			{Key: FieldReplay, Value: []byte(strconv.FormatBool(event.Replay))},
+		},
+	}, nil
+}
@@ -0,0 +1,73 @@
+package eventpublisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+)
+
+const (
+	BackendRedis = "redis"
+	BackendKafka = "kafka"
+)
+
+// 当前支付事件的消息格式版本号, 与 PaymentEvent 所在包名中的版本号保持一致.
+const SchemaVersion = 1
+
+// 消息头/流字段.
+const (
+	FieldKey           = "key"
+	FieldEventId       = "event_id"
+	FieldEventType     = "event_type"
+	FieldSchemaVersion = "schema_version"
+	FieldPayload       = "payload"
+	FieldReplay        = "replay"
+)
+
+var (
+	ErrUnsupportedBackend = errors.New("event_publisher: unsupported backend")
+	ErrEmptyTopic         = errors.New("event_publisher: empty stream or topic")
+)
+
+// EventPublisher 将支付事件发布到消息总线, 消息键为平台订单交易ID, 保证同一平台订单的支付事件有序.
+// 支付事件的类型与ID与 outbox_events 中的支付事件相同, 由支付事件投递任务发布.
+// NOTE: Publish 返回成功即表示消息已被消息总线确认, 调用方在失败时需要重试以保证至少一次投递.
+type EventPublisher interface {
+	// Publish 发布一条支付事件.
+	Publish(ctx context.Context, event *proto_gens.PaymentEvent) error
+	// Close 释放发布器持有的资源.
+	Close(ctx context.Context) error
+}
+
+// NewEventPublisher 按配置创建支付事件发布器, 未配置后端时返回不发布任何支付事件的发布器.
+func NewEventPublisher(conf *config.EventPublisher) (EventPublisher, error) {
+	switch conf.Backend {
+	case "":
+		return &noopPublisher{}, nil
+	case BackendRedis:
+		if len(conf.Redis.Stream) == 0 {
+			return nil, ErrEmptyTopic
+		}
+		return newRedisPublisher(&conf.Redis), nil
+	case BackendKafka:
+		if len(conf.Kafka.Topic) == 0 {
+			return nil, ErrEmptyTopic
+		}
+		return newKafkaPublisher(&conf.Kafka), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBackend, conf.Backend)
+	}
+}
+
+type noopPublisher struct{}
+
+func (p *noopPublisher) Publish(_ context.Context, _ *proto_gens.PaymentEvent) error {
+	return nil
+}
+
+func (p *noopPublisher) Close(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+package eventpublisher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+)
+
+func TestNewEventPublisher(t *testing.T) {
+	p, err := NewEventPublisher(&config.EventPublisher{})
+	assert.Nil(t, err)
+	assert.Nil(t, p.Publish(context.Background(), &proto_gens.PaymentEvent{}))
+
+	_, err = NewEventPublisher(&config.EventPublisher{Backend: BackendKafka})
+	assert.Equal(t, ErrEmptyTopic, err)
+	_, err = NewEventPublisher(&config.EventPublisher{Backend: "nats"})
+	assert.ErrorIs(t, err, ErrUnsupportedBackend)
+}
+
+func TestEncodePaymentEvent(t *testing.T) {
+	event := &proto_gens.PaymentEvent{
+		SchemaVersion:       SchemaVersion,
+		EventId:             "5f0c6e0a-3d4b-4c4e-9a57-2f7a1b0c9d11",
+		EventType:           "refund.succeeded",
+		TradeId:             "S1234567890123456789ABCDEF",
+		RefundedAmountTotal: 100,
+	}
+
+	values, err := streamValues(event)
+	assert.Nil(t, err)
+	assert.Equal(t, event.TradeId, values[FieldKey])
+	assert.Equal(t, "1", values[FieldSchemaVersion])
+	decoded := &proto_gens.PaymentEvent{}
+	assert.Nil(t, proto.Unmarshal(values[FieldPayload].([]byte), protoadapt.MessageV2Of(decoded)))
+	assert.True(t, proto.Equal(protoadapt.MessageV2Of(event), protoadapt.MessageV2Of(decoded)))
+
+	msg, err := kafkaMessage(event)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(event.TradeId), msg.Key)
+	assert.Equal(t, values[FieldPayload], msg.Value)
+}
+
+func TestJSONLinesPublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	p, err := NewJSONLinesPublisher(path)
+	assert.Nil(t, err)
+	for _, tradeId := range []string{"S1234567890123456789ABCDEF", "S1234567890123456789ABCDEG"} {
+		assert.Nil(t, p.Publish(context.Background(), &proto_gens.PaymentEvent{
+			SchemaVersion: SchemaVersion,
+			EventId:       "payment.closed." + tradeId,
+			EventType:     "payment.closed",
+			TradeId:       tradeId,
+			Replay:        true,
+		}))
+	}
+	assert.Nil(t, p.Close(context.Background()))
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, 2, len(lines))
+	decoded := &proto_gens.PaymentEvent{}
+	assert.Nil(t, protojson.Unmarshal([]byte(lines[1]), protoadapt.MessageV2Of(decoded)))
+	assert.Equal(t, "S1234567890123456789ABCDEG", decoded.TradeId)
+	assert.True(t, decoded.Replay)
+	// 字段名与proto定义保持一致
+	assert.Contains(t, lines[0], `"trade_id"`)
+}
@@ -0,0 +1,52 @@
+package eventpublisher
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+)
+
+// redisPublisher 将支付事件追加到Redis Stream, 复用 ext_redis 连接池.
+// 消费端可以使用消费者组(XREADGROUP)按 key 字段路由到同一消费者.
+type redisPublisher struct {
+	stream string
+	maxLen int64
+}
+
+func newRedisPublisher(conf *config.EventPublisherRedis) *redisPublisher {
+	return &redisPublisher{stream: conf.Stream, maxLen: conf.MaxLen}
+}
+
+func (p *redisPublisher) Publish(ctx context.Context, event *proto_gens.PaymentEvent) error {
+	values, err := streamValues(event)
+	if err != nil {
+		return err
+	}
+	_, err = ext_redis.GetConnPool().GetBigCache().XAdd(ctx, p.stream, p.maxLen, values)
+	return err
+}
+
+func (p *redisPublisher) Close(_ context.Context) error {
+	return nil
+}
+
+func streamValues(event *proto_gens.PaymentEvent) (map[string]interface{}, error) {
+	payload, err := proto.Marshal(protoadapt.MessageV2Of(event))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		FieldKey:           event.TradeId,
+		FieldEventId:       event.EventId,
+		FieldEventType:     event.EventType,
+		FieldSchemaVersion: strconv.Itoa(int(event.SchemaVersion)),
+		FieldPayload:       payload,
+		FieldReplay:        strconv.FormatBool(event.Replay),
+	}, nil
+}
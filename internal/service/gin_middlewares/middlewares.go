@@ -22,6 +22,10 @@ func RecoverPanicAndReportLatencyMiddleware() gin.HandlerFunc {
 		}
 		ctx.Set(string(common.ContextKeyTraceId), tid)
 		ctx.Set(string(common.ContextKeySpanId), sid)
+		// gin.Context.Value 只按字符串键查找 ctx.Keys, 其余键回退到请求上下文中查找
+		// NOTE: Http服务只用于接收微信支付通知
+		ctx.Request = ctx.Request.WithContext(common.NewContextWithEventSource(
+			common.NewContextWithProvidedTraceIdAndSpanId(ctx.Request.Context(), tid, sid), common.EventSourceNotify))
 
 		st := time.Now()
 		defer func() {
@@ -0,0 +1,62 @@
+package interceptors
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+func RecoverPanicAndReportLatencyStreamInterceptor(
+	srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, rpcHandler grpc.StreamHandler) (
+	err error) {
+
+	ctx := ss.Context()
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		err = status.Error(codes.InvalidArgument, "Request header must be provided.")
+		return
+	}
+
+	tid := uuid.New().String()
+	sid := uuid.New().String()
+	if v, ok := md[common.ReqHeaderKeyRequestId]; ok {
+		tid = v[0]
+	}
+	ctx = context.WithValue(ctx, common.ContextKeyTraceId, tid)
+	ctx = context.WithValue(ctx, common.ContextKeySpanId, sid)
+	ctx = common.NewContextWithEventSource(ctx, common.EventSourceRPC)
+	wrapped := grpc_middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+
+	st := time.Now()
+	defer func() {
+		ed := time.Now()
+		logger.GetGlobalLogger().
+			WithField(common.LoggerKeyTraceId, tid).
+			WithField(common.LoggerKeySpanId, sid).
+			WithField("latency", ed.Sub(st).Milliseconds()).
+			Debug("stream latency")
+
+		if e := recover(); e != nil {
+			logger.GetGlobalLogger().
+				WithField(common.LoggerKeyTraceId, tid).
+				WithField(common.LoggerKeySpanId, sid).
+				WithField("stack", string(debug.Stack())).
+				WithError(e.(error)).
+				Error("Recover from internal server panic.")
+			err = status.Error(codes.Internal, "Recover from internal server panic.")
+		}
+	}()
+
+	return rpcHandler(srv, wrapped)
+}
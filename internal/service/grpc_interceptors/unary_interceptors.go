@@ -32,6 +32,7 @@ func RecoverPanicAndReportLatencyUnaryInterceptor(
 	}
 	ctx = context.WithValue(ctx, common.ContextKeyTraceId, tid)
 	ctx = context.WithValue(ctx, common.ContextKeySpanId, sid)
+	ctx = common.NewContextWithEventSource(ctx, common.EventSourceRPC)
 
 	st := time.Now()
 	defer func() {
@@ -3,18 +3,29 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	wechatpay_utils "github.com/wechatpay-apiv3/wechatpay-go/utils"
 
 	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
 	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
 	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
 	payment_bill "github.com/amazingchow/wechat-payment-callback-service/internal/service/payment_bill"
 	"github.com/amazingchow/wechat-payment-callback-service/internal/utils/gopool"
 )
 
+const (
+	NotifyNonceKeyPrefix = "wechat_payment_callback_service.notify.nonce."
+	NotifyLockKeyPrefix  = "wechat_payment_callback_service.notify.lock.trade_id."
+	NotifyLockTTL        = 30 * time.Second
+)
+
 func (impl *WechatPaymentCallbackServiceImpl) HomeHandler(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, &CommonReponse{
 		Code:    0,
@@ -54,9 +65,47 @@ func (impl *WechatPaymentCallbackServiceImpl) NotifyHandler(ctx *gin.Context) {
 		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
 		WithField(common.LoggerKeyEvent, "NotifyHandler")
 
-	// 1. 解析异步通知
+	// 1. 读取原始通知报文, 验签必须使用未经解析的报文主体
+	body, err := ctx.GetRawData()
+	if err != nil {
+		_logger.WithError(err).Error("Failed to read AsyncNotificationFromWeChatPay.")
+		ctx.JSON(http.StatusBadRequest, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知读取失败",
+		})
+		return
+	}
+
+	// 2. 使用HTTP头Wechatpay-Signature来验证签名, 以确认请求来自微信, 而不是其他的第三方
+	signHeader, err := VerifyNotifySignature(ctx, impl.notifyVerifier, ctx.Request.Header, body, time.Now())
+	if err != nil {
+		_logger.WithError(err).Error("Failed to verify AsyncNotificationFromWeChatPay.")
+		ctx.JSON(http.StatusUnauthorized, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知验签失败",
+		})
+		return
+	}
+	// 同一个随机串在时间窗口内只接受一次, 拒绝被截获后重放的通知
+	nonceItem := &ext_redis.Item{}
+	nonceItem.SetKey(fmt.Sprintf("%s%s.%s", NotifyNonceKeyPrefix, signHeader.Serial, signHeader.Nonce))
+	nonceItem.SetValue(strconv.FormatInt(signHeader.Timestamp, 10))
+	nonceItem.SetTTL(2 * NotifyTimestampTolerance)
+	if ok, innerErr := ext_redis.GetConnPool().GetBigCache().SetNX(ctx, nonceItem); innerErr != nil {
+		// 缓存不可用时仍然依赖签名和时间戳校验, 不阻断通知处理
+		_logger.WithError(innerErr).Warn("Failed to check Wechatpay-Nonce.")
+	} else if !ok {
+		_logger.Errorf("Replayed AsyncNotificationFromWeChatPay, Wechatpay-Nonce:%s.", signHeader.Nonce)
+		ctx.JSON(http.StatusUnauthorized, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知重复请求",
+		})
+		return
+	}
+
+	// 3. 解析异步通知
 	var notification AsyncNotificationFromWeChatPay
-	if err := ctx.BindJSON(&notification); err != nil {
+	if err := json.Unmarshal(body, &notification); err != nil {
 		_logger.WithError(err).Error("Failed to parse AsyncNotificationFromWeChatPay.")
 		ctx.JSON(http.StatusBadRequest, &AckAsyncNotificationFromWeChatPay{
 			Code:    "FAIL",
@@ -86,6 +135,36 @@ func (impl *WechatPaymentCallbackServiceImpl) NotifyHandler(ctx *gin.Context) {
 		})
 		return
 	}
+	// 4. 根据通知的事件类型和原始回调类型分发处理
+	switch {
+	case notification.EventType == EventTypeTransactionSuccess &&
+		notification.Resource.OriginalType == OriginalTypeTransaction:
+		impl.handleTransactionNotification(ctx, &notification, plaintext)
+	case (notification.EventType == EventTypeRefundSuccess ||
+		notification.EventType == EventTypeRefundAbnormal ||
+		notification.EventType == EventTypeRefundClosed) &&
+		notification.Resource.OriginalType == OriginalTypeRefund:
+		impl.handleRefundNotification(ctx, &notification, plaintext)
+	default:
+		_logger.Errorf("Unsupported AsyncNotificationFromWeChatPay, event_type:%s, original_type:%s.",
+			notification.EventType, notification.Resource.OriginalType)
+		ctx.JSON(http.StatusBadRequest, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知类型不支持",
+		})
+	}
+}
+
+// 处理支付成功通知.
+func (impl *WechatPaymentCallbackServiceImpl) handleTransactionNotification(ctx *gin.Context,
+	notification *AsyncNotificationFromWeChatPay, plaintext string) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "HandleTransactionNotification")
+
+	// 1. 解析支付通知资源
 	var notificationResource NotificationResource
 	if err := json.Unmarshal([]byte(plaintext), &notificationResource); err != nil {
 		_logger.WithError(err).Error("Failed to parse NotificationResource.")
@@ -96,15 +175,61 @@ func (impl *WechatPaymentCallbackServiceImpl) NotifyHandler(ctx *gin.Context) {
 		return
 	}
 
-	// TODO: 2. 使用HTTP头Wechatpay-Signature来验证签名，以确认请求来自微信，而不是其他的第三方
-	signHeader := ctx.Request.Header["Wechatpay-Signature"]
-	_logger.Debugf("### Wechatpay-Signature: %s", signHeader)
+	// 2. 对同一笔平台订单的通知处理加锁, 避免函数重入造成的数据混乱
+	lock, err := ext_redis.GetConnPool().GetBigCache().TryLock(
+		ctx, fmt.Sprintf("%s%s", NotifyLockKeyPrefix, notificationResource.OutTradeNo), NotifyLockTTL)
+	if err != nil {
+		// 并发推送的同一通知由微信稍后重试
+		_logger.WithError(err).Warn("Failed to lock platform-order for AsyncNotificationFromWeChatPay.")
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知正在处理",
+		})
+		return
+	}
+	defer func() {
+		_ = lock.Unlock(context.Background())
+	}()
+
+	// 3. 检查通知是否已经处理, 如果已处理则直接返回成功
+	existed, err := impl.storage.HasPaymentNotification(ctx, notification.Id, notificationResource.TransactionId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知查重失败",
+		})
+		return
+	}
+	if existed {
+		_logger.Infof("Duplicate AsyncNotificationFromWeChatPay, notify_id:%s.", notification.Id)
+		_ = impl.storage.IncrPlatformOrderNotifyRedelivery(ctx, notificationResource.OutTradeNo)
+		ctx.JSON(http.StatusOK, &AckAsyncNotificationFromWeChatPay{
+			Code:    "SUCCESS",
+			Message: "",
+		})
+		return
+	}
 
-	// 3. 异步通知平台支付结果, 更新数据库订单记录
-	_ = impl.storage.UpdatePlatformOrder(ctx, notificationResource.OutTradeNo, dao.PaymentStatusRecvAsyncNotification)
+	// 4. 校验通知的信息是否与平台订单一致, 防止“假通知”造成资金损失
+	passed, err := impl.crossCheckNotification(ctx, notification, &notificationResource, plaintext)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知校验失败",
+		})
+		return
+	}
+	if !passed {
+		// 可疑通知已经记录并告警, 返回成功避免微信重复推送, 由人工核查处理
+		ctx.JSON(http.StatusOK, &AckAsyncNotificationFromWeChatPay{
+			Code:    "SUCCESS",
+			Message: "",
+		})
+		return
+	}
 
-	// 4. 持久化支付通知, 用于离线对账
-	if innerErr := impl.storage.AddPaymentNotification(ctx, &dao.PaymentNotificationModel{
+	// 5. 持久化支付通知并推进平台订单状态
+	duplicated, err := impl.storeTransactionResult(ctx, _logger, &dao.PaymentNotificationModel{
 		NotifyId:                    notification.Id,
 		CreateTime:                  notification.CreateTime,
 		EventType:                   notification.EventType,
@@ -122,40 +247,314 @@ func (impl *WechatPaymentCallbackServiceImpl) NotifyHandler(ctx *gin.Context) {
 		ResourceAmountPayerTotal:    notificationResource.Amount.PayerTotal,
 		ResourceAmountCurrency:      notificationResource.Amount.Currency,
 		ResourceAmountPayerCurrency: notificationResource.Amount.PayerCurrency,
+		ResourceAttach:              notificationResource.Attach,
 		Summary:                     notification.Summary,
-	}); innerErr != nil {
+		Source:                      dao.NotificationSourceNotify,
+	})
+	if err != nil {
+		// 返回失败由微信重新推送通知
+		_logger.WithError(err).Warn("Failed to store AsyncNotificationFromWeChatPay.")
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知处理失败",
+		})
+		return
+	}
+	if duplicated {
+		// 已经被其他实例处理
+		_ = impl.storage.IncrPlatformOrderNotifyRedelivery(ctx, notificationResource.OutTradeNo)
+	}
+
+	// NOTE: 支付事件与平台订单状态迁移一同写入 outbox_events, 由支付事件投递任务发布, 不在应答微信之前发布
+	ctx.JSON(http.StatusOK, &AckAsyncNotificationFromWeChatPay{
+		Code:    "SUCCESS",
+		Message: "",
+	})
+}
+
+// storeTransactionResult 持久化支付结果并推进平台订单状态, 支付通知与主动查询得到的支付结果共用同一处理流程.
+// 如果同一笔微信支付订单的支付结果已经保存过, 则不推进平台订单状态, 并返回 duplicated = true.
+// 回写支付结果或将平台订单迁移为已支付失败时返回错误, 调用方需要让支付结果被重新处理(微信重新推送通知/下一轮补偿).
+func (impl *WechatPaymentCallbackServiceImpl) storeTransactionResult(ctx context.Context, _logger *logrus.Entry,
+	record *dao.PaymentNotificationModel) (duplicated bool, err error) {
+
+	// 1. 异步通知平台支付结果, 更新数据库订单记录
+	// NOTE: 先回写支付结果, 使平台订单迁移为已支付时产生的支付事件携带微信支付订单号
+	// NOTE: 平台订单已经是已支付(或退款中/已退款)时迁移被拒绝, 不影响后续处理
+	if err = impl.storage.SavePlatformOrderTransaction(ctx, record.TradeId, record.ResourceTransactionId, record.ResourceSuccessTime); err != nil {
+		return
+	}
+	if err = impl.storage.UpdatePlatformOrder(ctx, record.TradeId, dao.PaymentStatusRecvAsyncNotification); err != nil {
+		if err != dao.ErrIllegalStateTransition {
+			return
+		}
+		err = nil
+	}
+
+	// 2. 持久化支付通知, 用于离线对账
+	if innerErr := impl.storage.AddPaymentNotification(ctx, record); innerErr == dao.ErrDuplicateRecord {
+		duplicated = true
+		return
+	} else if innerErr != nil {
 		_logger.WithError(innerErr).Error("Failed to invoke impl.storage.AddPaymentNotification.")
 		// 保存支付通知失败, 更新数据库订单记录
-		_ = impl.storage.UpdatePlatformOrder(ctx, notificationResource.OutTradeNo, dao.PaymentStatusStoreAsyncNotificationFailed)
+		_ = impl.storage.UpdatePlatformOrder(ctx, record.TradeId, dao.PaymentStatusStoreAsyncNotificationFailed)
 	} else {
 		// 保存支付通知成功, 更新数据库订单记录
-		_ = impl.storage.UpdatePlatformOrder(ctx, notificationResource.OutTradeNo, dao.PaymentStatusStoreAsyncNotification)
+		_ = impl.storage.UpdatePlatformOrder(ctx, record.TradeId, dao.PaymentStatusStoreAsyncNotification)
 	}
 
 	// NOTE: 支持在接收到支付通知后上传订单信息
 	// 用户后续可以从微信「我」-「服务」-「钱包」-「账单」中进入，也可以从支付凭证消息进入账单详情页回溯已购物的订单。
+	var items []*payment_bill.UploadShoppingInfoItem
+	if order, innerErr := impl.storage.GetPlatformOrder(ctx, record.TradeId); innerErr == nil {
+		for _, item := range order.Items {
+			items = append(items, &payment_bill.UploadShoppingInfoItem{
+				MerchantItemId: item.SkuId,
+				Name:           item.Name,
+				UnitPrice:      int(item.UnitPrice),
+				Quantity:       int(item.Quantity),
+			})
+		}
+	}
+	traceId, spanId := common.TraceId(ctx), common.SpanId(ctx)
 	gopool.Go(func() {
-		_ctx := context.WithValue(
-			context.WithValue(
-				context.Background(),
-				common.ContextKeyTraceId,
-				ctx.Value(common.ContextKeyTraceId).(string),
-			),
-			common.ContextKeySpanId,
-			ctx.Value(common.ContextKeySpanId).(string),
-		)
+		_ctx := common.NewContextWithProvidedTraceIdAndSpanId(context.Background(), traceId, spanId)
 		payment_bill.UploadShoppingInfo(_ctx, &payment_bill.UploadShoppingInfoParams{
-			AppId:         notificationResource.AppId,
-			MerchantId:    notificationResource.MchId,
-			TradeId:       notificationResource.OutTradeNo,
-			TransactionId: notificationResource.TransactionId,
-			PayerUid:      notificationResource.Payer.OpenId,
-			PayTotal:      notificationResource.Amount.PayerTotal,
+			AppId:         record.ResourceAppId,
+			MerchantId:    record.ResourceMchId,
+			TradeId:       record.TradeId,
+			TransactionId: record.ResourceTransactionId,
+			PayerUid:      record.ResourcePayerOpenId,
+			PayTotal:      record.ResourceAmountPayerTotal,
+			Items:         items,
 		})
 	})
 
-	// 5. 返回告知成功接收处理, 更新数据库订单记录
-	_ = impl.storage.UpdatePlatformOrder(ctx, notificationResource.OutTradeNo, dao.PaymentStatusAckAsyncNotification)
+	// 3. 返回告知成功接收处理, 更新数据库订单记录
+	_ = impl.storage.UpdatePlatformOrder(ctx, record.TradeId, dao.PaymentStatusAckAsyncNotification)
+	return
+}
+
+// crossCheckNotification 比对支付通知与平台订单的金额、商户号、应用ID和支付者,
+// 不一致或平台订单不存在的通知会被记录为可疑通知, 且不会推进平台订单状态. 可疑通知记录失败时返回错误.
+func (impl *WechatPaymentCallbackServiceImpl) crossCheckNotification(ctx context.Context,
+	notification *AsyncNotificationFromWeChatPay, resource *NotificationResource, plaintext string) (
+	passed bool, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, common.TraceId(ctx)).
+		WithField(common.LoggerKeySpanId, common.SpanId(ctx)).
+		WithField(common.LoggerKeyEvent, "CrossCheckNotification").
+		WithField("trade_id", resource.OutTradeNo).
+		WithField("notify_id", notification.Id)
+
+	suspicious := &dao.SuspiciousNotificationModel{
+		NotifyId:              notification.Id,
+		EventType:             notification.EventType,
+		TradeId:               resource.OutTradeNo,
+		ResourceTransactionId: resource.TransactionId,
+		ResourcePlaintext:     plaintext,
+		CreateTime:            time.Now().Unix(),
+	}
+	order, err := impl.storage.GetPlatformOrder(ctx, resource.OutTradeNo)
+	if err == dao.ErrRecordNotFound {
+		err = nil
+		suspicious.Reason = dao.SuspiciousReasonUnknownTradeId
+	} else if err != nil {
+		return
+	} else if suspicious.MismatchedFields = CrossCheckNotificationResource(order, resource); len(suspicious.MismatchedFields) > 0 {
+		suspicious.Reason = dao.SuspiciousReasonMismatch
+	} else {
+		passed = true
+		return
+	}
+
+	// 可疑通知以 Error 级别上报, 由 Sentry 告警, 已经记录过的可疑通知(如补偿任务重复查询到的支付结果)不再告警
+	if err = impl.storage.AddSuspiciousNotification(ctx, suspicious); err == dao.ErrDuplicateRecord {
+		err = nil
+		return
+	} else if err != nil {
+		return
+	}
+	_logger.WithField("reason", suspicious.Reason).
+		WithField("mismatched_fields", suspicious.MismatchedFields).
+		Error("Received suspicious AsyncNotificationFromWeChatPay.")
+	return
+}
+
+// 处理退款结果通知.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_11.shtml
+func (impl *WechatPaymentCallbackServiceImpl) handleRefundNotification(ctx *gin.Context,
+	notification *AsyncNotificationFromWeChatPay, plaintext string) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "HandleRefundNotification")
+
+	// 1. 解析退款通知资源
+	var refundResource RefundNotificationResource
+	if err := json.Unmarshal([]byte(plaintext), &refundResource); err != nil || refundResource.Amount == nil {
+		_logger.WithError(err).Error("Failed to parse RefundNotificationResource.")
+		ctx.JSON(http.StatusBadRequest, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知资源解析失败",
+		})
+		return
+	}
+	_logger = _logger.WithField("trade_id", refundResource.OutTradeNo).
+		WithField("out_refund_no", refundResource.OutRefundNo)
+
+	// 2. 退款通知与支付通知共用平台订单锁
+	lock, err := ext_redis.GetConnPool().GetBigCache().TryLock(
+		ctx, fmt.Sprintf("%s%s", NotifyLockKeyPrefix, refundResource.OutTradeNo), NotifyLockTTL)
+	if err != nil {
+		_logger.WithError(err).Warn("Failed to lock platform-order for AsyncNotificationFromWeChatPay.")
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知正在处理",
+		})
+		return
+	}
+	defer func() {
+		_ = lock.Unlock(context.Background())
+	}()
+
+	// 3. 检查通知是否已经处理, 如果已处理则直接返回成功
+	existed, err := impl.storage.HasRefundNotification(ctx, notification.Id, refundResource.RefundId, refundResource.RefundStatus)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知查重失败",
+		})
+		return
+	}
+	if existed {
+		_logger.Infof("Duplicate AsyncNotificationFromWeChatPay, notify_id:%s.", notification.Id)
+		_ = impl.storage.IncrPlatformOrderNotifyRedelivery(ctx, refundResource.OutTradeNo)
+		ctx.JSON(http.StatusOK, &AckAsyncNotificationFromWeChatPay{
+			Code:    "SUCCESS",
+			Message: "",
+		})
+		return
+	}
+
+	// 4. 校验退款通知对应的平台订单
+	order, err := impl.storage.GetPlatformOrder(ctx, refundResource.OutTradeNo)
+	if err != nil && err != dao.ErrRecordNotFound {
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "平台订单查询失败",
+		})
+		return
+	}
+	if err == dao.ErrRecordNotFound || order.MchId != refundResource.MchId {
+		suspicious := &dao.SuspiciousNotificationModel{
+			NotifyId:              notification.Id,
+			EventType:             notification.EventType,
+			TradeId:               refundResource.OutTradeNo,
+			ResourceTransactionId: refundResource.TransactionId,
+			ResourcePlaintext:     plaintext,
+			Reason:                dao.SuspiciousReasonUnknownTradeId,
+			CreateTime:            time.Now().Unix(),
+		}
+		if err == nil {
+			suspicious.Reason = dao.SuspiciousReasonMismatch
+			suspicious.MismatchedFields = []string{"mchid"}
+		}
+		if innerErr := impl.storage.AddSuspiciousNotification(ctx, suspicious); innerErr == nil {
+			_logger.WithField("reason", suspicious.Reason).
+				Error("Received suspicious AsyncNotificationFromWeChatPay.")
+		} else if innerErr != dao.ErrDuplicateRecord {
+			ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+				Code:    "FAIL",
+				Message: "通知校验失败",
+			})
+			return
+		}
+		// 可疑通知已经记录并告警, 返回成功避免微信重复推送, 由人工核查处理
+		ctx.JSON(http.StatusOK, &AckAsyncNotificationFromWeChatPay{
+			Code:    "SUCCESS",
+			Message: "",
+		})
+		return
+	}
+
+	// 5. 更新退款单记录, 该更新是幂等的, 失败时由微信重试
+	refundStatus, paymentStatus, refundedAmount := dao.RefundStatusSuccess, dao.PaymentStatusRefundSuccess, int64(refundResource.Amount.Refund)
+	switch notification.EventType {
+	case EventTypeRefundAbnormal:
+		refundStatus, paymentStatus, refundedAmount = dao.RefundStatusAbnormal, dao.PaymentStatusRefundAbnormal, 0
+	case EventTypeRefundClosed:
+		refundStatus, paymentStatus, refundedAmount = dao.RefundStatusClosed, dao.PaymentStatusRefundClosed, 0
+	}
+	if innerErr := impl.storage.ApplyRefundResult(ctx, &dao.RefundModel{
+		MchId:               refundResource.MchId,
+		TradeId:             refundResource.OutTradeNo,
+		TransactionId:       refundResource.TransactionId,
+		OutRefundNo:         refundResource.OutRefundNo,
+		RefundId:            refundResource.RefundId,
+		AmountTotal:         int64(refundResource.Amount.Total),
+		AmountRefund:        int64(refundResource.Amount.Refund),
+		Status:              refundStatus,
+		WxRefundStatus:      refundResource.RefundStatus,
+		UserReceivedAccount: refundResource.UserReceivedAccount,
+		SuccessTime:         refundResource.SuccessTime,
+	}); innerErr != nil {
+		_logger.WithError(innerErr).Error("Failed to invoke impl.storage.ApplyRefundResult.")
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "退款单更新失败",
+		})
+		return
+	}
+
+	// 6. 更新平台订单记录, 该更新是幂等的(同一退款单的退款金额只累加一次), 失败时由微信重试
+	if innerErr := impl.storage.ApplyPlatformOrderRefund(ctx, refundResource.OutTradeNo, refundResource.OutRefundNo,
+		paymentStatus, refundedAmount); innerErr != nil && innerErr != dao.ErrIllegalStateTransition {
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "平台订单更新失败",
+		})
+		return
+	}
+
+	// 7. 持久化退款通知, 用于离线对账, 同时作为该通知已处理的标记, 必须在所有更新完成后写入
+	if innerErr := impl.storage.AddRefundNotification(ctx, &dao.RefundNotificationModel{
+		NotifyId:                    notification.Id,
+		CreateTime:                  notification.CreateTime,
+		EventType:                   notification.EventType,
+		ResourceMchId:               refundResource.MchId,
+		TradeId:                     refundResource.OutTradeNo,
+		ResourceTransactionId:       refundResource.TransactionId,
+		OutRefundNo:                 refundResource.OutRefundNo,
+		ResourceRefundId:            refundResource.RefundId,
+		ResourceRefundStatus:        refundResource.RefundStatus,
+		ResourceSuccessTime:         refundResource.SuccessTime,
+		ResourceUserReceivedAccount: refundResource.UserReceivedAccount,
+		ResourceAmountTotal:         refundResource.Amount.Total,
+		ResourceAmountRefund:        refundResource.Amount.Refund,
+		ResourceAmountPayerTotal:    refundResource.Amount.PayerTotal,
+		ResourceAmountPayerRefund:   refundResource.Amount.PayerRefund,
+		Summary:                     notification.Summary,
+	}); innerErr == dao.ErrDuplicateRecord {
+		_ = impl.storage.IncrPlatformOrderNotifyRedelivery(ctx, refundResource.OutTradeNo)
+		ctx.JSON(http.StatusOK, &AckAsyncNotificationFromWeChatPay{
+			Code:    "SUCCESS",
+			Message: "",
+		})
+		return
+	} else if innerErr != nil {
+		_logger.WithError(innerErr).Error("Failed to invoke impl.storage.AddRefundNotification.")
+		ctx.JSON(http.StatusInternalServerError, &AckAsyncNotificationFromWeChatPay{
+			Code:    "FAIL",
+			Message: "通知保存失败",
+		})
+		return
+	}
+
+	// 8. 返回告知成功接收处理
 	ctx.JSON(http.StatusOK, &AckAsyncNotificationFromWeChatPay{
 		Code:    "SUCCESS",
 		Message: "",
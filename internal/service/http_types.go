@@ -1,5 +1,38 @@
 package service
 
+const (
+	/* 支付成功通知 */
+	EventTypeTransactionSuccess = "TRANSACTION.SUCCESS"
+	/* 退款成功通知 */
+	EventTypeRefundSuccess = "REFUND.SUCCESS"
+	/* 退款异常通知 */
+	EventTypeRefundAbnormal = "REFUND.ABNORMAL"
+	/* 退款关闭通知 */
+	EventTypeRefundClosed = "REFUND.CLOSED"
+)
+
+const (
+	OriginalTypeTransaction = "transaction"
+	OriginalTypeRefund      = "refund"
+)
+
+const (
+	/* 支付成功 */
+	TradeStateSuccess = "SUCCESS"
+	/* 转入退款 */
+	TradeStateRefund = "REFUND"
+	/* 未支付 */
+	TradeStateNotPay = "NOTPAY"
+	/* 已关闭 */
+	TradeStateClosed = "CLOSED"
+	/* 已撤销（付款码支付） */
+	TradeStateRevoked = "REVOKED"
+	/* 用户支付中（付款码支付） */
+	TradeStateUserPaying = "USERPAYING"
+	/* 支付失败(其他原因，如银行返回失败) */
+	TradeStatePayError = "PAYERROR"
+)
+
 type AsyncNotificationFromWeChatPay struct {
 	/* 通知的唯一ID, 示例值：EV-2018022511223320873 */
 	Id string `json:"id"`
@@ -16,7 +49,7 @@ type AsyncNotificationFromWeChatPay struct {
 }
 
 type AsyncNotificationResourceFromWeChatPay struct {
-	/* 原始回调类型, 为"transaction" */
+	/* 原始回调类型, 支付通知为"transaction", 退款通知为"refund" */
 	OriginalType string `json:"original_type"`
 	/* 加密算法类型, 目前只支持"AEAD_AES_256_GCM" */
 	Algorithm string `json:"algorithm"`
@@ -51,6 +84,8 @@ type NotificationResource struct {
 	Payer *NotificationResourcePayer `json:"payer"`
 	/* 订单金额 */
 	Amount *NotificationResourceAmount `json:"amount"`
+	/* 附加数据, 下单时传入的平台订单元数据 */
+	Attach string `json:"attach"`
 }
 
 type NotificationResourcePayer struct {
@@ -69,6 +104,38 @@ type NotificationResourceAmount struct {
 	PayerCurrency string `json:"payer_currency"`
 }
 
+type RefundNotificationResource struct {
+	/* 商户号 */
+	MchId string `json:"mchid"`
+	/* 商户订单号 */
+	OutTradeNo string `json:"out_trade_no"`
+	/* 微信支付订单号 */
+	TransactionId string `json:"transaction_id"`
+	/* 商户退款单号 */
+	OutRefundNo string `json:"out_refund_no"`
+	/* 微信支付退款单号 */
+	RefundId string `json:"refund_id"`
+	/* 退款状态, SUCCESS: 退款成功; CLOSED: 退款关闭; ABNORMAL: 退款异常 */
+	RefundStatus string `json:"refund_status"`
+	/* 退款成功时间 */
+	SuccessTime string `json:"success_time"`
+	/* 退款入账账户, 示例值：招商银行信用卡0403 */
+	UserReceivedAccount string `json:"user_received_account"`
+	/* 金额信息 */
+	Amount *RefundNotificationResourceAmount `json:"amount"`
+}
+
+type RefundNotificationResourceAmount struct {
+	/* 订单金额 */
+	Total int `json:"total"`
+	/* 退款金额 */
+	Refund int `json:"refund"`
+	/* 用户支付金额 */
+	PayerTotal int `json:"payer_total"`
+	/* 用户退款金额 */
+	PayerRefund int `json:"payer_refund"`
+}
+
 type AckAsyncNotificationFromWeChatPay struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"fmt"
-	"sync/atomic"
+	"maps"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/wechatpay-apiv3/wechatpay-go/core"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments"
 	"github.com/wechatpay-apiv3/wechatpay-go/services/payments/jsapi"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -14,6 +16,7 @@ import (
 	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
 	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
 	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
 	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
 	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
 )
@@ -42,9 +45,13 @@ func (impl *WechatPaymentCallbackServiceImpl) MakeNewPlatformTradeId(
 		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
 		WithField(common.LoggerKeyEvent, "MakeNewPlatformTradeId")
 
-	// NOTE: 如果服务需要多实例部署, 请使用分布式ID生成器
-	atomic.AddUint64(&(impl.ops), 1)
-	tradeId := fmt.Sprintf("%s%02d", time.Now().Format("20060102150405003"), (impl.ops)%100)
+	// NOTE: 使用集群安全的交易ID生成器, 服务可以多实例部署
+	tradeId, innerErr := impl.tradeIdGenerator.Generate(ctx)
+	if innerErr != nil {
+		_logger.WithError(innerErr).Error("Failed to invoke impl.tradeIdGenerator.Generate.")
+		err = status.Error(codes.Internal, "Failed to generate trade_id.")
+		return
+	}
 	_logger.Debugf("Generated new TradeId: %s.", tradeId)
 
 	resp = &proto_gens.MakeNewPlatformTradeIdResponse{
@@ -53,7 +60,13 @@ func (impl *WechatPaymentCallbackServiceImpl) MakeNewPlatformTradeId(
 	return
 }
 
+const (
+	PrepayLockKeyPrefix = "wechat_payment_callback_service.prepay.lock.trade_id."
+	PrepayLockTTL       = 30 * time.Second
+)
+
 // 创建平台订单和微信预支付订单, 其中平台订单用于标识交易, 微信预支付订单用于发起支付.
+// NOTE: 接口是幂等的, 使用相同参数重复调用时复用已有的微信预支付订单, 返回重新生成的带签名支付信息.
 // 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_1.shtml
 // NOTE: 如何调用 MakeNewWxPrepayOrder 接口失败, 务必由调用端发起去调用 CloseWxPrepayOrder 接口来关闭微信预支付订单.
 func (impl *WechatPaymentCallbackServiceImpl) MakeNewWxPrepayOrder(
@@ -83,6 +96,11 @@ func (impl *WechatPaymentCallbackServiceImpl) MakeNewWxPrepayOrder(
 		err = status.Error(codes.InvalidArgument, "Empty trade_id")
 		return
 	}
+	if !impl.tradeIdGenerator.Validate(req.TradeId) {
+		// 平台订单交易ID必须由 MakeNewPlatformTradeId 接口生成
+		err = status.Error(codes.InvalidArgument, "Invalid trade_id")
+		return
+	}
 	if len(req.ItemDescription) == 0 {
 		err = status.Error(codes.InvalidArgument, "Empty item_description")
 		return
@@ -91,111 +109,242 @@ func (impl *WechatPaymentCallbackServiceImpl) MakeNewWxPrepayOrder(
 		err = status.Error(codes.InvalidArgument, "Invalid item_amount_total")
 		return
 	}
+	items := orderItemsFromProto(req.Items)
+	if innerErr := ValidateOrderItems(items, req.ItemAmountTotal); len(items) > 0 && innerErr != nil {
+		if innerErr == ErrOrderItemsTotalMismatch {
+			err = status.Error(codes.InvalidArgument, "Items do not sum to item_amount_total")
+		} else {
+			err = status.Error(codes.InvalidArgument, "Invalid items")
+		}
+		return
+	}
+
+	if _, innerErr := EncodeAttach(req.Metadata); innerErr != nil {
+		err = status.Error(codes.InvalidArgument, "Invalid metadata")
+		return
+	}
 
-	// 1. 生成平台订单, 创建数据库订单记录
-	ct := time.Now()
-	if innerErr := impl.storage.AddPlatformOrder(ctx, &dao.PlatformOrderModel{
+	// 1. 同一平台订单交易ID的下单请求串行处理
+	lock, innerErr := ext_redis.GetConnPool().GetBigCache().TryLock(
+		ctx, fmt.Sprintf("%s%s", PrepayLockKeyPrefix, req.TradeId), PrepayLockTTL)
+	if innerErr == ext_redis.ErrLockNotAcquired {
+		err = status.Error(codes.Aborted, "Platform-order is being processed.")
+		return
+	} else if innerErr != nil {
+		_logger.WithError(innerErr).Error("Failed to lock platform-order.")
+		err = status.Error(codes.Internal, "Failed to lock platform-order.")
+		return
+	}
+	defer func() {
+		_ = lock.Unlock(context.Background())
+	}()
+
+	// 2. 重复下单请求参数一致时, 复用已有的微信预支付订单标识重新生成带签名支付信息, 不再请求JSAPI下单接口
+	expected := &dao.PlatformOrderModel{
 		AppId:           req.AppId,
-		MchId:           impl.confMerchantId,
 		TradeId:         req.TradeId,
 		PayerUid:        req.PayerUid,
+		TradeType:       dao.TradeTypeJSAPI,
 		ItemDescription: req.ItemDescription,
 		ItemAmountTotal: req.ItemAmountTotal,
-		Status:          dao.PaymentStatusCreatePlatformOrder,
-		ExpireTime:      ct.Add(time.Duration(config.GetConfig().ServiceInternalConfig.PaymentExpireTimeInMinute) * time.Minute).Unix(),
-		CreateTime:      ct.Unix(),
-		UpdateTime:      ct.Unix(),
-	}); innerErr != nil {
-		_logger.WithError(innerErr).Error("Failed to invoke impl.storage.AddPlatformOrder.")
-		err = status.Error(codes.Internal, "Failed to create platform-order.")
+		Items:           items,
+		Metadata:        req.Metadata,
+	}
+	order, innerErr := impl.storage.GetPlatformOrder(ctx, req.TradeId)
+	if innerErr != nil && innerErr != dao.ErrRecordNotFound {
+		err = status.Error(codes.Internal, "Failed to get platform-order.")
 		return
 	}
-
-	// 2. 请求JSAPI下单接口, 创建微信预支付订单, 更新数据库订单记录
-	_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusCreateWXPrepayOrder)
-	retries := 0
-RETRY:
-	prepayresp, _, innerErr := impl.svc.Prepay(
-		ctx,
-		jsapi.PrepayRequest{
-			Appid:       core.String(req.AppId),
-			Mchid:       core.String(impl.confMerchantId),
-			Description: core.String(req.ItemDescription),
-			OutTradeNo:  core.String(req.TradeId),
-			TimeExpire:  core.Time(ct.Add(time.Duration(config.GetConfig().ServiceInternalConfig.PaymentExpireTimeInMinute) * time.Minute)),
-			NotifyUrl:   core.String(impl.confNotifyUrl),
-			Amount:      &jsapi.Amount{Total: core.Int64(req.ItemAmountTotal)},
-			Payer:       &jsapi.Payer{Openid: core.String(req.PayerUid)},
-		},
-	)
-	if innerErr != nil {
-		_logger.WithError(innerErr).Error("Failed to invoke JsapiApiService.Prepay.")
-
-		if core.IsAPIError(innerErr, "SYSTEM_ERROR") ||
-			core.IsAPIError(innerErr, "BANK_ERROR") ||
-			core.IsAPIError(innerErr, "FREQUENCY_LIMITED") {
-			// 处理系统错误/银行系统异常/频率超限, 使用相同参数至多重新调用三次
-			if retries < 3 {
-				retries += 1
-				// NOTE: 重试间隔时间可以根据实际情况调整
-				time.Sleep(time.Duration(retries) * time.Second)
-				goto RETRY
-			} else {
-				// 重试次数超限
-				err = status.Error(codes.Internal, "WX_PRE_PAY_ERROR")
-			}
-		} else {
-			// 处理其他错误
-			err = status.Error(codes.Internal, "WX_PRE_PAY_ERROR")
+	if order != nil {
+		if err = checkRepeatedPrepayOrder(order, expected); err != nil {
+			return
+		}
+		if isWxPrepayIdValid(order, time.Now()) {
+			_logger.Info("Reuse prepay_id of repeated platform-order.")
+			resp, err = impl.makeNewWxRequestPaymentParams(ctx, _logger, req.TradeId, req.AppId, order.PrepayId)
+			return
+		}
+		// 平台订单已创建但微信预支付订单未创建成功(或预支付订单标识已失效), 使用原过期时间重新请求JSAPI下单接口
+	} else {
+		// 生成平台订单, 创建数据库订单记录
+		if innerErr := impl.createPlatformOrder(ctx, expected, time.Now()); innerErr == dao.ErrDuplicateRecord {
+			err = status.Error(codes.AlreadyExists, "trade_id is used by another platform-order.")
+			return
+		} else if innerErr != nil {
+			_logger.WithError(innerErr).Error("Failed to invoke impl.storage.AddPlatformOrder.")
+			err = status.Error(codes.Internal, "Failed to create platform-order.")
+			return
 		}
+		order = expected
 	}
+
+	// 3. 请求JSAPI下单接口, 创建微信预支付订单, 更新数据库订单记录
+	_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusCreateWXPrepayOrder)
+	var prepayresp *jsapi.PrepayResponse
+	innerErr = retryWxCall(ctx, _logger, "JsapiApiService.Prepay", WxRetryableCodes, func() (err error) {
+		prepayresp, _, err = impl.svc.Prepay(
+			ctx,
+			jsapi.PrepayRequest{
+				Appid:       core.String(req.AppId),
+				Mchid:       core.String(impl.confMerchantId),
+				Description: core.String(req.ItemDescription),
+				OutTradeNo:  core.String(req.TradeId),
+				TimeExpire:  core.Time(time.Unix(order.ExpireTime, 0)),
+				NotifyUrl:   core.String(impl.confNotifyUrl),
+				Amount:      &jsapi.Amount{Total: core.Int64(req.ItemAmountTotal)},
+				Payer:       &jsapi.Payer{Openid: core.String(req.PayerUid)},
+				Detail:      makeNewPrepayDetail(order.Items),
+				Attach:      makeNewPrepayAttach(order.Metadata),
+			},
+		)
+		return
+	})
 	if innerErr != nil {
-		// 3. 请求JSAPI下单接口失败, 更新数据库订单记录
-		_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusCreateWXPrepayOrderFailed)
+		// 4. 请求JSAPI下单接口失败, 更新数据库订单记录
+		_ = impl.storage.UpdatePlatformOrder(withWxErrorCode(ctx, innerErr), req.TradeId, dao.PaymentStatusCreateWXPrepayOrderFailed)
+		err = status.Error(codes.Internal, "WX_PRE_PAY_ERROR")
 		return
 	}
-	// 3. 请求JSAPI下单接口成功, 返回预支付订单标识, 更新数据库订单记录
+	// 4. 请求JSAPI下单接口成功, 返回预支付订单标识, 更新数据库订单记录
+	_ = impl.storage.SavePlatformOrderPrepayId(ctx, req.TradeId, *prepayresp.PrepayId)
 	_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusGetPrepayId)
 
-	// 4. 生成带签名支付信息
-	ts, nonce, pkg := time.Now().Unix(), GenerateNonce(), fmt.Sprintf("prepay_id=%s", *prepayresp.PrepayId)
-	signature, innerErr := MakeNewPaymentSignature(impl.confMchPrivateKey, &SignParams{
-		AppId:     req.AppId,
-		Timestamp: ts,
-		Nonce:     nonce,
-		Package:   pkg,
-	})
+	// 5. 生成带签名支付信息
+	resp, err = impl.makeNewWxRequestPaymentParams(ctx, _logger, req.TradeId, req.AppId, *prepayresp.PrepayId)
+	// NOTE: 过期未支付的平台订单由后台任务 closeExpiredOrders 负责关闭
+
+	return
+}
+
+// makeNewWxRequestPaymentParams 为微信预支付订单生成JSAPI调起支付所需的带签名支付信息, 更新数据库订单记录.
+func (impl *WechatPaymentCallbackServiceImpl) makeNewWxRequestPaymentParams(
+	ctx context.Context, _logger *logrus.Entry, tradeId, appId, prepayId string) (
+	resp *proto_gens.MakeNewWxPrepayOrderResponse, err error) {
+
+	params, innerErr := impl.signWxRequestPaymentParams(appId, prepayId)
 	if innerErr != nil {
-		// 4. 生成带签名支付信息失败, 更新数据库订单记录
-		_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusCreatePaymentSignatureFailed)
+		// 生成带签名支付信息失败, 更新数据库订单记录
+		_ = impl.storage.UpdatePlatformOrder(ctx, tradeId, dao.PaymentStatusCreatePaymentSignatureFailed)
 		_logger.WithError(innerErr).Error("Failed to invoke MakeNewPaymentSignature.")
 		err = status.Error(codes.Internal, "Failed to create payment signature.")
 		return
 	}
 	// 更新数据库订单记录
-	_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusCreatePaymentSignature)
+	_ = impl.storage.UpdatePlatformOrder(ctx, tradeId, dao.PaymentStatusCreatePaymentSignature)
 
-	resp = &proto_gens.MakeNewWxPrepayOrderResponse{
-		Params: &proto_gens.WxRequestPaymentParams{
-			Timestamp: ts,
-			Nonce:     nonce,
-			Package:   pkg,
-			SignType:  SignTypeRSA,
-			PaySign:   signature,
-		},
+	resp = &proto_gens.MakeNewWxPrepayOrderResponse{Params: params}
+	return
+}
+
+// checkRepeatedPrepayOrder 校验重复下单请求与已有平台订单是否一致, 以及已有平台订单是否仍可发起支付.
+func checkRepeatedPrepayOrder(order, expected *dao.PlatformOrderModel) error {
+	if order.AppId != expected.AppId ||
+		order.PayerUid != expected.PayerUid ||
+		order.TradeType != expected.TradeType ||
+		order.ItemDescription != expected.ItemDescription ||
+		order.ItemAmountTotal != expected.ItemAmountTotal ||
+		!sameOrderItems(order.Items, expected.Items) ||
+		!maps.Equal(order.Metadata, expected.Metadata) {
+		return status.Error(codes.AlreadyExists, "trade_id is used by another platform-order.")
+	}
+	switch order.CurrentState() {
+	case dao.OrderStatePaid, dao.OrderStateRefunding, dao.OrderStateRefunded:
+		return status.Error(codes.FailedPrecondition, "Platform-order is paid.")
+	case dao.OrderStateClosed:
+		return status.Error(codes.FailedPrecondition, "Platform-order is closed.")
+	case dao.OrderStateFailed:
+		return status.Error(codes.FailedPrecondition, "Platform-order is failed, use a new trade_id.")
+	}
+	if order.ExpireTime <= time.Now().Unix() {
+		return status.Error(codes.FailedPrecondition, "Platform-order is expired.")
 	}
-	// TODO: 如果N分钟内客户端未通知系统支付成功, 则由系统发起去关闭微信预支付订单
+	return nil
+}
 
-	return
+// sameOrderItems 比较两组商品明细是否一致, 商品明细的顺序也必须一致.
+func sameOrderItems(a, b []*dao.OrderItemModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if *(a[i]) != *(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// orderItemsFromProto 将请求中的商品明细转换为数据库记录.
+func orderItemsFromProto(items []*proto_gens.OrderItem) []*dao.OrderItemModel {
+	if len(items) == 0 {
+		return nil
+	}
+	models := make([]*dao.OrderItemModel, 0, len(items))
+	for _, item := range items {
+		models = append(models, &dao.OrderItemModel{
+			SkuId:            item.SkuId,
+			Name:             item.Name,
+			UnitPrice:        item.UnitPrice,
+			Quantity:         item.Quantity,
+			WechatpayGoodsId: item.WechatpayGoodsId,
+		})
+	}
+	return models
+}
+
+// makeNewPrepayDetail 将商品明细转换为JSAPI下单接口的优惠功能商品详情, 没有商品明细时不上传.
+func makeNewPrepayDetail(items []*dao.OrderItemModel) *jsapi.Detail {
+	if len(items) == 0 {
+		return nil
+	}
+	detail := &jsapi.Detail{GoodsDetail: make([]jsapi.GoodsDetail, 0, len(items))}
+	for _, item := range items {
+		goods := jsapi.GoodsDetail{
+			MerchantGoodsId: core.String(item.SkuId),
+			GoodsName:       core.String(item.Name),
+			Quantity:        core.Int64(item.Quantity),
+			UnitPrice:       core.Int64(item.UnitPrice),
+		}
+		if len(item.WechatpayGoodsId) > 0 {
+			goods.WechatpayGoodsId = core.String(item.WechatpayGoodsId)
+		}
+		detail.GoodsDetail = append(detail.GoodsDetail, goods)
+	}
+	return detail
+}
+
+// makeNewPrepayAttach 将平台订单元数据编码为下单接口的附加数据, 没有元数据时不上传.
+func makeNewPrepayAttach(metadata map[string]string) *string {
+	attach, err := EncodeAttach(metadata)
+	if err != nil || len(attach) == 0 {
+		return nil
+	}
+	return core.String(attach)
+}
+
+// createPlatformOrder 补全平台订单的商户号/状态/过期时间等公共字段, 创建数据库订单记录.
+// NOTE: 各支付渠道共用同一套平台订单记录和订单状态码, 查询/关单/支付通知处理与渠道无关.
+func (impl *WechatPaymentCallbackServiceImpl) createPlatformOrder(
+	ctx context.Context, order *dao.PlatformOrderModel, ct time.Time) error {
+
+	order.MchId = impl.confMerchantId
+	order.State = dao.OrderStateCreated
+	order.Status = dao.PaymentStatusCreatePlatformOrder
+	order.ExpireTime = ct.Add(time.Duration(config.GetConfig().ServiceInternalConfig.PaymentExpireTimeInMinute) * time.Minute).Unix()
+	order.CreateTime = ct.Unix()
+	order.UpdateTime = ct.Unix()
+	return impl.storage.AddPlatformOrder(ctx, order)
 }
 
 // 查询微信支付状态.
+// 支持按平台订单交易ID或微信支付订单号查询.
 // 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_2.shtml
 // NOET: 以下情况需要调用查询接口：
 // • 当商户后台、网络、服务器等出现异常，商户系统最终未接收到支付通知（N分钟后）。
 // • 调用支付接口后，返回系统错误或未知交易状态情况。
 // • 调用付款码支付API后，返回USERPAYING状态。
 // • 调用关单或撤销接口API之前，需确认支付状态。
+// NOTE: 查询得到的支付结果会回写到数据库订单记录, 本地优先模式下支付结果已确定的平台订单不再请求微信支付.
 func (impl *WechatPaymentCallbackServiceImpl) QueryWxPaymentStatus(
 	ctx context.Context, req *proto_gens.QueryWxPaymentStatusRequest) (
 	resp *proto_gens.QueryWxPaymentStatusResponse, err error) {
@@ -204,40 +353,57 @@ func (impl *WechatPaymentCallbackServiceImpl) QueryWxPaymentStatus(
 		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
 		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
 		WithField(common.LoggerKeyEvent, "QueryWxPaymentStatus").
-		WithField("trade_id", req.TradeId)
+		WithField("trade_id", req.GetTradeId()).
+		WithField("transaction_id", req.GetTransactionId())
 
-	retries := 0
-RETRY:
-	queryorderresp, _, innerErr := impl.svc.QueryOrderByOutTradeNo(
-		ctx,
-		jsapi.QueryOrderByOutTradeNoRequest{
-			OutTradeNo: core.String(req.TradeId),
-			Mchid:      core.String(impl.confMerchantId),
-		},
-	)
-	if innerErr != nil {
-		_logger.WithError(innerErr).Error("Failed to invoke JsapiApiService.QueryOrderByOutTradeNo.")
-
-		if core.IsAPIError(innerErr, "SYSTEMERROR") ||
-			core.IsAPIError(innerErr, "BANKERROR") ||
-			core.IsAPIError(innerErr, "FREQUENCY_LIMITED") {
-			// 处理系统错误/银行系统异常/频率超限, 使用相同参数至多重新调用三次
-			if retries < 3 {
-				retries += 1
-				// NOTE: 重试间隔时间可以根据实际情况调整
-				time.Sleep(time.Duration(retries) * time.Second)
-				goto RETRY
-			} else {
-				// 重试次数超限
-				err = status.Error(codes.Internal, "WX_QUERY_ORDER_ERROR")
-				return
-			}
-		} else {
-			// 处理其他错误
-			err = status.Error(codes.Internal, "WX_QUERY_ORDER_ERROR")
+	// 参数校验
+	if len(req.GetTradeId()) == 0 && len(req.GetTransactionId()) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id or transaction_id")
+		return
+	}
+
+	// 1. 查询数据库订单记录, 按微信支付订单号查询时由已保存的支付通知反查平台订单交易ID
+	// 本地优先模式下支付结果已确定的平台订单直接返回本地订单记录
+	tradeId := req.GetTradeId()
+	if len(tradeId) == 0 {
+		notification, innerErr := impl.storage.GetPaymentNotificationByTransactionId(ctx, req.GetTransactionId())
+		if innerErr == nil {
+			tradeId = notification.TradeId
+		} else if innerErr != dao.ErrRecordNotFound {
+			err = status.Error(codes.Internal, "Failed to get payment-notification.")
 			return
 		}
 	}
+	var order *dao.PlatformOrderModel
+	if len(tradeId) > 0 {
+		var innerErr error
+		order, innerErr = impl.storage.GetPlatformOrder(ctx, tradeId)
+		if innerErr != nil && innerErr != dao.ErrRecordNotFound {
+			err = status.Error(codes.Internal, "Failed to get platform-order.")
+			return
+		}
+	}
+	if order != nil && req.LocalFirst && order.IsPaymentSettled() {
+		if resp = toQueryWxPaymentStatusResponse(order); resp != nil {
+			return
+		}
+	}
+
+	// 2. 查询微信支付订单
+	var queryorderresp *payments.Transaction
+	var innerErr error
+	if len(req.GetTradeId()) > 0 {
+		queryorderresp, innerErr = impl.queryWxOrder(ctx, _logger, req.GetTradeId())
+	} else {
+		queryorderresp, innerErr = impl.queryWxOrderById(ctx, _logger, req.GetTransactionId())
+	}
+	if innerErr == ErrWxOrderNotExist {
+		err = status.Error(codes.NotFound, "WX_ORDER_NOT_EXIST")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "WX_QUERY_ORDER_ERROR")
+		return
+	}
 
 	resp = &proto_gens.QueryWxPaymentStatusResponse{
 		AppId:      *(queryorderresp.Appid),
@@ -253,10 +419,68 @@ RETRY:
 	if queryorderresp.SuccessTime != nil {
 		resp.SuccessTime = *(queryorderresp.SuccessTime)
 	}
+	if queryorderresp.Attach != nil {
+		resp.Metadata = DecodeAttach(*(queryorderresp.Attach))
+	}
+	if order == nil && len(tradeId) == 0 {
+		// 本地未保存过支付通知, 按微信支付订单返回的平台订单交易ID查询数据库订单记录
+		if order, innerErr = impl.storage.GetPlatformOrder(ctx, resp.TradeId); innerErr != nil {
+			order = nil
+		}
+	}
+	if order == nil {
+		return
+	}
+
+	// 3. 回写支付结果, 与支付通知处理共用同一把锁, 未获取到锁时由持有锁的一方推进平台订单状态
+	lock, innerErr := ext_redis.GetConnPool().GetBigCache().TryLock(
+		ctx, fmt.Sprintf("%s%s", NotifyLockKeyPrefix, order.TradeId), NotifyLockTTL)
+	if innerErr != nil {
+		_logger.WithError(innerErr).Debug("Failed to lock platform-order, skip syncing.")
+		resp.State = order.CurrentState()
+		return
+	}
+	defer func() {
+		_ = lock.Unlock(context.Background())
+	}()
+	impl.syncPlatformOrder(ctx, _logger, order, queryorderresp)
+	if synced, innerErr := impl.storage.GetPlatformOrder(ctx, order.TradeId); innerErr == nil {
+		order = synced
+	}
+	resp.State = order.CurrentState()
 
 	return
 }
 
+// toQueryWxPaymentStatusResponse 由本地订单记录生成支付结果, 早期订单记录缺失微信支付订单号时返回nil.
+func toQueryWxPaymentStatusResponse(order *dao.PlatformOrderModel) *proto_gens.QueryWxPaymentStatusResponse {
+	resp := &proto_gens.QueryWxPaymentStatusResponse{
+		AppId:       order.AppId,
+		TradeId:     order.TradeId,
+		TrxId:       order.TransactionId,
+		TradeType:   order.TradeType,
+		SuccessTime: order.SuccessTime,
+		Metadata:    order.Metadata,
+		State:       order.CurrentState(),
+		FromLocal:   true,
+	}
+	switch resp.State {
+	case dao.OrderStatePaid:
+		resp.TradeState = TradeStateSuccess
+	case dao.OrderStateRefunding, dao.OrderStateRefunded:
+		resp.TradeState = TradeStateRefund
+	case dao.OrderStateClosed:
+		resp.TradeState = TradeStateClosed
+		return resp
+	default:
+		return nil
+	}
+	if len(order.TransactionId) == 0 {
+		return nil
+	}
+	return resp
+}
+
 // 关闭微信支付订单.
 // 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_3.shtml
 // NOET: 以下情况需要调用关单接口：
@@ -273,50 +497,12 @@ func (impl *WechatPaymentCallbackServiceImpl) CloseWxPrepayOrder(
 		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
 		WithField(common.LoggerKeyEvent, "CloseWxPrepayOrder").
 		WithField("trade_id", req.TradeId)
-	_ = _logger
-
-	retries := 0
-RETRY:
-	_, innerErr := impl.svc.CloseOrder(
-		ctx,
-		jsapi.CloseOrderRequest{
-			OutTradeNo: core.String(req.TradeId),
-			Mchid:      core.String(impl.confMerchantId),
-		},
-	)
-	if innerErr != nil {
-		_logger.WithError(innerErr).Error("Failed to invoke JsapiApiService.CloseOrder")
-
-		if core.IsAPIError(innerErr, "ORDERNOTEXIST") ||
-			core.IsAPIError(innerErr, "ORDER_CLOSED") ||
-			core.IsAPIError(innerErr, "MCH_NOT_EXISTS") {
-			// 处理订单不存在/订单已关闭/商户号不存在
-			resp = &proto_gens.CloseWxPrepayOrderResponse{}
-			return
-		} else if core.IsAPIError(innerErr, "SYSTEMERROR") ||
-			core.IsAPIError(innerErr, "BANKERROR") ||
-			core.IsAPIError(innerErr, "FREQUENCY_LIMITED") {
-			// 处理系统错误/银行系统异常/频率超限, 使用相同参数至多重新调用三次
-			if retries < 3 {
-				retries += 1
-				// NOTE: 重试间隔时间可以根据实际情况调整
-				time.Sleep(time.Duration(retries) * time.Second)
-				goto RETRY
-			} else {
-				// 重试次数超限
-				err = status.Error(codes.Internal, "WX_CLOSE_ORDER_ERROR")
-				return
-			}
-		} else {
-			// 处理其他错误
-			err = status.Error(codes.Internal, "WX_CLOSE_ORDER_ERROR")
-			return
-		}
-	}
 
+	innerErr := impl.closeWxOrder(ctx, _logger, req.TradeId)
 	if innerErr != nil {
 		// 关闭平台订单失败, 更新数据库订单记录
-		_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusClosePlatformOrderFailed)
+		_ = impl.storage.UpdatePlatformOrder(withWxErrorCode(ctx, innerErr), req.TradeId, dao.PaymentStatusClosePlatformOrderFailed)
+		err = status.Error(codes.Internal, "WX_CLOSE_ORDER_ERROR")
 		return
 	}
 	// 关闭平台订单成功, 更新数据库订单记录
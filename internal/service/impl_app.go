@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/wechatpay-apiv3/wechatpay-go/core"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments/app"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+// 创建平台订单和微信APP预支付订单, 返回APP调起支付所需的带签名支付信息.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_2_1.shtml
+// NOTE: 如何调用 MakeNewAppPrepayOrder 接口失败, 务必由调用端发起去调用 CloseWxPrepayOrder 接口来关闭微信预支付订单.
+func (impl *WechatPaymentCallbackServiceImpl) MakeNewAppPrepayOrder(
+	ctx context.Context, req *proto_gens.MakeNewAppPrepayOrderRequest) (
+	resp *proto_gens.MakeNewAppPrepayOrderResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "MakeNewAppPrepayOrder").
+		WithField("trade_id", req.TradeId)
+
+	// 参数校验
+	if len(req.AppId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty app_id")
+		return
+	}
+	if _, ok := impl.confSupportedAppIdTable[req.AppId]; !ok {
+		err = status.Error(codes.InvalidArgument, "Unsupported app_id")
+		return
+	}
+	if len(req.TradeId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id")
+		return
+	}
+	if !impl.tradeIdGenerator.Validate(req.TradeId) {
+		// 平台订单交易ID必须由 MakeNewPlatformTradeId 接口生成
+		err = status.Error(codes.InvalidArgument, "Invalid trade_id")
+		return
+	}
+	if len(req.ItemDescription) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty item_description")
+		return
+	}
+	if req.ItemAmountTotal <= 0 {
+		err = status.Error(codes.InvalidArgument, "Invalid item_amount_total")
+		return
+	}
+
+	// 1. 生成平台订单, 创建数据库订单记录
+	ct := time.Now()
+	if innerErr := impl.createPlatformOrder(ctx, &dao.PlatformOrderModel{
+		AppId:           req.AppId,
+		TradeId:         req.TradeId,
+		PayerUid:        req.PayerUid,
+		TradeType:       dao.TradeTypeAPP,
+		ItemDescription: req.ItemDescription,
+		ItemAmountTotal: req.ItemAmountTotal,
+	}, ct); innerErr == dao.ErrDuplicateRecord {
+		err = status.Error(codes.AlreadyExists, "trade_id is used by another platform-order.")
+		return
+	} else if innerErr != nil {
+		_logger.WithError(innerErr).Error("Failed to invoke impl.storage.AddPlatformOrder.")
+		err = status.Error(codes.Internal, "Failed to create platform-order.")
+		return
+	}
+
+	// 2. 请求APP下单接口, 创建微信预支付订单, 更新数据库订单记录
+	_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusCreateWXPrepayOrder)
+	var prepayresp *app.PrepayResponse
+	innerErr := retryWxCall(ctx, _logger, "AppApiService.Prepay", WxRetryableCodes, func() (err error) {
+		prepayresp, _, err = impl.appSvc.Prepay(
+			ctx,
+			app.PrepayRequest{
+				Appid:       core.String(req.AppId),
+				Mchid:       core.String(impl.confMerchantId),
+				Description: core.String(req.ItemDescription),
+				OutTradeNo:  core.String(req.TradeId),
+				TimeExpire:  core.Time(ct.Add(time.Duration(config.GetConfig().ServiceInternalConfig.PaymentExpireTimeInMinute) * time.Minute)),
+				NotifyUrl:   core.String(impl.confNotifyUrl),
+				Amount:      &app.Amount{Total: core.Int64(req.ItemAmountTotal)},
+			},
+		)
+		return
+	})
+	if innerErr != nil {
+		// 3. 请求APP下单接口失败, 更新数据库订单记录
+		_ = impl.storage.UpdatePlatformOrder(withWxErrorCode(ctx, innerErr), req.TradeId, dao.PaymentStatusCreateWXPrepayOrderFailed)
+		err = status.Error(codes.Internal, "WX_PRE_PAY_ERROR")
+		return
+	}
+	// 3. 请求APP下单接口成功, 返回预支付交易会话标识, 更新数据库订单记录
+	_ = impl.storage.SavePlatformOrderPrepayId(ctx, req.TradeId, *prepayresp.PrepayId)
+	_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusGetPrepayId)
+
+	// 4. 生成带签名支付信息
+	params, innerErr := impl.signWxAppPaymentParams(req.AppId, *prepayresp.PrepayId)
+	if innerErr != nil {
+		// 4. 生成带签名支付信息失败, 更新数据库订单记录
+		_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusCreatePaymentSignatureFailed)
+		_logger.WithError(innerErr).Error("Failed to invoke MakeNewPaymentSignature.")
+		err = status.Error(codes.Internal, "Failed to create payment signature.")
+		return
+	}
+	// 更新数据库订单记录
+	_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusCreatePaymentSignature)
+
+	resp = &proto_gens.MakeNewAppPrepayOrderResponse{Params: params}
+	return
+}
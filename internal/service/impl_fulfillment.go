@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+// 确认平台订单已履约, 重复确认直接返回.
+// NOTE: 履约超时且已按自动退款策略处理的平台订单不允许再确认履约, 调用方需要收回已发放的权益.
+func (impl *WechatPaymentCallbackServiceImpl) ConfirmFulfillment(
+	ctx context.Context, req *proto_gens.ConfirmFulfillmentRequest) (
+	resp *proto_gens.ConfirmFulfillmentResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ConfirmFulfillment").
+		WithField("trade_id", req.TradeId)
+
+	// 参数校验
+	if len(req.TradeId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id")
+		return
+	}
+
+	order, innerErr := impl.storage.GetPlatformOrder(ctx, req.TradeId)
+	if innerErr == dao.ErrRecordNotFound {
+		err = status.Error(codes.NotFound, "Platform-order not found.")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to get platform-order.")
+		return
+	}
+	if order.FulfillmentState == dao.FulfillmentStateConfirmed {
+		resp = toConfirmFulfillmentResponse(order)
+		return
+	}
+	switch order.CurrentState() {
+	case dao.OrderStatePaid, dao.OrderStateRefunding, dao.OrderStateRefunded:
+	default:
+		err = status.Error(codes.FailedPrecondition, "Platform-order is not paid.")
+		return
+	}
+
+	confirmTime, innerErr := impl.storage.ConfirmPlatformOrderFulfillment(ctx, req.TradeId)
+	if innerErr == dao.ErrIllegalStateTransition {
+		// 可能已被并发确认, 重新读取平台订单
+		order, innerErr = impl.storage.GetPlatformOrder(ctx, req.TradeId)
+		if innerErr != nil {
+			err = status.Error(codes.Internal, "Failed to get platform-order.")
+			return
+		}
+		if order.FulfillmentState == dao.FulfillmentStateConfirmed {
+			resp = toConfirmFulfillmentResponse(order)
+			return
+		}
+		err = status.Error(codes.FailedPrecondition, "Platform-order fulfillment is overdue and will be refunded.")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to confirm fulfillment.")
+		return
+	}
+	_logger.Info("Confirmed platform-order fulfillment.")
+
+	resp = &proto_gens.ConfirmFulfillmentResponse{
+		FulfillmentState:       dao.FulfillmentStateConfirmed,
+		FulfillmentConfirmTime: confirmTime,
+	}
+	return
+}
+
+func toConfirmFulfillmentResponse(order *dao.PlatformOrderModel) *proto_gens.ConfirmFulfillmentResponse {
+	return &proto_gens.ConfirmFulfillmentResponse{
+		FulfillmentState:       order.FulfillmentState,
+		FulfillmentConfirmTime: order.FulfillmentConfirmTime,
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/wechatpay-apiv3/wechatpay-go/core"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments/h5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+// 创建平台订单和微信H5预支付订单, 返回支付跳转链接, 用于移动端浏览器拉起微信支付.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_3_1.shtml
+// NOTE: 如何调用 MakeNewH5PrepayOrder 接口失败, 务必由调用端发起去调用 CloseWxPrepayOrder 接口来关闭微信预支付订单.
+func (impl *WechatPaymentCallbackServiceImpl) MakeNewH5PrepayOrder(
+	ctx context.Context, req *proto_gens.MakeNewH5PrepayOrderRequest) (
+	resp *proto_gens.MakeNewH5PrepayOrderResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "MakeNewH5PrepayOrder").
+		WithField("trade_id", req.TradeId)
+
+	// 参数校验
+	if len(req.AppId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty app_id")
+		return
+	}
+	if _, ok := impl.confSupportedAppIdTable[req.AppId]; !ok {
+		err = status.Error(codes.InvalidArgument, "Unsupported app_id")
+		return
+	}
+	if len(req.TradeId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id")
+		return
+	}
+	if !impl.tradeIdGenerator.Validate(req.TradeId) {
+		// 平台订单交易ID必须由 MakeNewPlatformTradeId 接口生成
+		err = status.Error(codes.InvalidArgument, "Invalid trade_id")
+		return
+	}
+	if len(req.ItemDescription) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty item_description")
+		return
+	}
+	if req.ItemAmountTotal <= 0 {
+		err = status.Error(codes.InvalidArgument, "Invalid item_amount_total")
+		return
+	}
+	if req.SceneInfo == nil || len(req.SceneInfo.PayerClientIp) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty scene_info.payer_client_ip")
+		return
+	}
+	if req.SceneInfo.H5Info == nil || len(req.SceneInfo.H5Info.Type) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty scene_info.h5_info.type")
+		return
+	}
+
+	// 1. 生成平台订单, 创建数据库订单记录
+	ct := time.Now()
+	if innerErr := impl.createPlatformOrder(ctx, &dao.PlatformOrderModel{
+		AppId:           req.AppId,
+		TradeId:         req.TradeId,
+		PayerUid:        req.PayerUid,
+		TradeType:       dao.TradeTypeH5,
+		ItemDescription: req.ItemDescription,
+		ItemAmountTotal: req.ItemAmountTotal,
+	}, ct); innerErr == dao.ErrDuplicateRecord {
+		err = status.Error(codes.AlreadyExists, "trade_id is used by another platform-order.")
+		return
+	} else if innerErr != nil {
+		_logger.WithError(innerErr).Error("Failed to invoke impl.storage.AddPlatformOrder.")
+		err = status.Error(codes.Internal, "Failed to create platform-order.")
+		return
+	}
+
+	// 2. 请求H5下单接口, 创建微信预支付订单, 更新数据库订单记录
+	h5Info := &h5.H5Info{Type: core.String(req.SceneInfo.H5Info.Type)}
+	if len(req.SceneInfo.H5Info.AppName) > 0 {
+		h5Info.AppName = core.String(req.SceneInfo.H5Info.AppName)
+	}
+	if len(req.SceneInfo.H5Info.AppUrl) > 0 {
+		h5Info.AppUrl = core.String(req.SceneInfo.H5Info.AppUrl)
+	}
+	if len(req.SceneInfo.H5Info.BundleId) > 0 {
+		h5Info.BundleId = core.String(req.SceneInfo.H5Info.BundleId)
+	}
+	if len(req.SceneInfo.H5Info.PackageName) > 0 {
+		h5Info.PackageName = core.String(req.SceneInfo.H5Info.PackageName)
+	}
+	_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusCreateWXPrepayOrder)
+	var prepayresp *h5.PrepayResponse
+	innerErr := retryWxCall(ctx, _logger, "H5ApiService.Prepay", WxRetryableCodes, func() (err error) {
+		prepayresp, _, err = impl.h5Svc.Prepay(
+			ctx,
+			h5.PrepayRequest{
+				Appid:       core.String(req.AppId),
+				Mchid:       core.String(impl.confMerchantId),
+				Description: core.String(req.ItemDescription),
+				OutTradeNo:  core.String(req.TradeId),
+				TimeExpire:  core.Time(ct.Add(time.Duration(config.GetConfig().ServiceInternalConfig.PaymentExpireTimeInMinute) * time.Minute)),
+				NotifyUrl:   core.String(impl.confNotifyUrl),
+				Amount:      &h5.Amount{Total: core.Int64(req.ItemAmountTotal)},
+				SceneInfo: &h5.SceneInfo{
+					PayerClientIp: core.String(req.SceneInfo.PayerClientIp),
+					H5Info:        h5Info,
+				},
+			},
+		)
+		return
+	})
+	if innerErr != nil {
+		// 3. 请求H5下单接口失败, 更新数据库订单记录
+		_ = impl.storage.UpdatePlatformOrder(withWxErrorCode(ctx, innerErr), req.TradeId, dao.PaymentStatusCreateWXPrepayOrderFailed)
+		err = status.Error(codes.Internal, "WX_PRE_PAY_ERROR")
+		return
+	}
+	// 3. 请求H5下单接口成功, 返回支付跳转链接, 更新数据库订单记录
+	_ = impl.storage.UpdatePlatformOrder(ctx, req.TradeId, dao.PaymentStatusGetPrepayId)
+
+	resp = &proto_gens.MakeNewH5PrepayOrderResponse{
+		H5Url: AppendH5RedirectUrl(*(prepayresp.H5Url), req.RedirectUrl),
+	}
+	return
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+const (
+	ListPlatformOrdersDefaultPageSize = 20
+	ListPlatformOrdersMaxPageSize     = 100
+)
+
+// 查询平台订单, 只读取数据库订单记录, 不请求微信支付.
+func (impl *WechatPaymentCallbackServiceImpl) GetPlatformOrder(
+	ctx context.Context, req *proto_gens.GetPlatformOrderRequest) (
+	resp *proto_gens.GetPlatformOrderResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "GetPlatformOrder").
+		WithField("trade_id", req.TradeId)
+	_ = _logger
+
+	// 参数校验
+	if len(req.TradeId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id")
+		return
+	}
+
+	order, innerErr := impl.storage.GetPlatformOrder(ctx, req.TradeId)
+	if innerErr == dao.ErrRecordNotFound {
+		err = status.Error(codes.NotFound, "Platform-order not found.")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to get platform-order.")
+		return
+	}
+	notifications, innerErr := impl.storage.GetLatestPaymentNotifications(ctx, []string{req.TradeId})
+	if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to get payment-notifications.")
+		return
+	}
+
+	resp = &proto_gens.GetPlatformOrderResponse{
+		Order: toPlatformOrderInfo(order, notifications[req.TradeId]),
+	}
+	return
+}
+
+// 分页查询平台订单列表, 只读取数据库订单记录, 不请求微信支付.
+func (impl *WechatPaymentCallbackServiceImpl) ListPlatformOrders(
+	ctx context.Context, req *proto_gens.ListPlatformOrdersRequest) (
+	resp *proto_gens.ListPlatformOrdersResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListPlatformOrders")
+	_ = _logger
+
+	// 参数校验
+	if req.PageSize < 0 || req.PageSize > ListPlatformOrdersMaxPageSize {
+		err = status.Error(codes.InvalidArgument, "Invalid page_size")
+		return
+	}
+	if req.CreateTimeBegin < 0 || req.CreateTimeEnd < 0 ||
+		(req.CreateTimeEnd > 0 && req.CreateTimeBegin >= req.CreateTimeEnd) {
+		err = status.Error(codes.InvalidArgument, "Invalid create_time range")
+		return
+	}
+	pageSize := int64(req.PageSize)
+	if pageSize == 0 {
+		pageSize = ListPlatformOrdersDefaultPageSize
+	}
+	filter := &dao.PlatformOrderFilter{
+		AppId:           req.AppId,
+		PayerUid:        req.PayerUid,
+		StateList:       req.State,
+		StatusList:      make([]int, 0, len(req.Status)),
+		CreateTimeBegin: req.CreateTimeBegin,
+		CreateTimeEnd:   req.CreateTimeEnd,
+	}
+	for _, s := range req.Status {
+		filter.StatusList = append(filter.StatusList, int(s))
+	}
+
+	orders, nextCursor, innerErr := impl.storage.ListPlatformOrders(ctx, filter, req.Cursor, pageSize)
+	if innerErr == dao.ErrInvalidCursor {
+		err = status.Error(codes.InvalidArgument, "Invalid cursor")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to list platform-orders.")
+		return
+	}
+	tradeIds := make([]string, 0, len(orders))
+	for _, order := range orders {
+		tradeIds = append(tradeIds, order.TradeId)
+	}
+	notifications, innerErr := impl.storage.GetLatestPaymentNotifications(ctx, tradeIds)
+	if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to get payment-notifications.")
+		return
+	}
+
+	resp = &proto_gens.ListPlatformOrdersResponse{
+		Orders:     make([]*proto_gens.PlatformOrderInfo, 0, len(orders)),
+		NextCursor: nextCursor,
+	}
+	for _, order := range orders {
+		resp.Orders = append(resp.Orders, toPlatformOrderInfo(order, notifications[order.TradeId]))
+	}
+	return
+}
+
+// 查询平台订单的状态迁移历史.
+func (impl *WechatPaymentCallbackServiceImpl) GetOrderTimeline(
+	ctx context.Context, req *proto_gens.GetOrderTimelineRequest) (
+	resp *proto_gens.GetOrderTimelineResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "GetOrderTimeline").
+		WithField("trade_id", req.TradeId)
+	_ = _logger
+
+	// 参数校验
+	if len(req.TradeId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id")
+		return
+	}
+
+	if _, innerErr := impl.storage.GetPlatformOrder(ctx, req.TradeId); innerErr == dao.ErrRecordNotFound {
+		err = status.Error(codes.NotFound, "Platform-order not found.")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to get platform-order.")
+		return
+	}
+	events, innerErr := impl.storage.ListOrderEvents(ctx, req.TradeId)
+	if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to list order-events.")
+		return
+	}
+
+	resp = &proto_gens.GetOrderTimelineResponse{
+		Events: make([]*proto_gens.OrderEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		resp.Events = append(resp.Events, &proto_gens.OrderEvent{
+			FromState:   event.FromState,
+			FromStatus:  int32(event.FromStatus),
+			ToState:     event.ToState,
+			ToStatus:    int32(event.ToStatus),
+			Version:     event.Version,
+			TraceId:     event.TraceId,
+			Source:      event.Source,
+			WxErrorCode: event.WxErrorCode,
+			CreateTime:  event.CreateTime,
+		})
+	}
+	return
+}
+
+func toPlatformOrderInfo(order *dao.PlatformOrderModel, notification *dao.PaymentNotificationModel) *proto_gens.PlatformOrderInfo {
+	info := &proto_gens.PlatformOrderInfo{
+		AppId:                    order.AppId,
+		MerchantId:               order.MchId,
+		TradeId:                  order.TradeId,
+		PayerUid:                 order.PayerUid,
+		TradeType:                order.TradeType,
+		ItemDescription:          order.ItemDescription,
+		ItemAmountTotal:          order.ItemAmountTotal,
+		State:                    order.CurrentState(),
+		Status:                   int32(order.Status),
+		Version:                  order.Version,
+		Metadata:                 order.Metadata,
+		TrxId:                    order.TransactionId,
+		SuccessTime:              order.SuccessTime,
+		RefundedAmountTotal:      order.RefundedAmountTotal,
+		FulfillmentState:         order.FulfillmentState,
+		FulfillmentDeadline:      order.FulfillmentDeadline,
+		FulfillmentConfirmTime:   order.FulfillmentConfirmTime,
+		FulfillmentOverduePolicy: order.FulfillmentOverduePolicy,
+		FulfillmentOutRefundNo:   order.FulfillmentOutRefundNo,
+		ExpireTime:               order.ExpireTime,
+		CreateTime:               order.CreateTime,
+		UpdateTime:               order.UpdateTime,
+	}
+	for _, item := range order.Items {
+		info.Items = append(info.Items, &proto_gens.OrderItem{
+			SkuId:            item.SkuId,
+			Name:             item.Name,
+			UnitPrice:        item.UnitPrice,
+			Quantity:         item.Quantity,
+			WechatpayGoodsId: item.WechatpayGoodsId,
+		})
+	}
+	if notification != nil {
+		info.LatestNotification = &proto_gens.NotificationSummary{
+			NotifyId:       notification.NotifyId,
+			EventType:      notification.EventType,
+			TrxId:          notification.ResourceTransactionId,
+			TradeType:      notification.ResourceTradeType,
+			TradeState:     notification.ResourceTradeState,
+			TradeStateDesc: notification.ResourceTradeStateDesc,
+			SuccessTime:    notification.ResourceSuccessTime,
+			PayerTotal:     int64(notification.ResourceAmountPayerTotal),
+			Source:         notification.Source,
+			Metadata:       DecodeAttach(notification.ResourceAttach),
+		}
+		// 早期保存的支付通知没有来源字段, 均来自支付通知
+		if len(info.LatestNotification.Source) == 0 {
+			info.LatestNotification.Source = dao.NotificationSourceNotify
+		}
+	}
+	return info
+}
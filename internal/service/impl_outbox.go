@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+// 分页查询支付事件及其Webhook投递状态.
+func (impl *WechatPaymentCallbackServiceImpl) ListOutboxEvents(
+	ctx context.Context, req *proto_gens.ListOutboxEventsRequest) (
+	resp *proto_gens.ListOutboxEventsResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ListOutboxEvents")
+	_ = _logger
+
+	// 参数校验
+	if req.PageSize < 0 || req.PageSize > ListPlatformOrdersMaxPageSize {
+		err = status.Error(codes.InvalidArgument, "Invalid page_size")
+		return
+	}
+	switch req.DeliveryStatus {
+	case "", dao.DeliveryStatusPending, dao.DeliveryStatusDelivered, dao.DeliveryStatusDead:
+	default:
+		err = status.Error(codes.InvalidArgument, "Invalid delivery_status")
+		return
+	}
+	pageSize := int64(req.PageSize)
+	if pageSize == 0 {
+		pageSize = ListPlatformOrdersDefaultPageSize
+	}
+
+	events, nextCursor, innerErr := impl.storage.ListOutboxEvents(ctx, &dao.OutboxEventFilter{
+		TradeId:        req.TradeId,
+		DeliveryStatus: req.DeliveryStatus,
+	}, req.Cursor, pageSize)
+	if innerErr == dao.ErrInvalidCursor {
+		err = status.Error(codes.InvalidArgument, "Invalid cursor")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to list outbox-events.")
+		return
+	}
+
+	resp = &proto_gens.ListOutboxEventsResponse{
+		Events:     make([]*proto_gens.OutboxEventInfo, 0, len(events)),
+		NextCursor: nextCursor,
+	}
+	for _, event := range events {
+		info := &proto_gens.OutboxEventInfo{
+			EventId:         event.EventId,
+			EventType:       event.EventType,
+			TradeId:         event.TradeId,
+			State:           event.State,
+			Status:          int32(event.Status),
+			Version:         event.Version,
+			DeliveryStatus:  event.DeliveryStatus,
+			NextAttemptTime: event.NextAttemptTime,
+			CreateTime:      event.CreateTime,
+			UpdateTime:      event.UpdateTime,
+		}
+		for _, delivery := range event.Deliveries {
+			info.Deliveries = append(info.Deliveries, &proto_gens.WebhookDelivery{
+				Name:            delivery.Name,
+				Url:             delivery.Url,
+				Status:          delivery.Status,
+				Attempts:        int32(delivery.Attempts),
+				LastError:       delivery.LastError,
+				LastAttemptTime: delivery.LastAttemptTime,
+				DeliveredTime:   delivery.DeliveredTime,
+			})
+		}
+		resp.Events = append(resp.Events, info)
+	}
+	return
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+const (
+	// 微信预支付订单标识的有效期, 超过有效期后需要重新下单
+	WxPrepayIdValidity = 2 * time.Hour
+)
+
+// 重新生成带签名支付信息, 复用已有的微信预支付订单标识, 不请求微信支付.
+// NOTE: 仅支持JSAPI和APP支付, 平台订单已过期/已关闭/已支付或预支付订单标识已失效(超过2小时)时拒绝刷新.
+func (impl *WechatPaymentCallbackServiceImpl) RefreshWxPaymentParams(
+	ctx context.Context, req *proto_gens.RefreshWxPaymentParamsRequest) (
+	resp *proto_gens.RefreshWxPaymentParamsResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "RefreshWxPaymentParams").
+		WithField("trade_id", req.TradeId)
+
+	// 参数校验
+	if len(req.TradeId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id")
+		return
+	}
+
+	// 1. 查询数据库订单记录, 校验平台订单仍可发起支付
+	order, innerErr := impl.storage.GetPlatformOrder(ctx, req.TradeId)
+	if innerErr == dao.ErrRecordNotFound {
+		err = status.Error(codes.NotFound, "Platform-order not found.")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to get platform-order.")
+		return
+	}
+	if err = checkRefreshablePrepayOrder(order); err != nil {
+		return
+	}
+
+	// 2. 生成带签名支付信息
+	resp = &proto_gens.RefreshWxPaymentParamsResponse{}
+	switch order.TradeType {
+	case dao.TradeTypeJSAPI:
+		resp.Params, innerErr = impl.signWxRequestPaymentParams(order.AppId, order.PrepayId)
+	case dao.TradeTypeAPP:
+		resp.AppParams, innerErr = impl.signWxAppPaymentParams(order.AppId, order.PrepayId)
+	}
+	if innerErr != nil {
+		_logger.WithError(innerErr).Error("Failed to invoke MakeNewPaymentSignature.")
+		resp = nil
+		err = status.Error(codes.Internal, "Failed to create payment signature.")
+		return
+	}
+	return
+}
+
+// checkRefreshablePrepayOrder 校验平台订单是否仍可使用已有的微信预支付订单发起支付.
+func checkRefreshablePrepayOrder(order *dao.PlatformOrderModel) error {
+	if order.TradeType != dao.TradeTypeJSAPI && order.TradeType != dao.TradeTypeAPP {
+		return status.Error(codes.FailedPrecondition, "Unsupported trade_type.")
+	}
+	switch order.CurrentState() {
+	case dao.OrderStatePaid, dao.OrderStateRefunding, dao.OrderStateRefunded:
+		return status.Error(codes.FailedPrecondition, "Platform-order is paid.")
+	case dao.OrderStateClosed:
+		return status.Error(codes.FailedPrecondition, "Platform-order is closed.")
+	}
+	if len(order.PrepayId) == 0 {
+		return status.Error(codes.FailedPrecondition, "Platform-order has no prepay_id.")
+	}
+	if order.ExpireTime <= time.Now().Unix() {
+		return status.Error(codes.FailedPrecondition, "Platform-order is expired.")
+	}
+	if !isWxPrepayIdValid(order, time.Now()) {
+		return status.Error(codes.FailedPrecondition, "Prepay_id is expired.")
+	}
+	return nil
+}
+
+// isWxPrepayIdValid 判断平台订单保存的微信预支付订单标识是否仍在有效期内.
+// NOTE: 早期保存的平台订单没有记录预支付订单的创建时间, 无法判断是否失效, 视为已失效
+func isWxPrepayIdValid(order *dao.PlatformOrderModel, now time.Time) bool {
+	if len(order.PrepayId) == 0 || order.PrepayTime <= 0 {
+		return false
+	}
+	return now.Sub(time.Unix(order.PrepayTime, 0)) < WxPrepayIdValidity
+}
+
+// signWxRequestPaymentParams 生成JSAPI调起支付所需的带签名支付信息.
+func (impl *WechatPaymentCallbackServiceImpl) signWxRequestPaymentParams(appId, prepayId string) (
+	*proto_gens.WxRequestPaymentParams, error) {
+
+	ts, nonce, pkg := time.Now().Unix(), GenerateNonce(), fmt.Sprintf("prepay_id=%s", prepayId)
+	signature, err := MakeNewPaymentSignature(impl.confMchPrivateKey, &SignParams{
+		Layout:    SignLayoutJSAPI,
+		AppId:     appId,
+		Timestamp: ts,
+		Nonce:     nonce,
+		Package:   pkg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &proto_gens.WxRequestPaymentParams{
+		Timestamp: ts,
+		Nonce:     nonce,
+		Package:   pkg,
+		SignType:  SignTypeRSA,
+		PaySign:   signature,
+	}, nil
+}
+
+// signWxAppPaymentParams 生成APP调起支付所需的带签名支付信息.
+func (impl *WechatPaymentCallbackServiceImpl) signWxAppPaymentParams(appId, prepayId string) (
+	*proto_gens.WxAppPaymentParams, error) {
+
+	ts, nonce := time.Now().Unix(), GenerateNonce()
+	signature, err := MakeNewPaymentSignature(impl.confMchPrivateKey, &SignParams{
+		Layout:    SignLayoutAPP,
+		AppId:     appId,
+		Timestamp: ts,
+		Nonce:     nonce,
+		PrepayId:  prepayId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &proto_gens.WxAppPaymentParams{
+		AppId:     appId,
+		PartnerId: impl.confMerchantId,
+		PrepayId:  prepayId,
+		Package:   AppPackage,
+		Nonce:     nonce,
+		Timestamp: ts,
+		Sign:      signature,
+	}, nil
+}
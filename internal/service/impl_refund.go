@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wechatpay-apiv3/wechatpay-go/core"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/refunddomestic"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+const (
+	RefundLockKeyPrefix = "wechat_payment_callback_service.refund.lock.trade_id."
+	RefundLockTTL       = 30 * time.Second
+)
+
+// 申请退款, 支持对同一笔平台订单多次部分退款.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_9.shtml
+// NOTE: 退款请求失败需要重试时, 务必使用原商户退款单号, 同一商户退款单号多次请求只退一笔.
+// 失败时错误详情(ResourceInfo)中附带商户退款单号; 返回 Unavailable 表示退款结果未知, 需使用该商户退款单号重试或查询.
+func (impl *WechatPaymentCallbackServiceImpl) CreateRefund(
+	ctx context.Context, req *proto_gens.CreateRefundRequest) (
+	resp *proto_gens.CreateRefundResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "CreateRefund").
+		WithField("trade_id", req.TradeId)
+
+	// 参数校验
+	if len(req.TradeId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id")
+		return
+	}
+	if req.RefundAmount <= 0 {
+		err = status.Error(codes.InvalidArgument, "Invalid refund_amount")
+		return
+	}
+
+	order, innerErr := impl.storage.GetPlatformOrder(ctx, req.TradeId)
+	if innerErr == dao.ErrRecordNotFound {
+		err = status.Error(codes.NotFound, "Platform-order not found.")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to get platform-order.")
+		return
+	}
+	switch order.CurrentState() {
+	case dao.OrderStatePaid, dao.OrderStateRefunding, dao.OrderStateRefunded:
+	default:
+		err = status.Error(codes.FailedPrecondition, "Platform-order is not paid.")
+		return
+	}
+
+	// 1. 同一平台订单的退款请求串行处理, 避免并发申请退款超过可退金额
+	lock, innerErr := ext_redis.GetConnPool().GetBigCache().TryLock(
+		ctx, fmt.Sprintf("%s%s", RefundLockKeyPrefix, req.TradeId), RefundLockTTL)
+	if innerErr == ext_redis.ErrLockNotAcquired {
+		err = status.Error(codes.Aborted, "Platform-order is being refunded.")
+		return
+	} else if innerErr != nil {
+		_logger.WithError(innerErr).Error("Failed to lock platform-order.")
+		err = status.Error(codes.Internal, "Failed to lock platform-order.")
+		return
+	}
+	defer func() {
+		_ = lock.Unlock(context.Background())
+	}()
+
+	// 2. 重试时复用原商户退款单号, 已受理的退款单直接返回
+	var refund *dao.RefundModel
+	if len(req.OutRefundNo) > 0 {
+		refund, innerErr = impl.storage.GetRefund(ctx, req.OutRefundNo)
+		if innerErr != nil && innerErr != dao.ErrRecordNotFound {
+			err = status.Error(codes.Internal, "Failed to get refund.")
+			return
+		}
+		if refund != nil {
+			if refund.TradeId != req.TradeId || refund.AmountRefund != req.RefundAmount {
+				err = status.Error(codes.AlreadyExists, "out_refund_no is used by another refund.")
+				return
+			}
+			if refund.Status != dao.RefundStatusCreated && refund.Status != dao.RefundStatusCreateFailed {
+				resp = &proto_gens.CreateRefundResponse{Refund: toRefundInfo(refund)}
+				return
+			}
+		}
+	}
+
+	// 3. 校验可退金额, 重新申请失败的退款单同样需要校验, 然后创建数据库退款单记录
+	if refund == nil || refund.Status == dao.RefundStatusCreateFailed {
+		refunds, innerErr := impl.storage.ListRefunds(ctx, req.TradeId)
+		if innerErr != nil {
+			err = status.Error(codes.Internal, "Failed to list refunds.")
+			return
+		}
+		var refunding int64
+		for _, r := range refunds {
+			if r.Status != dao.RefundStatusCreateFailed && r.Status != dao.RefundStatusClosed {
+				refunding += r.AmountRefund
+			}
+		}
+		if refunding+req.RefundAmount > order.ItemAmountTotal {
+			err = status.Error(codes.FailedPrecondition, "Refund amount exceeds the refundable amount.")
+			return
+		}
+	}
+	if refund == nil {
+		outRefundNo := req.OutRefundNo
+		if len(outRefundNo) == 0 {
+			outRefundNo = MakeNewOutRefundNo(req.TradeId)
+		}
+		ct := time.Now().Unix()
+		refund = &dao.RefundModel{
+			MchId:        order.MchId,
+			TradeId:      req.TradeId,
+			OutRefundNo:  outRefundNo,
+			Reason:       req.Reason,
+			AmountTotal:  order.ItemAmountTotal,
+			AmountRefund: req.RefundAmount,
+			Status:       dao.RefundStatusCreated,
+			CreateTime:   ct,
+			UpdateTime:   ct,
+		}
+		if innerErr := impl.storage.AddRefund(ctx, refund); innerErr == dao.ErrDuplicateRecord {
+			err = status.Error(codes.AlreadyExists, "out_refund_no is used by another refund.")
+			return
+		} else if innerErr != nil {
+			_logger.WithError(innerErr).Error("Failed to invoke impl.storage.AddRefund.")
+			err = status.Error(codes.Internal, "Failed to create refund.")
+			return
+		}
+	}
+	_logger = _logger.WithField("out_refund_no", refund.OutRefundNo)
+
+	// 4. 请求申请退款接口, 更新数据库退款单记录
+	createReq := refunddomestic.CreateRequest{
+		OutTradeNo:  core.String(refund.TradeId),
+		OutRefundNo: core.String(refund.OutRefundNo),
+		NotifyUrl:   core.String(impl.confNotifyUrl),
+		Amount: &refunddomestic.AmountReq{
+			Refund:   core.Int64(refund.AmountRefund),
+			Total:    core.Int64(refund.AmountTotal),
+			Currency: core.String("CNY"),
+		},
+	}
+	if len(refund.Reason) > 0 {
+		createReq.Reason = core.String(refund.Reason)
+	}
+	var refundresp *refunddomestic.Refund
+	innerErr = retryWxCall(ctx, _logger, "RefundsApiService.Create", WxRetryableCodes, func() (err error) {
+		refundresp, _, err = impl.refundSvc.Create(ctx, createReq)
+		return
+	})
+	if innerErr != nil && (IsWxRetryableError(innerErr, WxRetryableCodes) || !IsWxAPIError(innerErr)) {
+		// 5. 网络错误或重试次数超限, 退款可能已被受理, 退款单保持已创建状态, 以查询结果为准
+		if refundresp, innerErr = impl.queryWxRefund(ctx, _logger, refund.OutRefundNo); innerErr != nil {
+			err = refundError(codes.Unavailable, "WX_CREATE_REFUND_UNKNOWN", refund.OutRefundNo)
+			return
+		}
+	} else if innerErr != nil {
+		// 5. 申请退款被拒绝, 如余额不足/订单已全额退款/交易时间超过一年等, 更新数据库退款单记录
+		_ = impl.storage.UpdateRefundStatus(ctx, refund.OutRefundNo, dao.RefundStatusCreateFailed)
+		err = refundError(codes.Internal, "WX_CREATE_REFUND_ERROR", refund.OutRefundNo)
+		return
+	}
+	// 5. 申请退款成功, 更新数据库退款单和平台订单记录, 已退款总额以退款结果通知为准
+	result := refundModelFromWx(impl.confMerchantId, refundresp)
+	_ = impl.storage.ApplyRefundResult(ctx, result)
+	if result.Status == dao.RefundStatusProcessing {
+		_ = impl.storage.ApplyPlatformOrderRefund(ctx, refund.TradeId, refund.OutRefundNo, dao.PaymentStatusRefundProcessing, 0)
+	}
+
+	result.Reason = refund.Reason
+	result.CreateTime = refund.CreateTime
+	result.UpdateTime = time.Now().Unix()
+	resp = &proto_gens.CreateRefundResponse{Refund: toRefundInfo(result)}
+	return
+}
+
+// 查询单笔退款, 并使用查询结果更新数据库退款单记录.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_10.shtml
+func (impl *WechatPaymentCallbackServiceImpl) QueryRefund(
+	ctx context.Context, req *proto_gens.QueryRefundRequest) (
+	resp *proto_gens.QueryRefundResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "QueryRefund").
+		WithField("out_refund_no", req.OutRefundNo)
+
+	// 参数校验
+	if len(req.OutRefundNo) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty out_refund_no")
+		return
+	}
+
+	refundresp, innerErr := impl.queryWxRefund(ctx, _logger, req.OutRefundNo)
+	if core.IsAPIError(innerErr, "RESOURCE_NOT_EXISTS") {
+		err = status.Error(codes.NotFound, "Refund not found.")
+		return
+	} else if innerErr != nil {
+		err = status.Error(codes.Internal, "WX_QUERY_REFUND_ERROR")
+		return
+	}
+
+	// 使用查询结果更新数据库退款单记录
+	if innerErr := impl.storage.ApplyRefundResult(ctx, refundModelFromWx(impl.confMerchantId, refundresp)); innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to update refund.")
+		return
+	}
+	refund, innerErr := impl.storage.GetRefund(ctx, req.OutRefundNo)
+	if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to get refund.")
+		return
+	}
+
+	resp = &proto_gens.QueryRefundResponse{Refund: toRefundInfo(refund)}
+	return
+}
+
+// 查询平台订单的全部退款单.
+func (impl *WechatPaymentCallbackServiceImpl) ListRefunds(
+	ctx context.Context, req *proto_gens.ListRefundsRequest) (
+	resp *proto_gens.ListRefundsResponse, err error) {
+
+	// 参数校验
+	if len(req.TradeId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id")
+		return
+	}
+
+	refunds, innerErr := impl.storage.ListRefunds(ctx, req.TradeId)
+	if innerErr != nil {
+		err = status.Error(codes.Internal, "Failed to list refunds.")
+		return
+	}
+
+	resp = &proto_gens.ListRefundsResponse{
+		Refunds: make([]*proto_gens.RefundInfo, 0, len(refunds)),
+	}
+	for _, refund := range refunds {
+		resp.Refunds = append(resp.Refunds, toRefundInfo(refund))
+	}
+	return
+}
+
+// queryWxRefund 按商户退款单号查询微信退款单, 系统错误/频率超限时至多重试三次.
+func (impl *WechatPaymentCallbackServiceImpl) queryWxRefund(
+	ctx context.Context, _logger *logrus.Entry, outRefundNo string) (
+	refund *refunddomestic.Refund, err error) {
+
+	err = retryWxCall(ctx, _logger, "RefundsApiService.QueryByOutRefundNo", WxRetryableCodes, func() (err error) {
+		refund, _, err = impl.refundSvc.QueryByOutRefundNo(
+			ctx,
+			refunddomestic.QueryByOutRefundNoRequest{
+				OutRefundNo: core.String(outRefundNo),
+			},
+		)
+		return
+	})
+	return
+}
+
+// refundError 返回在错误详情中附带商户退款单号的错误, 调用方使用该商户退款单号重试或查询退款结果.
+func refundError(code codes.Code, msg, outRefundNo string) error {
+	st := status.New(code, msg)
+	if detailed, err := st.WithDetails(&errdetails.ResourceInfo{
+		ResourceType: "refund",
+		ResourceName: outRefundNo,
+	}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// MakeNewOutRefundNo 生成商户退款单号, 格式为 "平台订单交易ID + R + 8位随机串".
+func MakeNewOutRefundNo(tradeId string) string {
+	return fmt.Sprintf("%sR%s", tradeId, GenerateNonce()[:8])
+}
+
+// RefundStatusFromWx 将微信退款状态转换为退款单状态.
+func RefundStatusFromWx(wxStatus string) int {
+	switch wxStatus {
+	case string(refunddomestic.STATUS_SUCCESS):
+		return dao.RefundStatusSuccess
+	case string(refunddomestic.STATUS_CLOSED):
+		return dao.RefundStatusClosed
+	case string(refunddomestic.STATUS_ABNORMAL):
+		return dao.RefundStatusAbnormal
+	default:
+		return dao.RefundStatusProcessing
+	}
+}
+
+func refundModelFromWx(mchId string, wxRefund *refunddomestic.Refund) *dao.RefundModel {
+	refund := &dao.RefundModel{
+		MchId:       mchId,
+		TradeId:     *(wxRefund.OutTradeNo),
+		OutRefundNo: *(wxRefund.OutRefundNo),
+	}
+	if wxRefund.TransactionId != nil {
+		refund.TransactionId = *(wxRefund.TransactionId)
+	}
+	if wxRefund.RefundId != nil {
+		refund.RefundId = *(wxRefund.RefundId)
+	}
+	if wxRefund.UserReceivedAccount != nil {
+		refund.UserReceivedAccount = *(wxRefund.UserReceivedAccount)
+	}
+	if wxRefund.Status != nil {
+		refund.WxRefundStatus = string(*(wxRefund.Status))
+	}
+	refund.Status = RefundStatusFromWx(refund.WxRefundStatus)
+	if wxRefund.SuccessTime != nil {
+		refund.SuccessTime = wxRefund.SuccessTime.Format(time.RFC3339)
+	}
+	if wxRefund.Amount != nil {
+		if wxRefund.Amount.Total != nil {
+			refund.AmountTotal = *(wxRefund.Amount.Total)
+		}
+		if wxRefund.Amount.Refund != nil {
+			refund.AmountRefund = *(wxRefund.Amount.Refund)
+		}
+	}
+	return refund
+}
+
+func toRefundInfo(refund *dao.RefundModel) *proto_gens.RefundInfo {
+	return &proto_gens.RefundInfo{
+		TradeId:             refund.TradeId,
+		OutRefundNo:         refund.OutRefundNo,
+		RefundId:            refund.RefundId,
+		Reason:              refund.Reason,
+		AmountTotal:         refund.AmountTotal,
+		AmountRefund:        refund.AmountRefund,
+		Status:              int32(refund.Status),
+		WxRefundStatus:      refund.WxRefundStatus,
+		UserReceivedAccount: refund.UserReceivedAccount,
+		SuccessTime:         refund.SuccessTime,
+		CreateTime:          refund.CreateTime,
+		UpdateTime:          refund.UpdateTime,
+	}
+}
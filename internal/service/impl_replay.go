@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+	eventpublisher "github.com/amazingchow/wechat-payment-callback-service/internal/service/event_publisher"
+)
+
+const (
+	ReplayPaymentEventsMaxTradeIds = 100
+)
+
+// 支付事件重放目标.
+const (
+	ReplaySinkWebhook = "webhook"
+	ReplaySinkRedis   = "redis"
+	ReplaySinkFile    = "file"
+)
+
+// 由 outbox_events 中的支付事件(支付成功/关闭/退款成功)重建支付事件并重放到指定目标, 重放的支付事件ID与原支付事件相同并带有重放标记.
+// 没有写入 outbox_events 的平台订单由当前的业务状态和最近的支付通知重建支付事件.
+// 按创建时间窗口重放时每次只处理一页平台订单, 调用方使用返回的next_cursor继续重放.
+func (impl *WechatPaymentCallbackServiceImpl) ReplayPaymentEvents(
+	ctx context.Context, req *proto_gens.ReplayPaymentEventsRequest) (
+	resp *proto_gens.ReplayPaymentEventsResponse, err error) {
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "ReplayPaymentEvents")
+
+	// 参数校验
+	if len(req.TradeIds) > ReplayPaymentEventsMaxTradeIds {
+		err = status.Error(codes.InvalidArgument, "Too many trade_ids")
+		return
+	}
+	for _, tradeId := range req.TradeIds {
+		if len(tradeId) == 0 {
+			err = status.Error(codes.InvalidArgument, "Empty trade_id")
+			return
+		}
+	}
+	if len(req.TradeIds) == 0 && (req.CreateTimeBegin <= 0 || req.CreateTimeEnd <= req.CreateTimeBegin) {
+		err = status.Error(codes.InvalidArgument, "Invalid create_time window")
+		return
+	}
+	if req.PageSize < 0 || req.PageSize > ListPlatformOrdersMaxPageSize {
+		err = status.Error(codes.InvalidArgument, "Invalid page_size")
+		return
+	}
+	pageSize := int64(req.PageSize)
+	if pageSize == 0 {
+		pageSize = ListPlatformOrdersDefaultPageSize
+	}
+	sink, err := newReplaySink(req.Sink, req.Target)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if innerErr := sink.Close(ctx); innerErr != nil {
+			_logger.WithError(innerErr).Error("Failed to close replay sink.")
+		}
+	}()
+
+	resp = &proto_gens.ReplayPaymentEventsResponse{}
+
+	// 1. 查询需要重放的平台订单
+	var orders []*dao.PlatformOrderModel
+	if len(req.TradeIds) > 0 {
+		orders = make([]*dao.PlatformOrderModel, 0, len(req.TradeIds))
+		for _, tradeId := range req.TradeIds {
+			order, innerErr := impl.storage.GetPlatformOrder(ctx, tradeId)
+			if innerErr == dao.ErrRecordNotFound {
+				resp.SkippedTradeIds = append(resp.SkippedTradeIds, tradeId)
+				continue
+			} else if innerErr != nil {
+				resp = nil
+				err = status.Error(codes.Internal, "Failed to get platform-order.")
+				return
+			}
+			orders = append(orders, order)
+		}
+	} else {
+		var innerErr error
+		orders, resp.NextCursor, innerErr = impl.storage.ListPlatformOrders(ctx, &dao.PlatformOrderFilter{
+			AppId: req.AppId,
+			StateList: []string{
+				dao.OrderStatePaid, dao.OrderStateRefunding, dao.OrderStateRefunded, dao.OrderStateClosed,
+			},
+			CreateTimeBegin: req.CreateTimeBegin,
+			CreateTimeEnd:   req.CreateTimeEnd,
+		}, req.Cursor, pageSize)
+		if innerErr == dao.ErrInvalidCursor {
+			resp = nil
+			err = status.Error(codes.InvalidArgument, "Invalid cursor")
+			return
+		} else if innerErr != nil {
+			resp = nil
+			err = status.Error(codes.Internal, "Failed to list platform-orders.")
+			return
+		}
+	}
+
+	// 2. 查询平台订单的支付事件, 以及平台订单最近的支付通知, 用于补齐支付结果
+	tradeIds := make([]string, 0, len(orders))
+	for _, order := range orders {
+		tradeIds = append(tradeIds, order.TradeId)
+	}
+	events, innerErr := impl.storage.GetOutboxEventsByTradeIds(ctx, tradeIds)
+	if innerErr != nil {
+		resp = nil
+		err = status.Error(codes.Internal, "Failed to get outbox-events.")
+		return
+	}
+	notifications, innerErr := impl.storage.GetLatestPaymentNotifications(ctx, tradeIds)
+	if innerErr != nil {
+		resp = nil
+		err = status.Error(codes.Internal, "Failed to get payment-notifications.")
+		return
+	}
+
+	// 3. 按写入顺序重放支付事件, 重放失败时由调用方使用相同参数重新重放
+	for _, order := range orders {
+		orderEvents := events[order.TradeId]
+		if len(orderEvents) == 0 {
+			orderEvents = newFallbackOutboxEvents(order)
+		}
+		if len(orderEvents) == 0 {
+			resp.SkippedTradeIds = append(resp.SkippedTradeIds, order.TradeId)
+			continue
+		}
+		for _, event := range orderEvents {
+			paymentEvent := newReplayPaymentEvent(event, notifications[order.TradeId])
+			if innerErr := sink.Replay(ctx, event, paymentEvent); innerErr != nil {
+				_logger.WithField("trade_id", order.TradeId).WithField("event_id", event.EventId).
+					WithError(innerErr).Error("Failed to replay payment-event.")
+				resp = nil
+				err = status.Error(codes.Internal, "Failed to replay payment-event.")
+				return
+			}
+			resp.Replayed += 1
+		}
+	}
+	_logger.Infof("Replayed %d payment-events to %s:%s.", resp.Replayed, req.Sink, req.Target)
+	return
+}
+
+// replaySink 支付事件重放目标.
+type replaySink interface {
+	// Replay 重放一条支付事件, paymentEvent 为由 event 重建并补齐支付结果的支付事件, 各重放目标使用相同的支付事件.
+	Replay(ctx context.Context, event *dao.OutboxEventModel, paymentEvent *proto_gens.PaymentEvent) error
+	// Close 释放重放目标持有的资源.
+	Close(ctx context.Context) error
+}
+
+// newReplaySink 按重放目标创建支付事件重放目标.
+// NOTE: 为避免管理接口被用于访问任意地址或写入任意路径, webhook只能是已配置的Webhook, 文件只能写入重放目录.
+func newReplaySink(sink, target string) (replaySink, error) {
+	if len(target) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Empty target")
+	}
+	conf := &(config.GetConfig().ServiceInternalConfig)
+	switch sink {
+	case ReplaySinkWebhook:
+		for i := range conf.Outbox.Webhooks {
+			if conf.Outbox.Webhooks[i].Url == target {
+				return &webhookReplaySink{webhook: &(conf.Outbox.Webhooks[i])}, nil
+			}
+		}
+		return nil, status.Error(codes.InvalidArgument, "Unknown webhook target")
+	case ReplaySinkRedis:
+		publisher, err := eventpublisher.NewEventPublisher(&config.EventPublisher{
+			Backend: eventpublisher.BackendRedis,
+			Redis: config.EventPublisherRedis{
+				Stream: target,
+				MaxLen: conf.EventPublisher.Redis.MaxLen,
+			},
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to create replay sink.")
+		}
+		return &publisherReplaySink{publisher: publisher}, nil
+	case ReplaySinkFile:
+		if target != filepath.Base(target) || target == "." || target == ".." {
+			return nil, status.Error(codes.InvalidArgument, "Invalid file target")
+		}
+		if len(conf.Replay.FileDir) == 0 {
+			return nil, status.Error(codes.FailedPrecondition, "Replay file_dir not configured")
+		}
+		if err := os.MkdirAll(conf.Replay.FileDir, 0755); err != nil {
+			return nil, status.Error(codes.Internal, "Failed to create replay sink.")
+		}
+		publisher, err := eventpublisher.NewJSONLinesPublisher(filepath.Join(conf.Replay.FileDir, target))
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to create replay sink.")
+		}
+		return &publisherReplaySink{publisher: publisher}, nil
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Invalid sink")
+	}
+}
+
+// webhookReplaySink 将支付事件投递到Webhook, 请求体与签名方式与支付事件投递相同, 请求体带有重放标记.
+type webhookReplaySink struct {
+	webhook *config.Webhook
+}
+
+func (s *webhookReplaySink) Replay(ctx context.Context, event *dao.OutboxEventModel, paymentEvent *proto_gens.PaymentEvent) error {
+	body, err := json.Marshal(newReplayWebhookPayload(event, paymentEvent))
+	if err != nil {
+		return err
+	}
+	return postWebhook(ctx, s.webhook, event.EventId, body, time.Now())
+}
+
+// newReplayWebhookPayload 生成重放到Webhook的请求体, 支付结果与发布到消息总线的重放支付事件一致.
+func newReplayWebhookPayload(event *dao.OutboxEventModel, paymentEvent *proto_gens.PaymentEvent) *WebhookPayload {
+	payload := newWebhookPayload(event)
+	payload.TransactionId = paymentEvent.TrxId
+	payload.PayerTotal = paymentEvent.PayerTotal
+	payload.SuccessTime = paymentEvent.SuccessTime
+	payload.Replay = paymentEvent.Replay
+	return payload
+}
+
+func (s *webhookReplaySink) Close(_ context.Context) error {
+	return nil
+}
+
+// publisherReplaySink 将支付事件发布到Redis Stream或写入文件, 消息格式与支付事件发布相同.
+type publisherReplaySink struct {
+	publisher eventpublisher.EventPublisher
+}
+
+func (s *publisherReplaySink) Replay(ctx context.Context, _ *dao.OutboxEventModel, paymentEvent *proto_gens.PaymentEvent) error {
+	return s.publisher.Publish(ctx, paymentEvent)
+}
+
+func (s *publisherReplaySink) Close(ctx context.Context) error {
+	return s.publisher.Close(ctx)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+const (
+	PaymentStatusChannelPrefix     = "wechat_payment_callback_service.payment_status.trade_id."
+	WatchPaymentStatusMaxDuration  = 30 * time.Minute
+	WatchPaymentStatusPollInterval = 30 * time.Second
+)
+
+// 平台订单状态变更消息, 订阅方收到后重新读取数据库订单记录.
+type paymentStatusChange struct {
+	TradeId string `msgpack:"trade_id"`
+	Status  int    `msgpack:"status"`
+}
+
+// paymentStatusPublishingStorage 在平台订单状态迁移成功后发布状态变更消息,
+// 支付通知处理、关单、退款以及后台任务推进平台订单状态时均会通知 WatchPaymentStatus 的订阅方.
+type paymentStatusPublishingStorage struct {
+	dao.PaymentInfoStorage
+}
+
+func newPaymentStatusPublishingStorage(storage dao.PaymentInfoStorage) dao.PaymentInfoStorage {
+	return &paymentStatusPublishingStorage{PaymentInfoStorage: storage}
+}
+
+func (s *paymentStatusPublishingStorage) UpdatePlatformOrder(ctx context.Context, tradeId string, status int) (
+	err error) {
+
+	if err = s.PaymentInfoStorage.UpdatePlatformOrder(ctx, tradeId, status); err == nil {
+		publishPaymentStatusChange(ctx, tradeId, status)
+	}
+	return
+}
+
+func (s *paymentStatusPublishingStorage) ApplyPlatformOrderRefund(ctx context.Context, tradeId, outRefundNo string, status int, refundedAmount int64) (
+	err error) {
+
+	if err = s.PaymentInfoStorage.ApplyPlatformOrderRefund(ctx, tradeId, outRefundNo, status, refundedAmount); err == nil {
+		publishPaymentStatusChange(ctx, tradeId, status)
+	}
+	return
+}
+
+// publishPaymentStatusChange 发布平台订单状态变更消息, 发布失败只记录日志, 订阅方会定期重新读取数据库订单记录兜底.
+func publishPaymentStatusChange(ctx context.Context, tradeId string, status int) {
+	if err := ext_redis.GetConnPool().GetBigCache().Publish(ctx, PaymentStatusChannelPrefix+tradeId,
+		&paymentStatusChange{TradeId: tradeId, Status: status}); err != nil {
+		logger.GetGlobalLogger().
+			WithField(common.LoggerKeyTraceId, common.TraceId(ctx)).
+			WithField(common.LoggerKeySpanId, common.SpanId(ctx)).
+			WithField("trade_id", tradeId).
+			WithError(err).Warn("Failed to publish payment-status change.")
+	}
+}
+
+// 订阅平台订单支付状态.
+// 首先返回平台订单的当前状态, 此后每次状态变更返回一次, 平台订单进入已支付/已关闭/退款中/已退款/下单失败后结束.
+// NOTE: 单次订阅最长持续 WatchPaymentStatusMaxDuration, 超时后返回 DeadlineExceeded, 调用端可重新订阅.
+func (impl *WechatPaymentCallbackServiceImpl) WatchPaymentStatus(
+	req *proto_gens.WatchPaymentStatusRequest, stream proto_gens.WechatPaymentCallbackService_WatchPaymentStatusServer) (
+	err error) {
+
+	ctx, cancel := context.WithTimeout(stream.Context(), WatchPaymentStatusMaxDuration)
+	defer cancel()
+
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, ctx.Value(common.ContextKeyTraceId).(string)).
+		WithField(common.LoggerKeySpanId, ctx.Value(common.ContextKeySpanId).(string)).
+		WithField(common.LoggerKeyEvent, "WatchPaymentStatus").
+		WithField("trade_id", req.TradeId)
+
+	// 参数校验
+	if len(req.TradeId) == 0 {
+		err = status.Error(codes.InvalidArgument, "Empty trade_id")
+		return
+	}
+
+	// 1. 先订阅再读取当前状态, 避免遗漏两者之间发生的状态变更
+	sub, innerErr := ext_redis.GetConnPool().GetBigCache().Subscribe(ctx, fmt.Sprintf("%s%s", PaymentStatusChannelPrefix, req.TradeId))
+	if innerErr != nil {
+		_logger.WithError(innerErr).Error("Failed to subscribe payment-status changes.")
+		err = status.Error(codes.Internal, "Failed to subscribe payment-status changes.")
+		return
+	}
+	defer func() {
+		_ = sub.Close()
+	}()
+
+	// 2. 返回当前状态, 此后每次收到状态变更消息或定期兜底时重新读取数据库订单记录, 版本号变化时返回
+	ticker := time.NewTicker(WatchPaymentStatusPollInterval)
+	defer ticker.Stop()
+	var version int64 = -1
+	for {
+		order, innerErr := impl.storage.GetPlatformOrder(ctx, req.TradeId)
+		if innerErr == dao.ErrRecordNotFound {
+			err = status.Error(codes.NotFound, "Platform-order not found.")
+			return
+		} else if innerErr != nil {
+			err = status.Error(codes.Internal, "Failed to get platform-order.")
+			return
+		}
+		if order.Version != version {
+			version = order.Version
+			if innerErr = stream.Send(&proto_gens.WatchPaymentStatusResponse{
+				TradeId:     order.TradeId,
+				State:       order.CurrentState(),
+				Status:      int32(order.Status),
+				Version:     order.Version,
+				TrxId:       order.TransactionId,
+				SuccessTime: order.SuccessTime,
+				UpdateTime:  order.UpdateTime,
+			}); innerErr != nil {
+				_logger.WithError(innerErr).Warn("Failed to send payment-status.")
+				err = innerErr
+				return
+			}
+		}
+		// 下单失败的平台订单没有微信预支付订单, 不会再发生支付, 同样结束订阅
+		if order.IsPaymentSettled() || order.CurrentState() == dao.OrderStateFailed {
+			return
+		}
+
+		select {
+		case _, ok := <-sub.Channel():
+			if !ok {
+				_logger.Warn("Payment-status subscription closed.")
+				err = status.Error(codes.Internal, "Failed to receive payment-status change.")
+				return
+			}
+		case <-ticker.C:
+		case <-ctx.Done():
+			err = status.FromContextError(ctx.Err()).Err()
+			return
+		}
+	}
+}
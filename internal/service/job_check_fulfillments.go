@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+)
+
+const FulfillmentRefundReason = "履约超时自动退款"
+
+// checkFulfillments 将超过期限仍未确认履约的平台订单标记为履约超时, 并按应用策略告警或自动退款.
+// 自动退款使用由平台订单交易ID确定的商户退款单号, 退款未受理时下一轮任务使用原商户退款单号重新申请, 不会重复退款.
+// 已部分退款的平台订单只退款剩余的金额.
+func (impl *WechatPaymentCallbackServiceImpl) checkFulfillments(
+	ctx context.Context, _logger *logrus.Entry, conf *config.Job) {
+
+	// 1. 标记履约超时, 告警通过错误日志(Sentry)发出
+	orders, err := impl.storage.ListFulfillmentOverduePlatformOrders(ctx, time.Now().Unix(), conf.BatchSize)
+	if err != nil {
+		return
+	}
+	for _, order := range orders {
+		if ctx.Err() != nil {
+			return
+		}
+		policy := impl.fulfillmentOverduePolicyOf(order.AppId)
+		if err := impl.storage.MarkPlatformOrderFulfillmentOverdue(ctx, order.TradeId, policy); err != nil {
+			// 已被确认履约或已全额退款
+			continue
+		}
+		_logger.WithField("trade_id", order.TradeId).
+			Errorf("Platform-order fulfillment is overdue (deadline:%d), policy:%s.",
+				order.FulfillmentDeadline, policy)
+	}
+
+	// 2. 对按自动退款策略处理的平台订单申请退款剩余的可退金额
+	orders, err = impl.storage.ListFulfillmentUnrefundedPlatformOrders(ctx, conf.BatchSize)
+	if err != nil {
+		return
+	}
+	for _, order := range orders {
+		if ctx.Err() != nil {
+			return
+		}
+		impl.refundOverdueOrder(ctx, _logger.WithField("trade_id", order.TradeId), order)
+	}
+}
+
+func (impl *WechatPaymentCallbackServiceImpl) refundOverdueOrder(
+	ctx context.Context, _logger *logrus.Entry, order *dao.PlatformOrderModel) {
+
+	if !order.IsFulfillmentRefundable() {
+		return
+	}
+	// 自动退款的金额在申请退款时确定, 扣除标记履约超时后发生的退款
+	refunds, err := impl.storage.ListRefunds(ctx, order.TradeId)
+	if err != nil {
+		return
+	}
+	outRefundNo := MakeFulfillmentOutRefundNo(order.TradeId)
+	amount := fulfillmentRefundAmount(order, refunds, outRefundNo)
+	if amount <= 0 {
+		// 剩余金额均在退款中, 下一轮任务按退款结果重新计算
+		_logger.Infof("No refundable amount left for fulfillment-overdue platform-order, refunded_amount_total:%d.",
+			order.RefundedAmountTotal)
+		return
+	}
+	if _, err := impl.CreateRefund(ctx, &proto_gens.CreateRefundRequest{
+		TradeId:      order.TradeId,
+		RefundAmount: amount,
+		OutRefundNo:  outRefundNo,
+		Reason:       FulfillmentRefundReason,
+	}); err != nil {
+		if isRetryableRefundError(err) {
+			_logger.WithError(err).Warn("Failed to refund fulfillment-overdue platform-order, retry next round.")
+			return
+		}
+		// 不可重试的错误(如可退款金额不足、微信支付拒绝退款)需要人工处理, 不再自动退款
+		_logger.WithError(err).Error("Failed to refund fulfillment-overdue platform-order, give up.")
+		_ = impl.storage.SavePlatformOrderFulfillmentRefundError(ctx, order.TradeId, err.Error())
+		return
+	}
+	_ = impl.storage.SavePlatformOrderFulfillmentRefund(ctx, order.TradeId, outRefundNo)
+	_logger.Warnf("Refunded fulfillment-overdue platform-order, out_refund_no:%s.", outRefundNo)
+}
+
+// fulfillmentRefundAmount 返回履约超时自动退款的金额, 即订单金额扣除已成功及处理中的退款后剩余的可退金额.
+// 使用原商户退款单号重试时沿用原退款单的金额, 否则申请退款会因金额不一致被拒绝.
+func fulfillmentRefundAmount(order *dao.PlatformOrderModel, refunds []*dao.RefundModel, outRefundNo string) int64 {
+	amount := order.ItemAmountTotal
+	for _, r := range refunds {
+		if r.OutRefundNo == outRefundNo {
+			return r.AmountRefund
+		}
+		if r.Status != dao.RefundStatusCreateFailed && r.Status != dao.RefundStatusClosed {
+			amount -= r.AmountRefund
+		}
+	}
+	return amount
+}
+
+// isRetryableRefundError 返回申请退款的错误是否可以使用原商户退款单号重试.
+// 微信支付退款结果未知、平台订单正在退款以及数据库错误可以重试, 参数/状态错误及微信支付明确拒绝的退款不可重试.
+func isRetryableRefundError(err error) bool {
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.Unavailable, codes.Aborted:
+		return true
+	case codes.Internal:
+		return st.Message() != "WX_CREATE_REFUND_ERROR"
+	}
+	return false
+}
+
+// fulfillmentOverduePolicyOf 返回应用的履约超时策略, 未配置的应用默认告警.
+func (impl *WechatPaymentCallbackServiceImpl) fulfillmentOverduePolicyOf(appId string) string {
+	if policy, ok := impl.confFulfillmentOverduePolicyTable[appId]; ok {
+		return policy
+	}
+	return dao.FulfillmentOverduePolicyAlert
+}
+
+// MakeFulfillmentOutRefundNo 生成履约超时自动退款的商户退款单号, 格式为 "平台订单交易ID + RF".
+// 同一平台订单的自动退款总是使用相同的商户退款单号, 重复申请只退一笔.
+func MakeFulfillmentOutRefundNo(tradeId string) string {
+	return fmt.Sprintf("%sRF", tradeId)
+}
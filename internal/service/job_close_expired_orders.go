@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
+)
+
+// closeExpiredOrders 关闭已过期的未支付平台订单.
+// NOTE: 关单前先查询微信支付订单, 避免关闭已支付但支付通知尚未到达的订单.
+// 每次检查后推迟该平台订单的下次检查时间, 关单失败的平台订单按指数退避重试, 不会阻塞其他平台订单.
+func (impl *WechatPaymentCallbackServiceImpl) closeExpiredOrders(
+	ctx context.Context, _logger *logrus.Entry, conf *config.Job) {
+
+	orders, err := impl.storage.ListExpiredPlatformOrders(ctx, time.Now().Unix(), conf.BatchSize)
+	if err != nil {
+		return
+	}
+	interval := time.Duration(conf.IntervalInSecond) * time.Second
+	for _, order := range orders {
+		if ctx.Err() != nil {
+			return
+		}
+		_ = impl.storage.DeferPlatformOrderCheck(ctx, order.TradeId,
+			time.Now().Add(JobCheckBackoff(interval, order.CheckAttempts)).Unix())
+		impl.closeExpiredOrder(ctx, _logger.WithField("trade_id", order.TradeId), order)
+	}
+}
+
+func (impl *WechatPaymentCallbackServiceImpl) closeExpiredOrder(
+	ctx context.Context, _logger *logrus.Entry, order *dao.PlatformOrderModel) {
+
+	// 1. 与支付通知处理共用同一把锁, 避免关单与支付通知并发修改同一笔平台订单
+	lock, err := ext_redis.GetConnPool().GetBigCache().TryLock(
+		ctx, fmt.Sprintf("%s%s", NotifyLockKeyPrefix, order.TradeId), NotifyLockTTL)
+	if err != nil {
+		_logger.WithError(err).Debug("Failed to lock platform-order, retry next round.")
+		return
+	}
+	defer func() {
+		_ = lock.Unlock(context.Background())
+	}()
+
+	// 2. 查询微信支付订单
+	transaction, err := impl.queryWxOrder(ctx, _logger, order.TradeId)
+	if err == ErrWxOrderNotExist {
+		// 微信预支付订单未创建成功, 直接关闭平台订单
+		_ = impl.storage.UpdatePlatformOrder(withWxErrorCode(ctx, err), order.TradeId, dao.PaymentStatusClosePlatformOrder)
+		return
+	} else if err != nil {
+		return
+	}
+
+	// 3. 仍未支付则关闭微信支付订单, 更新数据库订单记录
+	tradeState := ""
+	if transaction.TradeState != nil {
+		tradeState = *(transaction.TradeState)
+	}
+	switch tradeState {
+	case TradeStateSuccess, TradeStateRefund:
+		// 已支付但未收到支付通知, 不能关单, 由补偿任务 compensateOrders 补齐支付通知
+		_logger.Warnf("Expired platform-order was paid (trade_state:%s), skip closing.", tradeState)
+	case TradeStateClosed, TradeStateRevoked:
+		_ = impl.storage.UpdatePlatformOrder(ctx, order.TradeId, dao.PaymentStatusClosePlatformOrder)
+	default:
+		if err := impl.closeWxOrder(ctx, _logger, order.TradeId); err != nil {
+			_ = impl.storage.UpdatePlatformOrder(withWxErrorCode(ctx, err), order.TradeId, dao.PaymentStatusClosePlatformOrderFailed)
+			return
+		}
+		_ = impl.storage.UpdatePlatformOrder(ctx, order.TradeId, dao.PaymentStatusClosePlatformOrder)
+		_logger.Info("Closed expired platform-order.")
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
+)
+
+// compensateOrders 对长时间停留在中间状态的平台订单主动查询支付结果, 补偿丢失的支付通知,
+// 并修复因服务异常而只写了一半的平台订单状态.
+// 每次检查后推迟该平台订单的下次检查时间, 无法补偿的平台订单按指数退避重试, 不会阻塞其他平台订单.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_2.shtml
+func (impl *WechatPaymentCallbackServiceImpl) compensateOrders(
+	ctx context.Context, _logger *logrus.Entry, conf *config.Job) {
+
+	updateBefore := time.Now().Add(-time.Duration(conf.DelayInSecond) * time.Second).Unix()
+	orders, err := impl.storage.ListStuckPlatformOrders(ctx, updateBefore, conf.BatchSize)
+	if err != nil {
+		return
+	}
+	interval := time.Duration(conf.IntervalInSecond) * time.Second
+	for _, order := range orders {
+		if ctx.Err() != nil {
+			return
+		}
+		_ = impl.storage.DeferPlatformOrderCheck(ctx, order.TradeId,
+			time.Now().Add(JobCheckBackoff(interval, order.CheckAttempts)).Unix())
+		impl.compensateOrder(ctx, _logger.WithField("trade_id", order.TradeId), order)
+	}
+}
+
+func (impl *WechatPaymentCallbackServiceImpl) compensateOrder(
+	ctx context.Context, _logger *logrus.Entry, order *dao.PlatformOrderModel) {
+
+	// 1. 与支付通知处理共用同一把锁, 避免补偿与支付通知并发修改同一笔平台订单
+	lock, err := ext_redis.GetConnPool().GetBigCache().TryLock(
+		ctx, fmt.Sprintf("%s%s", NotifyLockKeyPrefix, order.TradeId), NotifyLockTTL)
+	if err != nil {
+		_logger.WithError(err).Debug("Failed to lock platform-order, retry next round.")
+		return
+	}
+	defer func() {
+		_ = lock.Unlock(context.Background())
+	}()
+
+	// 2. 查询微信支付订单
+	transaction, err := impl.queryWxOrder(ctx, _logger, order.TradeId)
+	if err == ErrWxOrderNotExist {
+		if order.Status == dao.PaymentStatusCreatePlatformOrder || order.Status == dao.PaymentStatusCreateWXPrepayOrder {
+			// 请求下单接口前后服务异常, 微信预支付订单未创建成功, 修复为下单失败, 过期后由关单任务关闭
+			_ = impl.storage.UpdatePlatformOrder(withWxErrorCode(ctx, err), order.TradeId, dao.PaymentStatusCreateWXPrepayOrderFailed)
+		} else {
+			_logger.Warnf("Wechat order not exist for platform-order(status:%d).", order.Status)
+		}
+		return
+	} else if err != nil {
+		return
+	}
+
+	// 3. 按支付结果推进平台订单状态
+	impl.syncPlatformOrder(ctx, _logger, order, transaction)
+}
+
+// syncPlatformOrder 按微信支付订单的交易状态推进平台订单状态, 调用方需持有支付通知处理锁.
+func (impl *WechatPaymentCallbackServiceImpl) syncPlatformOrder(
+	ctx context.Context, _logger *logrus.Entry, order *dao.PlatformOrderModel, transaction *payments.Transaction) {
+
+	tradeState := ""
+	if transaction.TradeState != nil {
+		tradeState = *(transaction.TradeState)
+	}
+	switch tradeState {
+	case TradeStateSuccess, TradeStateRefund:
+		if state := order.CurrentState(); state == dao.OrderStatePaid ||
+			state == dao.OrderStateRefunding || state == dao.OrderStateRefunded {
+			// 平台订单已支付, 只补齐早期订单记录缺失的支付结果
+			if len(order.TransactionId) == 0 && transaction.TransactionId != nil {
+				successTime := ""
+				if transaction.SuccessTime != nil {
+					successTime = *(transaction.SuccessTime)
+				}
+				_ = impl.storage.SavePlatformOrderTransaction(ctx, order.TradeId, *(transaction.TransactionId), successTime)
+			}
+			return
+		}
+		impl.compensateTransaction(ctx, _logger, transaction)
+	case TradeStateClosed, TradeStateRevoked:
+		if order.CurrentState() != dao.OrderStateClosed {
+			_ = impl.storage.UpdatePlatformOrder(ctx, order.TradeId, dao.PaymentStatusClosePlatformOrder)
+		}
+	default:
+		if order.Status == dao.PaymentStatusCreatePlatformOrder || order.Status == dao.PaymentStatusCreateWXPrepayOrder {
+			// 微信预支付订单已创建成功, 但未来得及更新数据库订单记录
+			_ = impl.storage.UpdatePlatformOrder(ctx, order.TradeId, dao.PaymentStatusGetPrepayId)
+		}
+	}
+}
+
+// compensateTransaction 将主动查询得到的支付结果视同一条支付通知进行处理, 合成的支付通知标记为 source=query.
+func (impl *WechatPaymentCallbackServiceImpl) compensateTransaction(
+	ctx context.Context, _logger *logrus.Entry, transaction *payments.Transaction) {
+
+	resource, plaintext, err := NotificationResourceFromTransaction(transaction)
+	if err != nil {
+		_logger.WithError(err).Error("Failed to invoke NotificationResourceFromTransaction.")
+		return
+	}
+
+	// 1. 支付通知已经保存, 只修复平台订单状态
+	existed, err := impl.storage.HasPaymentNotification(ctx, "", resource.TransactionId)
+	if err != nil {
+		return
+	}
+	if existed {
+		_ = impl.storage.SavePlatformOrderTransaction(ctx, resource.OutTradeNo, resource.TransactionId, resource.SuccessTime)
+		_ = impl.storage.UpdatePlatformOrder(ctx, resource.OutTradeNo, dao.PaymentStatusAckAsyncNotification)
+		return
+	}
+
+	// 2. 校验支付结果是否与平台订单一致
+	notification := &AsyncNotificationFromWeChatPay{
+		CreateTime: time.Now().Format(time.RFC3339),
+		EventType:  EventTypeTransactionSuccess,
+		Summary:    "支付成功",
+	}
+	passed, err := impl.crossCheckNotification(ctx, notification, resource, plaintext)
+	if err != nil || !passed {
+		return
+	}
+
+	// 3. 持久化合成的支付通知并推进平台订单状态
+	duplicated, err := impl.storeTransactionResult(ctx, _logger, &dao.PaymentNotificationModel{
+		CreateTime:                  notification.CreateTime,
+		EventType:                   notification.EventType,
+		ResourceAppId:               resource.AppId,
+		ResourceMchId:               resource.MchId,
+		TradeId:                     resource.OutTradeNo,
+		ResourceTransactionId:       resource.TransactionId,
+		ResourceTradeType:           resource.TradeType,
+		ResourceTradeState:          resource.TradeState,
+		ResourceTradeStateDesc:      resource.TradeStateDesc,
+		ResourceBankType:            resource.BankType,
+		ResourceSuccessTime:         resource.SuccessTime,
+		ResourcePayerOpenId:         resource.Payer.OpenId,
+		ResourceAmountTotal:         resource.Amount.Total,
+		ResourceAmountPayerTotal:    resource.Amount.PayerTotal,
+		ResourceAmountCurrency:      resource.Amount.Currency,
+		ResourceAmountPayerCurrency: resource.Amount.PayerCurrency,
+		ResourceAttach:              resource.Attach,
+		Summary:                     notification.Summary,
+		Source:                      dao.NotificationSourceQuery,
+	})
+	if err != nil {
+		// 下一轮补偿重新查询
+		_logger.WithError(err).Warn("Failed to store compensated transaction result.")
+		return
+	}
+	if duplicated {
+		_ = impl.storage.UpdatePlatformOrder(ctx, resource.OutTradeNo, dao.PaymentStatusAckAsyncNotification)
+		return
+	}
+	_logger.Warn("Compensated missing AsyncNotificationFromWeChatPay by querying wechat order.")
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/utils/httptools"
+)
+
+const (
+	OutboxDefaultMaxAttempts = 8
+	OutboxDefaultBackoff     = 10 * time.Second
+	OutboxDefaultMaxBackoff  = time.Hour
+	WebhookDefaultTimeout    = 5 * time.Second
+	// 发布到消息总线时使用的投递名称, 与Webhook的投递状态一同记录
+	EventPublisherDeliveryName = "event_publisher"
+)
+
+// Webhook请求头, 接收方使用约定的密钥校验签名.
+const (
+	WebhookHeaderEventId   = "X-Payment-Event-Id"
+	WebhookHeaderTimestamp = "X-Payment-Event-Timestamp"
+	WebhookHeaderSignature = "X-Payment-Event-Signature"
+)
+
+// SignWebhookPayload 按 "时间戳\n请求体\n" 构造签名串, 计算HMAC-SHA256签名并以十六进制编码.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("\n"))
+	mac.Write(body)
+	mac.Write([]byte("\n"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookBackoff 返回第 attempts 次投递失败后的重试间隔, 按指数增长且不超过 maxBackoff.
+func WebhookBackoff(attempts int, backoff, maxBackoff time.Duration) time.Duration {
+	d := backoff
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// Webhook请求体.
+type WebhookPayload struct {
+	EventId             string            `json:"event_id"`
+	EventType           string            `json:"event_type"`
+	AppId               string            `json:"app_id"`
+	TradeId             string            `json:"trade_id"`
+	PayerUid            string            `json:"payer_uid"`
+	TransactionId       string            `json:"transaction_id"`
+	ItemAmountTotal     int64             `json:"item_amount_total"`
+	RefundedAmountTotal int64             `json:"refunded_amount_total"`
+	Metadata            map[string]string `json:"metadata,omitempty"`
+	State               string            `json:"state"`
+	Status              int               `json:"status"`
+	Version             int64             `json:"version"`
+	CreateTime          int64             `json:"create_time"`
+	PayerTotal          int64             `json:"payer_total,omitempty"`  // 用户支付金额, 重放支付成功事件时补齐
+	SuccessTime         string            `json:"success_time,omitempty"` // 支付完成时间, 重放支付成功事件时补齐
+	Replay              bool              `json:"replay,omitempty"`       // 是否为重放的支付事件
+}
+
+// outboxTarget 支付事件的投递目标, Webhook或消息总线.
+type outboxTarget struct {
+	name    string
+	url     string
+	deliver func(ctx context.Context, event *dao.OutboxEventModel, body []byte, now time.Time) error
+}
+
+// dispatchOutboxEvents 将到达投递时间的支付事件投递到全部已配置的Webhook, 并发布到已配置的消息总线.
+// 投递失败时按指数退避重试, 同一目标连续失败 max_attempts 次后不再投递(死信), 可通过 ListOutboxEvents 接口查看.
+func (impl *WechatPaymentCallbackServiceImpl) dispatchOutboxEvents(
+	ctx context.Context, _logger *logrus.Entry, conf *config.Job) {
+
+	targets := impl.outboxTargets()
+	if len(targets) == 0 {
+		_logger.Warn("No webhook or event publisher configured, skip dispatching outbox-events.")
+		return
+	}
+	events, err := impl.storage.ListDueOutboxEvents(ctx, time.Now().Unix(), conf.BatchSize)
+	if err != nil {
+		return
+	}
+	outbox := &(config.GetConfig().ServiceInternalConfig.Outbox)
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return
+		}
+		impl.dispatchOutboxEvent(ctx, _logger.WithField("event_id", event.EventId).WithField("trade_id", event.TradeId),
+			outbox, targets, event)
+	}
+}
+
+// outboxTargets 返回支付事件的投递目标, 已配置消息总线时首先发布到消息总线.
+func (impl *WechatPaymentCallbackServiceImpl) outboxTargets() []*outboxTarget {
+	conf := &(config.GetConfig().ServiceInternalConfig)
+	targets := make([]*outboxTarget, 0, len(conf.Outbox.Webhooks)+1)
+	if len(conf.EventPublisher.Backend) > 0 {
+		targets = append(targets, &outboxTarget{
+			name:    EventPublisherDeliveryName,
+			url:     conf.EventPublisher.Backend,
+			deliver: impl.publishOutboxEvent,
+		})
+	}
+	for i := range conf.Outbox.Webhooks {
+		webhook := &(conf.Outbox.Webhooks[i])
+		targets = append(targets, &outboxTarget{
+			name: webhook.Name,
+			url:  webhook.Url,
+			deliver: func(ctx context.Context, event *dao.OutboxEventModel, body []byte, now time.Time) error {
+				return postWebhook(ctx, webhook, event.EventId, body, now)
+			},
+		})
+	}
+	return targets
+}
+
+// publishOutboxEvent 将支付事件发布到消息总线, 支付成功事件使用平台订单最近的支付通知补齐支付结果.
+func (impl *WechatPaymentCallbackServiceImpl) publishOutboxEvent(
+	ctx context.Context, event *dao.OutboxEventModel, _ []byte, _ time.Time) error {
+
+	var notification *dao.PaymentNotificationModel
+	if event.EventType == dao.OutboxEventTypePaymentSucceeded {
+		notifications, err := impl.storage.GetLatestPaymentNotifications(ctx, []string{event.TradeId})
+		if err != nil {
+			return err
+		}
+		notification = notifications[event.TradeId]
+	}
+	return impl.eventPublisher.Publish(ctx, newOutboxPaymentEvent(event, notification))
+}
+
+func (impl *WechatPaymentCallbackServiceImpl) dispatchOutboxEvent(
+	ctx context.Context, _logger *logrus.Entry, outbox *config.Outbox, targets []*outboxTarget, event *dao.OutboxEventModel) {
+
+	maxAttempts := outbox.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = OutboxDefaultMaxAttempts
+	}
+	backoff := time.Duration(outbox.BackoffInSecond) * time.Second
+	if backoff <= 0 {
+		backoff = OutboxDefaultBackoff
+	}
+	maxBackoff := time.Duration(outbox.MaxBackoffInSecond) * time.Second
+	if maxBackoff <= 0 {
+		maxBackoff = OutboxDefaultMaxBackoff
+	}
+
+	body, err := json.Marshal(newWebhookPayload(event))
+	if err != nil {
+		_logger.WithError(err).Error("Failed to marshal WebhookPayload.")
+		return
+	}
+
+	// 1. 依次投递到尚未投递成功的目标
+	now := time.Now()
+	deliveries := make([]*dao.WebhookDeliveryModel, 0, len(targets))
+	for _, target := range targets {
+		delivery := findWebhookDelivery(event, target.name)
+		if delivery == nil {
+			delivery = &dao.WebhookDeliveryModel{Name: target.name, Status: dao.DeliveryStatusPending}
+			event.Deliveries = append(event.Deliveries, delivery)
+		}
+		deliveries = append(deliveries, delivery)
+		if delivery.Status != dao.DeliveryStatusPending {
+			continue
+		}
+
+		delivery.Url = target.url
+		delivery.Attempts += 1
+		delivery.LastAttemptTime = now.Unix()
+		if err := target.deliver(ctx, event, body, now); err != nil {
+			delivery.LastError = err.Error()
+			if delivery.Attempts >= maxAttempts {
+				delivery.Status = dao.DeliveryStatusDead
+				_logger.WithError(err).Errorf("Failed to deliver outbox-event to %s, move to dead-letter.", target.name)
+			} else {
+				_logger.WithError(err).Warnf("Failed to deliver outbox-event to %s, attempts:%d.", target.name, delivery.Attempts)
+			}
+			continue
+		}
+		delivery.Status = dao.DeliveryStatusDelivered
+		delivery.LastError = ""
+		delivery.DeliveredTime = time.Now().Unix()
+	}
+
+	// 2. 汇总支付事件的投递状态, 仍有待投递的Webhook时按最少的失败次数计算下一次投递时间
+	event.DeliveryStatus = dao.DeliveryStatusDelivered
+	minAttempts := 0
+	for _, delivery := range deliveries {
+		switch delivery.Status {
+		case dao.DeliveryStatusPending:
+			if event.DeliveryStatus != dao.DeliveryStatusPending || delivery.Attempts < minAttempts {
+				minAttempts = delivery.Attempts
+			}
+			event.DeliveryStatus = dao.DeliveryStatusPending
+		case dao.DeliveryStatusDead:
+			if event.DeliveryStatus == dao.DeliveryStatusDelivered {
+				event.DeliveryStatus = dao.DeliveryStatusDead
+			}
+		}
+	}
+	if event.DeliveryStatus == dao.DeliveryStatusPending {
+		event.NextAttemptTime = now.Add(WebhookBackoff(minAttempts, backoff, maxBackoff)).Unix()
+	}
+	_ = impl.storage.UpdateOutboxEventDelivery(ctx, event)
+}
+
+func newWebhookPayload(event *dao.OutboxEventModel) *WebhookPayload {
+	return &WebhookPayload{
+		EventId:             event.EventId,
+		EventType:           event.EventType,
+		AppId:               event.AppId,
+		TradeId:             event.TradeId,
+		PayerUid:            event.PayerUid,
+		TransactionId:       event.TransactionId,
+		ItemAmountTotal:     event.ItemAmountTotal,
+		RefundedAmountTotal: event.RefundedAmountTotal,
+		Metadata:            event.Metadata,
+		State:               event.State,
+		Status:              event.Status,
+		Version:             event.Version,
+		CreateTime:          event.CreateTime,
+	}
+}
+
+func findWebhookDelivery(event *dao.OutboxEventModel, name string) *dao.WebhookDeliveryModel {
+	for _, delivery := range event.Deliveries {
+		if delivery.Name == name {
+			return delivery
+		}
+	}
+	return nil
+}
+
+// postWebhook 投递一次支付事件, 响应状态码为2xx时视为投递成功.
+func postWebhook(ctx context.Context, webhook *config.Webhook, eventId string, body []byte, now time.Time) error {
+	timeout := time.Duration(webhook.TimeoutInSecond) * time.Second
+	if timeout <= 0 {
+		timeout = WebhookDefaultTimeout
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(httptools.ContentType, httptools.JsonContentType)
+	req.Header.Set(WebhookHeaderEventId, eventId)
+	req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(webhook.Secret, now.Unix(), body))
+
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+const (
+	JobLeaseKeyPrefix     = "wechat_payment_callback_service.job.lease."
+	JobDefaultInterval    = 60 * time.Second
+	JobDefaultBatchSize   = 100
+	JobDefaultDelay       = 300 * time.Second
+	JobMaxCheckBackoff    = time.Hour
+	JobCloseExpiredOrders = "close_expired_orders"
+	JobCompensateOrders   = "compensate_orders"
+	JobDispatchOutbox     = "dispatch_outbox_events"
+	JobCheckFulfillments  = "check_fulfillments"
+)
+
+type jobFunc func(ctx context.Context, _logger *logrus.Entry, conf *config.Job)
+
+// JobCheckBackoff 返回后台任务第 attempts+1 次检查同一平台订单后的推迟时长, 以任务间隔为起点指数增长且不超过 JobMaxCheckBackoff.
+func JobCheckBackoff(interval time.Duration, attempts int64) time.Duration {
+	d := interval
+	for i := int64(0); i < attempts && d < JobMaxCheckBackoff; i++ {
+		d *= 2
+	}
+	if d > JobMaxCheckBackoff {
+		d = JobMaxCheckBackoff
+	}
+	return d
+}
+
+// RunBackgroundJobs 启动全部已开启的后台定时任务, 直到stopCh被关闭.
+func (impl *WechatPaymentCallbackServiceImpl) RunBackgroundJobs(stopCh chan struct{}) {
+	conf := &(config.GetConfig().ServiceInternalConfig)
+
+	wg := &sync.WaitGroup{}
+	if conf.CloseExpiredOrdersJob.Enable {
+		wg.Add(1)
+		go impl.runPeriodicJob(wg, stopCh, JobCloseExpiredOrders, conf.CloseExpiredOrdersJob, impl.closeExpiredOrders)
+	}
+	if conf.CompensateOrdersJob.Enable {
+		wg.Add(1)
+		go impl.runPeriodicJob(wg, stopCh, JobCompensateOrders, conf.CompensateOrdersJob, impl.compensateOrders)
+	}
+	if conf.Outbox.Enable && conf.Outbox.Dispatcher.Enable {
+		wg.Add(1)
+		go impl.runPeriodicJob(wg, stopCh, JobDispatchOutbox, conf.Outbox.Dispatcher, impl.dispatchOutboxEvents)
+	}
+	if conf.Fulfillment.Enable && conf.Fulfillment.Checker.Enable {
+		wg.Add(1)
+		go impl.runPeriodicJob(wg, stopCh, JobCheckFulfillments, conf.Fulfillment.Checker, impl.checkFulfillments)
+	}
+	wg.Wait()
+}
+
+func (impl *WechatPaymentCallbackServiceImpl) runPeriodicJob(
+	wg *sync.WaitGroup, stopCh chan struct{}, name string, conf config.Job, fn jobFunc) {
+	defer wg.Done()
+
+	if conf.IntervalInSecond <= 0 {
+		conf.IntervalInSecond = int64(JobDefaultInterval.Seconds())
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = JobDefaultBatchSize
+	}
+	if conf.DelayInSecond <= 0 {
+		conf.DelayInSecond = int64(JobDefaultDelay.Seconds())
+	}
+	interval := time.Duration(conf.IntervalInSecond) * time.Second
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			impl.runJobOnce(stopCh, name, &conf, fn)
+		}
+	}
+}
+
+// runJobOnce 持有Redis租约执行一轮任务, 保证多副本部署时同一时刻只有一个副本在执行同一任务.
+// 租约时长为任务间隔的两倍, 执行期间定期续约, 续约失败(租约已被他人持有)时立即中止本轮任务.
+func (impl *WechatPaymentCallbackServiceImpl) runJobOnce(
+	stopCh chan struct{}, name string, conf *config.Job, fn jobFunc) {
+
+	ctx, cancel := context.WithCancel(common.NewContextWithEventSource(common.NewContextWithProvidedTraceIdAndSpanId(
+		context.Background(), uuid.New().String(), uuid.New().String()), common.EventSourceJob))
+	defer cancel()
+	_logger := logger.GetGlobalLogger().
+		WithField(common.LoggerKeyTraceId, common.TraceId(ctx)).
+		WithField(common.LoggerKeySpanId, common.SpanId(ctx)).
+		WithField(common.LoggerKeyEvent, "Job").
+		WithField("job", name)
+
+	leaseTTL := 2 * time.Duration(conf.IntervalInSecond) * time.Second
+	lease, err := ext_redis.GetConnPool().GetBigCache().TryLock(ctx, JobLeaseKeyPrefix+name, leaseTTL)
+	if err == ext_redis.ErrLockNotAcquired {
+		_logger.Debug("Job lease is held by another replica, skip this round.")
+		return
+	} else if err != nil {
+		_logger.WithError(err).Warn("Failed to acquire job lease.")
+		return
+	}
+	defer func() {
+		_ = lease.Unlock(context.Background())
+	}()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(leaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-stopCh:
+				cancel()
+				return
+			case <-ticker.C:
+				if err := lease.Refresh(ctx, leaseTTL); err != nil {
+					_logger.WithError(err).Warn("Failed to refresh job lease, abort this round.")
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	st := time.Now()
+	fn(ctx, _logger, conf)
+	_logger.Debugf("Job finished, took %v.", time.Since(st))
+}
@@ -13,7 +13,7 @@ func init() {
 	if !ok {
 		panic("_RoundTripper is not an *http.Transport")
 	}
-	_HttpTransport := *_HttpTransportPtr
+	_HttpTransport := _HttpTransportPtr.Clone()
 	// In case of "Thousands of connections in the TIME_WAIT state,
 	// eventually, service will run out of ephemeral ports and
 	// not be able to open new client connections."
@@ -21,6 +21,6 @@ func init() {
 	_HttpTransport.MaxIdleConnsPerHost = 32
 	_HttpCli = &http.Client{
 		Timeout:   time.Duration(10) * time.Second,
-		Transport: &_HttpTransport,
+		Transport: _HttpTransport,
 	}
 }
@@ -64,11 +64,7 @@ func UploadShoppingInfo(ctx context.Context, params *UploadShoppingInfoParams) {
 					Path:  "/index",
 					Type:  "MINI_PROGRAM",
 				},
-				ItemList: &UploadShoppingInfoRequestOrderListItemList{
-					Name:      "虚拟商品",
-					UnitPrice: params.PayTotal,
-					Quantity:  1,
-				},
+				ItemList: makeNewItemList(params),
 			},
 			Payer: &UploadShoppingInfoRequestPayer{
 				OpenId: params.PayerUid,
@@ -92,3 +88,25 @@ func UploadShoppingInfo(ctx context.Context, params *UploadShoppingInfoParams) {
 		_logger.Debug("Invoked UploadShoppingInfo successfully.")
 	}
 }
+
+func makeNewItemList(params *UploadShoppingInfoParams) []*UploadShoppingInfoRequestOrderListItemList {
+	if len(params.Items) == 0 {
+		return []*UploadShoppingInfoRequestOrderListItemList{
+			{
+				Name:      "虚拟商品",
+				UnitPrice: params.PayTotal,
+				Quantity:  1,
+			},
+		}
+	}
+	itemList := make([]*UploadShoppingInfoRequestOrderListItemList, 0, len(params.Items))
+	for _, item := range params.Items {
+		itemList = append(itemList, &UploadShoppingInfoRequestOrderListItemList{
+			MerchantItemId: item.MerchantItemId,
+			Name:           item.Name,
+			UnitPrice:      item.UnitPrice,
+			Quantity:       item.Quantity,
+		})
+	}
+	return itemList
+}
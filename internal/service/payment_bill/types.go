@@ -7,6 +7,14 @@ type UploadShoppingInfoParams struct {
 	TransactionId string
 	PayerUid      string
 	PayTotal      int
+	Items         []*UploadShoppingInfoItem // 商品明细, 为空时上传一件总价为实付金额的虚拟商品
+}
+
+type UploadShoppingInfoItem struct {
+	MerchantItemId string
+	Name           string
+	UnitPrice      int
+	Quantity       int
 }
 
 type UploadShoppingInfoRequest struct {
@@ -27,7 +35,7 @@ type UploadShoppingInfoRequestOrderKey struct {
 type UploadShoppingInfoRequestOrderList struct {
 	MerchantOrderNo     string                                                 `json:"merchant_order_no"`
 	OrderDetailJumpLink *UploadShoppingInfoRequestOrderListOrderDetailJumpLink `json:"order_detail_jump_link"`
-	ItemList            *UploadShoppingInfoRequestOrderListItemList            `json:"item_list"`
+	ItemList            []*UploadShoppingInfoRequestOrderListItemList          `json:"item_list"`
 }
 
 type UploadShoppingInfoRequestOrderListOrderDetailJumpLink struct {
@@ -37,10 +45,11 @@ type UploadShoppingInfoRequestOrderListOrderDetailJumpLink struct {
 }
 
 type UploadShoppingInfoRequestOrderListItemList struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	UnitPrice   int    `json:"unit_price"`
-	Quantity    int    `json:"quantity"`
+	MerchantItemId string `json:"merchant_item_id,omitempty"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	UnitPrice      int    `json:"unit_price"`
+	Quantity       int    `json:"quantity"`
 }
 
 type UploadShoppingInfoRequestPayer struct {
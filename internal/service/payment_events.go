@@ -0,0 +1,78 @@
+package service
+
+import (
+	"github.com/google/uuid"
+
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	"github.com/amazingchow/wechat-payment-callback-service/internal/proto_gens"
+	eventpublisher "github.com/amazingchow/wechat-payment-callback-service/internal/service/event_publisher"
+)
+
+// newOutboxPaymentEvent 由 outbox_events 中的支付事件生成发布到消息总线的支付事件, 事件类型与事件ID保持不变.
+// 支付成功事件的支付结果取自平台订单最近的支付通知, notification 为nil时不补齐.
+func newOutboxPaymentEvent(event *dao.OutboxEventModel, notification *dao.PaymentNotificationModel) *proto_gens.PaymentEvent {
+	paymentEvent := &proto_gens.PaymentEvent{
+		SchemaVersion:       eventpublisher.SchemaVersion,
+		EventId:             event.EventId,
+		EventType:           event.EventType,
+		AppId:               event.AppId,
+		TradeId:             event.TradeId,
+		PayerUid:            event.PayerUid,
+		TrxId:               event.TransactionId,
+		TradeType:           event.TradeType,
+		State:               event.State,
+		ItemAmountTotal:     event.ItemAmountTotal,
+		RefundedAmountTotal: event.RefundedAmountTotal,
+		Metadata:            event.Metadata,
+		OccurTime:           event.CreateTime,
+	}
+	if notification != nil && event.EventType == dao.OutboxEventTypePaymentSucceeded {
+		paymentEvent.TrxId = notification.ResourceTransactionId
+		paymentEvent.TradeType = notification.ResourceTradeType
+		paymentEvent.PayerTotal = int64(notification.ResourceAmountPayerTotal)
+		paymentEvent.SuccessTime = notification.ResourceSuccessTime
+	}
+	return paymentEvent
+}
+
+// newReplayPaymentEvent 由 outbox_events 中的支付事件重建带有重放标记的支付事件, 支付事件ID与原支付事件相同, 消费端可据此幂等处理.
+func newReplayPaymentEvent(event *dao.OutboxEventModel, notification *dao.PaymentNotificationModel) *proto_gens.PaymentEvent {
+	paymentEvent := newOutboxPaymentEvent(event, notification)
+	paymentEvent.Replay = true
+	return paymentEvent
+}
+
+// newFallbackOutboxEvents 由平台订单当前的业务状态重建支付事件, 用于重放没有写入 outbox_events 的平台订单(早期保存或未开启outbox).
+// 重建的支付事件ID由平台订单号和事件类型确定, 多次重放时保持不变.
+func newFallbackOutboxEvents(order *dao.PlatformOrderModel) []*dao.OutboxEventModel {
+	newEvent := func(eventType, state string, refundedAmountTotal int64) *dao.OutboxEventModel {
+		return &dao.OutboxEventModel{
+			EventId:             uuid.NewSHA1(uuid.NameSpaceOID, []byte(order.TradeId+"/"+eventType)).String(),
+			EventType:           eventType,
+			AppId:               order.AppId,
+			TradeId:             order.TradeId,
+			PayerUid:            order.PayerUid,
+			TradeType:           order.TradeType,
+			TransactionId:       order.TransactionId,
+			ItemAmountTotal:     order.ItemAmountTotal,
+			RefundedAmountTotal: refundedAmountTotal,
+			Metadata:            order.Metadata,
+			State:               state,
+			Status:              order.Status,
+			Version:             order.Version,
+			CreateTime:          order.UpdateTime,
+		}
+	}
+	switch order.CurrentState() {
+	case dao.OrderStatePaid, dao.OrderStateRefunding:
+		return []*dao.OutboxEventModel{newEvent(dao.OutboxEventTypePaymentSucceeded, dao.OrderStatePaid, 0)}
+	case dao.OrderStateRefunded:
+		return []*dao.OutboxEventModel{
+			newEvent(dao.OutboxEventTypePaymentSucceeded, dao.OrderStatePaid, 0),
+			newEvent(dao.OutboxEventTypeRefundSucceeded, dao.OrderStateRefunded, order.RefundedAmountTotal),
+		}
+	case dao.OrderStateClosed:
+		return []*dao.OutboxEventModel{newEvent(dao.OutboxEventTypePaymentClosed, dao.OrderStateClosed, 0)}
+	}
+	return nil
+}
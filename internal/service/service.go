@@ -6,28 +6,45 @@ import (
 	"time"
 
 	"github.com/wechatpay-apiv3/wechatpay-go/core"
+	"github.com/wechatpay-apiv3/wechatpay-go/core/auth"
+	"github.com/wechatpay-apiv3/wechatpay-go/core/auth/verifiers"
+	"github.com/wechatpay-apiv3/wechatpay-go/core/downloader"
 	"github.com/wechatpay-apiv3/wechatpay-go/core/option"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments/app"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments/h5"
 	"github.com/wechatpay-apiv3/wechatpay-go/services/payments/jsapi"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments/native"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/refunddomestic"
 	wechatpay_utils "github.com/wechatpay-apiv3/wechatpay-go/utils"
 
 	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
 	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
 	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
+	eventpublisher "github.com/amazingchow/wechat-payment-callback-service/internal/service/event_publisher"
+	tradeid "github.com/amazingchow/wechat-payment-callback-service/internal/service/trade_id"
 )
 
 var impl *WechatPaymentCallbackServiceImpl
 
 type WechatPaymentCallbackServiceImpl struct {
-	ops uint64
-
 	confMchPrivateKey       *rsa.PrivateKey
 	confSupportedAppIdTable map[string]struct{}
-	confMerchantId          string
-	confMchAPIv3Key         string
-	confNotifyUrl           string
+	// 各应用履约超时后执行的策略
+	confFulfillmentOverduePolicyTable map[string]string
+	confMerchantId                    string
+	confMchAPIv3Key                   string
+	confNotifyUrl                     string
+
+	svc            *jsapi.JsapiApiService
+	nativeSvc      *native.NativeApiService
+	h5Svc          *h5.H5ApiService
+	appSvc         *app.AppApiService
+	refundSvc      *refunddomestic.RefundsApiService
+	notifyVerifier auth.Verifier
+	storage        dao.PaymentInfoStorage
 
-	svc     *jsapi.JsapiApiService
-	storage dao.PaymentInfoStorage
+	tradeIdGenerator tradeid.Generator
+	eventPublisher   eventpublisher.EventPublisher
 }
 
 func SetupWechatPaymentCallbackServiceImpl() {
@@ -57,12 +74,27 @@ func SetupWechatPaymentCallbackServiceImpl() {
 	}
 	// 3. 初始化支付服务
 	impl.svc = &jsapi.JsapiApiService{Client: client}
+	impl.nativeSvc = &native.NativeApiService{Client: client}
+	impl.h5Svc = &h5.H5ApiService{Client: client}
+	impl.appSvc = &app.AppApiService{Client: client}
+	impl.refundSvc = &refunddomestic.RefundsApiService{Client: client}
+	// 使用客户端自动更新的微信支付平台证书来验证支付通知的签名
+	impl.notifyVerifier = verifiers.NewSHA256WithRSAVerifier(downloader.MgrInstance().GetCertificateVisitor(mchID))
 
 	// 4. 初始化配置
 	supportedAppList := config.GetConfig().ServiceInternalConfig.SupportedAppList
 	impl.confSupportedAppIdTable = make(map[string]struct{}, len(supportedAppList))
+	impl.confFulfillmentOverduePolicyTable = make(map[string]string, len(supportedAppList))
 	for i := 0; i < len(supportedAppList); i++ {
 		impl.confSupportedAppIdTable[supportedAppList[i].AppID] = struct{}{}
+		switch policy := supportedAppList[i].FulfillmentOverduePolicy; policy {
+		case "":
+			impl.confFulfillmentOverduePolicyTable[supportedAppList[i].AppID] = dao.FulfillmentOverduePolicyAlert
+		case dao.FulfillmentOverduePolicyAlert, dao.FulfillmentOverduePolicyRefund:
+			impl.confFulfillmentOverduePolicyTable[supportedAppList[i].AppID] = policy
+		default:
+			logger.GetGlobalLogger().Fatalf("Invalid fulfillment_overdue_policy:%s for app:%s.", policy, supportedAppList[i].AppID)
+		}
 	}
 	impl.confMerchantId = mchID
 	impl.confMchAPIv3Key = mchAPIv3Key
@@ -70,7 +102,35 @@ func SetupWechatPaymentCallbackServiceImpl() {
 
 	// 5. 初始化数据库连接
 	dao.InitConnPool(&(config.GetConfig().ServiceInternalConfig.Storage))
-	impl.storage = dao.GetConnPool()
+	// 平台订单状态迁移后通知 WatchPaymentStatus 的订阅方
+	impl.storage = newPaymentStatusPublishingStorage(dao.GetConnPool())
+
+	// 6. 初始化交易ID生成器, 校验码密钥必须单独配置, 不能复用商户APIv3密钥
+	tradeIdSecret := config.GetConfig().ServiceInternalConfig.TradeIdGenerator.Secret
+	if len(tradeIdSecret) == 0 || tradeIdSecret == mchAPIv3Key {
+		logger.GetGlobalLogger().Fatal("A dedicated trade_id_generator.secret is required.")
+	}
+	impl.tradeIdGenerator, err = tradeid.NewGenerator(ctx, &(config.GetConfig().ServiceInternalConfig.TradeIdGenerator), tradeIdSecret)
+	if err != nil {
+		logger.GetGlobalLogger().WithError(err).Fatal("Failed to create trade-id generator.")
+	}
+
+	// 7. 初始化支付事件发布器, 支付事件由支付事件投递任务从 outbox_events 发布
+	conf := &(config.GetConfig().ServiceInternalConfig)
+	if len(conf.EventPublisher.Backend) > 0 {
+		if !conf.Outbox.Enable || !conf.Outbox.Dispatcher.Enable {
+			logger.GetGlobalLogger().Fatal("The event_publisher requires outbox and its dispatcher to be enabled.")
+		}
+		for i := range conf.Outbox.Webhooks {
+			if conf.Outbox.Webhooks[i].Name == EventPublisherDeliveryName {
+				logger.GetGlobalLogger().Fatalf("The webhook name %s is reserved for the event_publisher.", EventPublisherDeliveryName)
+			}
+		}
+	}
+	impl.eventPublisher, err = eventpublisher.NewEventPublisher(&conf.EventPublisher)
+	if err != nil {
+		logger.GetGlobalLogger().WithError(err).Fatal("Failed to create event publisher.")
+	}
 }
 
 func GetWechatPaymentCallbackServiceImpl() *WechatPaymentCallbackServiceImpl {
@@ -78,5 +138,11 @@ func GetWechatPaymentCallbackServiceImpl() *WechatPaymentCallbackServiceImpl {
 }
 
 func CloseWechatPaymentCallbackServiceImpl() {
+	if impl.tradeIdGenerator != nil {
+		_ = impl.tradeIdGenerator.Close(context.Background())
+	}
+	if impl.eventPublisher != nil {
+		_ = impl.eventPublisher.Close(context.Background())
+	}
 	dao.CloseConnPool()
 }
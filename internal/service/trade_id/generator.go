@@ -0,0 +1,104 @@
+package tradeid
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/config"
+)
+
+// 平台订单交易ID即微信支付的商户订单号 out_trade_no, 只能由数字、大小写字母、_-|* 组成, 长度为6-32个字符.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_1.shtml
+const (
+	TradeIdMinLength = 6
+	TradeIdMaxLength = 32
+)
+
+const (
+	BackendSnowflake = "snowflake"
+	BackendRedis     = "redis"
+)
+
+// 交易ID布局: 前缀(1位) + 主体 + 校验码(6位), 前缀标识签发交易ID的生成器.
+const (
+	snowflakePrefix = "S"
+	redisPrefix     = "R"
+	checkCodeLength = 6
+)
+
+var (
+	ErrUnsupportedBackend = errors.New("trade_id: unsupported backend")
+	ErrEmptySecret        = errors.New("trade_id: empty secret")
+)
+
+var outTradeNoPattern = regexp.MustCompile(`^[0-9A-Za-z_\-|*]+$`)
+
+// Generator 集群安全的平台订单交易ID生成器.
+type Generator interface {
+	// Generate 生成一个新的平台订单交易ID.
+	Generate(ctx context.Context) (tradeId string, err error)
+	// Validate 校验平台订单交易ID是否由生成器签发, 不区分签发时使用的后端.
+	Validate(tradeId string) bool
+	// Close 释放生成器持有的资源.
+	Close(ctx context.Context) error
+}
+
+// NewGenerator 按配置创建交易ID生成器, secret 用于计算交易ID的校验码, 同一集群内必须保持一致.
+func NewGenerator(ctx context.Context, conf *config.TradeIdGenerator, secret string) (Generator, error) {
+	if len(secret) == 0 {
+		return nil, ErrEmptySecret
+	}
+	signer := &signer{secret: []byte(secret)}
+	switch conf.Backend {
+	case "", BackendSnowflake:
+		return newSnowflakeGenerator(ctx, signer)
+	case BackendRedis:
+		return newRedisGenerator(signer), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBackend, conf.Backend)
+	}
+}
+
+// signer 为交易ID计算校验码, 校验码为HMAC-SHA256摘要的前6位十六进制大写字符.
+type signer struct {
+	secret []byte
+}
+
+func (s *signer) sign(prefix, body string) string {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(prefix + body))
+	code := strings.ToUpper(hex.EncodeToString(h.Sum(nil)))[:checkCodeLength]
+	return prefix + body + code
+}
+
+func (s *signer) Validate(tradeId string) bool {
+	if len(tradeId) < TradeIdMinLength || len(tradeId) > TradeIdMaxLength ||
+		!outTradeNoPattern.MatchString(tradeId) {
+		return false
+	}
+	var bodyLength int
+	switch tradeId[:1] {
+	case snowflakePrefix:
+		bodyLength = snowflakeBodyLength
+	case redisPrefix:
+		bodyLength = redisBodyLength
+	default:
+		return false
+	}
+	if len(tradeId) != 1+bodyLength+checkCodeLength {
+		return false
+	}
+	body := tradeId[1 : 1+bodyLength]
+	for i := 0; i < len(body); i++ {
+		if body[i] < '0' || body[i] > '9' {
+			return false
+		}
+	}
+	return hmac.Equal([]byte(s.sign(tradeId[:1], body)), []byte(tradeId))
+}
@@ -0,0 +1,55 @@
+package tradeid
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
+)
+
+func TestSnowflakeGenerate(t *testing.T) {
+	s := &signer{secret: []byte("secret")}
+	g := &snowflakeGenerator{
+		signer:   s,
+		lease:    &ext_redis.Lock{},
+		deadline: time.Now().Add(time.Minute),
+		workerId: snowflakeMaxWorkerId,
+	}
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 10000; i++ {
+		tradeId, err := g.Generate(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 1+snowflakeBodyLength+checkCodeLength, len(tradeId))
+		assert.True(t, g.Validate(tradeId))
+		_, ok := seen[tradeId]
+		assert.False(t, ok)
+		seen[tradeId] = struct{}{}
+	}
+
+	// 租约到期后停止签发交易ID
+	g.deadline = time.Now().Add(-time.Second)
+	_, err := g.Generate(context.Background())
+	assert.Equal(t, ErrWorkerLeaseLost, err)
+}
+
+func TestValidate(t *testing.T) {
+	s := &signer{secret: []byte("secret")}
+
+	tradeId := s.sign(redisPrefix, fmt.Sprintf("%s%0*d", "20240101000000", redisSequenceDigits, 1))
+	assert.True(t, s.Validate(tradeId))
+	assert.True(t, len(tradeId) >= TradeIdMinLength && len(tradeId) <= TradeIdMaxLength)
+
+	// 篡改主体或校验码
+	assert.False(t, s.Validate(tradeId[:5]+"9"+tradeId[6:]))
+	assert.False(t, s.Validate(tradeId[:len(tradeId)-1]+"G"))
+	// 其他密钥签发
+	assert.False(t, (&signer{secret: []byte("other")}).Validate(tradeId))
+	// 非生成器签发
+	assert.False(t, s.Validate("2024010100000000301"))
+	assert.False(t, s.Validate(""))
+}
@@ -0,0 +1,55 @@
+package tradeid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
+)
+
+// Redis布局: 14位UTC秒级时间(yyyyMMddHHmmss) + 6位秒内序列号.
+const (
+	redisTimeLayout     = "20060102150405"
+	redisSequenceDigits = 6
+	redisMaxSequence    = int64(999999)
+	redisBodyLength     = len(redisTimeLayout) + redisSequenceDigits
+)
+
+const (
+	RedisSequenceKeyPrefix = "wechat_payment_callback_service.trade_id.seq."
+	RedisSequenceTTL       = 5 * time.Second
+)
+
+var (
+	ErrSequenceExhausted = errors.New("trade_id: sequence of current second is exhausted")
+)
+
+// redisGenerator 对每一秒使用一个Redis计数器(INCR)生成秒内序列号, 集群内所有实例共享同一计数器.
+type redisGenerator struct {
+	*signer
+}
+
+func newRedisGenerator(signer *signer) *redisGenerator {
+	return &redisGenerator{signer: signer}
+}
+
+func (g *redisGenerator) Generate(ctx context.Context) (tradeId string, err error) {
+	// 使用UTC时间, 避免各实例时区配置不一致时同一秒对应不同的计数器而生成重复的交易ID
+	ts := time.Now().UTC().Format(redisTimeLayout)
+	seq, err := ext_redis.GetConnPool().GetBigCache().IncrWithTTL(ctx, RedisSequenceKeyPrefix+ts, RedisSequenceTTL)
+	if err != nil {
+		return
+	}
+	if seq > redisMaxSequence {
+		err = ErrSequenceExhausted
+		return
+	}
+	tradeId = g.sign(redisPrefix, fmt.Sprintf("%s%0*d", ts, redisSequenceDigits, seq))
+	return
+}
+
+func (g *redisGenerator) Close(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,175 @@
+package tradeid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/common/logger"
+	ext_redis "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_redis"
+)
+
+// Snowflake布局: 41位毫秒时间戳 + 10位机器ID + 12位序列号, 十进制补零到19位.
+const (
+	snowflakeEpoch        = int64(1704067200000) // 2024-01-01 00:00:00 UTC
+	snowflakeWorkerIdBits = 10
+	snowflakeSequenceBits = 12
+	snowflakeMaxWorkerId  = int64(1)<<snowflakeWorkerIdBits - 1
+	snowflakeMaxSequence  = int64(1)<<snowflakeSequenceBits - 1
+	snowflakeBodyLength   = 19
+	// 允许等待的最大时钟回拨
+	snowflakeMaxClockBackwards = 5 * time.Millisecond
+)
+
+const (
+	SnowflakeWorkerLeaseKeyPrefix = "wechat_payment_callback_service.trade_id.worker."
+	SnowflakeWorkerLeaseTTL       = 30 * time.Second
+)
+
+var (
+	ErrWorkerLeaseLost     = errors.New("trade_id: snowflake worker lease is lost")
+	ErrNoWorkerIdAvailable = errors.New("trade_id: no snowflake worker id available")
+	ErrClockMovedBackwards = errors.New("trade_id: clock moved backwards")
+)
+
+// snowflakeGenerator 使用Snowflake算法生成交易ID, 机器ID通过Redis租约分配, 保证同一时刻集群内不重复.
+// 租约续约失败时停止签发交易ID, 并尝试重新申请机器ID.
+type snowflakeGenerator struct {
+	*signer
+
+	mu       sync.Mutex
+	lease    *ext_redis.Lock
+	deadline time.Time // 租约到期时间, 到期前未能续约则停止签发交易ID
+	workerId int64
+	lastMs   int64
+	sequence int64
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func newSnowflakeGenerator(ctx context.Context, signer *signer) (*snowflakeGenerator, error) {
+	g := &snowflakeGenerator{
+		signer: signer,
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+	if err := g.acquireWorkerId(ctx); err != nil {
+		return nil, err
+	}
+	go g.keepLease()
+	return g, nil
+}
+
+func (g *snowflakeGenerator) acquireWorkerId(ctx context.Context) error {
+	for id := int64(0); id <= snowflakeMaxWorkerId; id++ {
+		st := time.Now()
+		lease, err := ext_redis.GetConnPool().GetBigCache().TryLock(
+			ctx, fmt.Sprintf("%s%d", SnowflakeWorkerLeaseKeyPrefix, id), SnowflakeWorkerLeaseTTL)
+		if err == ext_redis.ErrLockNotAcquired {
+			continue
+		} else if err != nil {
+			return err
+		}
+		g.mu.Lock()
+		g.lease, g.deadline, g.workerId = lease, st.Add(SnowflakeWorkerLeaseTTL), id
+		g.mu.Unlock()
+		logger.GetGlobalLogger().Infof("Acquired snowflake worker id:%d.", id)
+		return nil
+	}
+	return ErrNoWorkerIdAvailable
+}
+
+func (g *snowflakeGenerator) keepLease() {
+	defer close(g.doneCh)
+
+	ticker := time.NewTicker(SnowflakeWorkerLeaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-g.stopCh:
+			return
+		case <-ticker.C:
+			g.mu.Lock()
+			lease := g.lease
+			g.mu.Unlock()
+			if lease != nil {
+				st := time.Now()
+				err := lease.Refresh(context.Background(), SnowflakeWorkerLeaseTTL)
+				if err == nil {
+					g.mu.Lock()
+					g.deadline = st.Add(SnowflakeWorkerLeaseTTL)
+					g.mu.Unlock()
+					continue
+				}
+				logger.GetGlobalLogger().WithError(err).Errorf("Failed to refresh snowflake worker lease.")
+				if err == ext_redis.ErrLockNotAcquired {
+					// 租约已过期, 机器ID可能已被其他实例使用
+					g.mu.Lock()
+					g.lease = nil
+					g.mu.Unlock()
+				} else {
+					continue
+				}
+			}
+			if err := g.acquireWorkerId(context.Background()); err != nil {
+				logger.GetGlobalLogger().WithError(err).Errorf("Failed to acquire snowflake worker id.")
+			}
+		}
+	}
+}
+
+func (g *snowflakeGenerator) Generate(ctx context.Context) (tradeId string, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.lease == nil || time.Now().After(g.deadline) {
+		err = ErrWorkerLeaseLost
+		return
+	}
+	nowMs := time.Now().UnixMilli()
+	if nowMs < g.lastMs {
+		backwards := time.Duration(g.lastMs-nowMs) * time.Millisecond
+		if backwards > snowflakeMaxClockBackwards {
+			err = ErrClockMovedBackwards
+			return
+		}
+		time.Sleep(backwards)
+		nowMs = g.lastMs
+	}
+	if nowMs == g.lastMs {
+		g.sequence = (g.sequence + 1) & snowflakeMaxSequence
+		if g.sequence == 0 {
+			// 当前毫秒内序列号已用尽, 等待下一毫秒
+			for nowMs <= g.lastMs {
+				time.Sleep(100 * time.Microsecond)
+				nowMs = time.Now().UnixMilli()
+			}
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastMs = nowMs
+
+	id := (nowMs-snowflakeEpoch)<<(snowflakeWorkerIdBits+snowflakeSequenceBits) |
+		g.workerId<<snowflakeSequenceBits |
+		g.sequence
+	tradeId = g.sign(snowflakePrefix, fmt.Sprintf("%0*d", snowflakeBodyLength, id))
+	return
+}
+
+func (g *snowflakeGenerator) Close(ctx context.Context) error {
+	close(g.stopCh)
+	<-g.doneCh
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.lease == nil {
+		return nil
+	}
+	err := g.lease.Unlock(ctx)
+	g.lease = nil
+	return err
+}
@@ -1,11 +1,27 @@
 package service
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/skip2/go-qrcode"
+	"github.com/wechatpay-apiv3/wechatpay-go/core/auth"
+	"github.com/wechatpay-apiv3/wechatpay-go/core/consts"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments"
 	wechatpay_utils "github.com/wechatpay-apiv3/wechatpay-go/utils"
+
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
 )
 
 const (
@@ -23,23 +39,248 @@ func GenerateNonce() string {
 	return string(bytes)
 }
 
+// 签名串布局, 不同的调起支付方式使用不同的签名串.
+type SignLayout int
+
+const (
+	// JSAPI/小程序调起支付: "应用ID\n时间戳\n随机字符串\n订单详情扩展字符串\n", 其中扩展字符串为 "prepay_id=***".
+	// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_4.shtml
+	SignLayoutJSAPI SignLayout = iota
+	// APP调起支付: "应用ID\n时间戳\n随机字符串\n预支付交易会话ID\n".
+	// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_2_4.shtml
+	SignLayoutAPP
+)
+
 type SignParams struct {
+	Layout    SignLayout
 	AppId     string
 	Timestamp int64
 	Nonce     string
-	Package   string
+	Package   string // JSAPI调起支付使用
+	PrepayId  string // APP调起支付使用
 }
 
 const (
 	SignTypeRSA = "RSA"
+	// APP调起支付的订单详情扩展字符串, 固定值
+	AppPackage = "Sign=WXPay"
 )
 
 func MakeNewPaymentSignature(privKey *rsa.PrivateKey, signParams *SignParams) (signature string, err error) {
-	source := fmt.Sprintf("%s\n%d\n%s\n%s\n",
-		signParams.AppId,
-		signParams.Timestamp,
-		signParams.Nonce,
-		signParams.Package,
-	)
+	var source string
+	switch signParams.Layout {
+	case SignLayoutJSAPI:
+		source = fmt.Sprintf("%s\n%d\n%s\n%s\n",
+			signParams.AppId,
+			signParams.Timestamp,
+			signParams.Nonce,
+			signParams.Package,
+		)
+	case SignLayoutAPP:
+		source = fmt.Sprintf("%s\n%d\n%s\n%s\n",
+			signParams.AppId,
+			signParams.Timestamp,
+			signParams.Nonce,
+			signParams.PrepayId,
+		)
+	default:
+		err = fmt.Errorf("unsupported sign layout %d", signParams.Layout)
+		return
+	}
 	return wechatpay_utils.SignSHA256WithRSA(source, privKey)
 }
+
+// 二维码图片的默认边长和最大边长, 单位（像素）.
+const (
+	QRCodeDefaultSize = 256
+	QRCodeMaxSize     = 1024
+)
+
+// MakeNewQRCodePNG 将Native支付返回的二维码链接渲染为PNG图片.
+func MakeNewQRCodePNG(codeUrl string, size int) ([]byte, error) {
+	if size <= 0 {
+		size = QRCodeDefaultSize
+	}
+	return qrcode.Encode(codeUrl, qrcode.Medium, size)
+}
+
+// AppendH5RedirectUrl 将支付完成后的回跳页面经过URL编码后拼接到H5支付跳转链接之后.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/open/pay/chapter2_6_2.shtml
+func AppendH5RedirectUrl(h5Url, redirectUrl string) string {
+	if len(redirectUrl) == 0 {
+		return h5Url
+	}
+	sep := "&"
+	if !strings.Contains(h5Url, "?") {
+		sep = "?"
+	}
+	return fmt.Sprintf("%s%sredirect_url=%s", h5Url, sep, url.QueryEscape(redirectUrl))
+}
+
+// 下单接口附加数据(attach)的最大长度.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_1.shtml
+const AttachMaxLength = 128
+
+var (
+	ErrAttachTooLong = errors.New("attach: metadata is too long")
+	ErrEmptyMetaKey  = errors.New("attach: metadata key is empty")
+)
+
+// EncodeAttach 将平台订单元数据编码为紧凑的URL查询串(按键排序), 作为下单接口的附加数据.
+func EncodeAttach(metadata map[string]string) (string, error) {
+	if len(metadata) == 0 {
+		return "", nil
+	}
+	values := make(url.Values, len(metadata))
+	for k, v := range metadata {
+		if len(k) == 0 {
+			return "", ErrEmptyMetaKey
+		}
+		values.Set(k, v)
+	}
+	attach := values.Encode()
+	if len(attach) > AttachMaxLength {
+		return "", ErrAttachTooLong
+	}
+	return attach, nil
+}
+
+// DecodeAttach 从支付通知或查询订单返回的附加数据中解析平台订单元数据, 无法解析时返回空.
+func DecodeAttach(attach string) map[string]string {
+	if len(attach) == 0 {
+		return nil
+	}
+	values, err := url.ParseQuery(attach)
+	if err != nil {
+		return nil
+	}
+	metadata := make(map[string]string, len(values))
+	for k := range values {
+		metadata[k] = values.Get(k)
+	}
+	return metadata
+}
+
+var (
+	ErrInvalidOrderItem        = errors.New("order: invalid item")
+	ErrOrderItemsTotalMismatch = errors.New("order: items do not sum to the total")
+)
+
+// 商户侧商品编码只能由大小写字母、数字、中划线、下划线组成, 不超过32个字符.
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_5_1.shtml
+var merchantGoodsIdPattern = regexp.MustCompile(`^[0-9A-Za-z_\-]{1,32}$`)
+
+// ValidateOrderItems 校验商品明细, 各商品单价与数量之积的和必须等于订单总额.
+func ValidateOrderItems(items []*dao.OrderItemModel, total int64) error {
+	var sum int64
+	for _, item := range items {
+		if !merchantGoodsIdPattern.MatchString(item.SkuId) || len(item.Name) == 0 ||
+			item.UnitPrice <= 0 || item.Quantity <= 0 {
+			return ErrInvalidOrderItem
+		}
+		// 避免溢出, 单个商品总价超过订单总额时直接判定不一致
+		if item.UnitPrice > (total-sum)/item.Quantity {
+			return ErrOrderItemsTotalMismatch
+		}
+		sum += item.UnitPrice * item.Quantity
+	}
+	if sum != total {
+		return ErrOrderItemsTotalMismatch
+	}
+	return nil
+}
+
+// 微信支付通知的时间戳与当前时间之差不得超过该值, 否则视为过期(重放)通知.
+const NotifyTimestampTolerance = 5 * time.Minute
+
+var (
+	ErrNotifyHeaderMissing   = errors.New("notify: wechatpay header is missing")
+	ErrNotifyTimestampExpire = errors.New("notify: wechatpay timestamp expires")
+)
+
+type NotifySignatureHeader struct {
+	Serial    string
+	Signature string
+	Timestamp int64
+	Nonce     string
+}
+
+// VerifyNotifySignature 使用微信支付平台证书验证通知的签名, 验签原文为 "时间戳\n随机串\n报文主体\n".
+// 文档链接: https://pay.weixin.qq.com/wiki/doc/apiv3/wechatpay/wechatpay4_1.shtml
+func VerifyNotifySignature(ctx context.Context, verifier auth.Verifier, header http.Header, body []byte, now time.Time) (
+	signHeader *NotifySignatureHeader, err error) {
+
+	signHeader = &NotifySignatureHeader{
+		Serial:    strings.TrimSpace(header.Get(consts.WechatPaySerial)),
+		Signature: strings.TrimSpace(header.Get(consts.WechatPaySignature)),
+		Nonce:     strings.TrimSpace(header.Get(consts.WechatPayNonce)),
+	}
+	ts := strings.TrimSpace(header.Get(consts.WechatPayTimestamp))
+	if len(signHeader.Serial) == 0 || len(signHeader.Signature) == 0 ||
+		len(signHeader.Nonce) == 0 || len(ts) == 0 {
+		err = ErrNotifyHeaderMissing
+		return
+	}
+	if signHeader.Timestamp, err = strconv.ParseInt(ts, 10, 64); err != nil {
+		err = fmt.Errorf("notify: invalid wechatpay timestamp %q", ts)
+		return
+	}
+	if math.Abs(float64(now.Unix()-signHeader.Timestamp)) > NotifyTimestampTolerance.Seconds() {
+		err = ErrNotifyTimestampExpire
+		return
+	}
+
+	message := fmt.Sprintf("%d\n%s\n%s\n", signHeader.Timestamp, signHeader.Nonce, string(body))
+	if err = verifier.Verify(ctx, signHeader.Serial, message, signHeader.Signature); err != nil {
+		err = fmt.Errorf("notify: %w", err)
+		return
+	}
+	return
+}
+
+// CrossCheckNotificationResource 校验支付通知的信息是否与平台订单一致, 返回不一致的字段.
+func CrossCheckNotificationResource(order *dao.PlatformOrderModel, resource *NotificationResource) (mismatchedFields []string) {
+	if resource.Amount == nil || int64(resource.Amount.Total) != order.ItemAmountTotal {
+		mismatchedFields = append(mismatchedFields, "amount.total")
+	}
+	if resource.MchId != order.MchId {
+		mismatchedFields = append(mismatchedFields, "mchid")
+	}
+	if resource.AppId != order.AppId {
+		mismatchedFields = append(mismatchedFields, "appid")
+	}
+	if len(order.TradeType) > 0 && resource.TradeType != order.TradeType {
+		mismatchedFields = append(mismatchedFields, "trade_type")
+	}
+	// NOTE: 只有JSAPI支付(早期保存的平台订单没有交易类型字段, 均为JSAPI支付)下单时指定了付款用户的openid,
+	// Native/H5/APP支付的付款用户在下单时无法获知, 平台订单记录的用户唯一标识不一定是该应用下的openid, 不校验付款用户
+	checkPayer := order.TradeType == "" || order.TradeType == dao.TradeTypeJSAPI
+	if checkPayer && len(order.PayerUid) > 0 && (resource.Payer == nil || resource.Payer.OpenId != order.PayerUid) {
+		mismatchedFields = append(mismatchedFields, "payer.openid")
+	}
+	return
+}
+
+// NotificationResourceFromTransaction 将查询订单接口返回的支付结果转换为支付通知的资源数据,
+// 两者使用相同的JSON结构, 同时返回资源数据的明文.
+func NotificationResourceFromTransaction(transaction *payments.Transaction) (
+	resource *NotificationResource, plaintext string, err error) {
+
+	data, err := json.Marshal(transaction)
+	if err != nil {
+		return
+	}
+	resource = &NotificationResource{}
+	if err = json.Unmarshal(data, resource); err != nil {
+		resource = nil
+		return
+	}
+	if resource.Payer == nil {
+		resource.Payer = &NotificationResourcePayer{}
+	}
+	if resource.Amount == nil {
+		resource.Amount = &NotificationResourceAmount{}
+	}
+	plaintext = string(data)
+	return
+}
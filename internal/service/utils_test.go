@@ -1,10 +1,33 @@
 package service
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/wechatpay-apiv3/wechatpay-go/core"
+	"github.com/wechatpay-apiv3/wechatpay-go/core/auth/verifiers"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments"
 	wechatpay_utils "github.com/wechatpay-apiv3/wechatpay-go/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dao "github.com/amazingchow/wechat-payment-callback-service/internal/extensions/ext_mongo"
 )
 
 func TestMakeNewPaymentSignature(t *testing.T) {
@@ -73,4 +96,425 @@ CxgVDLe+oR3NCvzJFizD8zNDSIXFQqJKIIV/gZ2W3ygWa2jxa8pzdXYJ2DpdgRIC
 	})
 	assert.Nil(t, err)
 	assert.Equal(t, "cRhvEAqKgjlenfOuS89U24H3gnN9h0MSpTvDjS8PAWExHkRnsjH/wFEKRjLE2y56pn7z86inss1RbH/HYHX7u1IGwpHlwEhW4osnWFf/h6wLrtSx3pIerPCEpJgZ+qkv+f3VbnZFyw9Ep1yw1Oxu5iZFl58QFJlucfQaW4ACB79Ig09GEypeXZ3F2iC3mZpbMaJZfSyA7opSQukmQln1D2dXJtc+K+au6tUUo/uMCAenXptwgWFdFcvCJXev6RRHKrUg5GfZgOiOFBcurGQEOKy2CFPUqissiSi8w9GNINThxmgV0PzJihh/z8ujg35rHtifx8UUxo3xtVR21q74Ir7Eygu1KTyjCuu6P+AMak49i9uAB+yUxu8J/YC+RJqTbTHqdHEUAs3I9C/XvrcO2EZKLlxXQ8pvdn1wuf/vw5BhBTBio6z/cX2L9usEJbG2OUSO4n8ABRk01BVIc/Qxs7OVwDEUkQFEHVvQX44FLzYL0+/5vZ1rAmDCwzvEbSpV/+CIukGcS0Aw8REPhykZ/AQSvIyWN6TlJtEhLitYNF3jBvITnnUAXx2wuzb81scvjiVJNQg8pwGkHk2hPvEjzGufZOd7o9zWKJJgGeAalJWqfivui1wW2BxLGi1qQpZhT4W1d2l9Ds1T2IbS0gcXSBdYHA8tE2iw/Do2rf0Fp5g=", signature)
+
+	// APP调起支付, 签名串的第四行为预支付交易会话ID
+	signature, err = MakeNewPaymentSignature(key, &SignParams{
+		Layout:    SignLayoutAPP,
+		AppId:     "wx8888888888888888",
+		Timestamp: 1414561699,
+		Nonce:     "5K8264ILTKCH16CQ2502SI8ZNMTM67VS",
+		PrepayId:  "wx201410272009395522657a690389285100",
+	})
+	assert.Nil(t, err)
+	expected, err := wechatpay_utils.SignSHA256WithRSA(
+		"wx8888888888888888\n1414561699\n5K8264ILTKCH16CQ2502SI8ZNMTM67VS\nwx201410272009395522657a690389285100\n", key)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, signature)
+
+	_, err = MakeNewPaymentSignature(key, &SignParams{Layout: SignLayout(-1)})
+	assert.NotNil(t, err)
+}
+
+func TestVerifyNotifySignature(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Tenpay.com Root CA"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	verifier := verifiers.NewSHA256WithRSAVerifier(core.NewCertificateMap(map[string]*x509.Certificate{"SERIAL": cert}))
+
+	body := []byte(`{"id":"EV-2018022511223320873","event_type":"TRANSACTION.SUCCESS"}`)
+	now := time.Now()
+	makeHeader := func(ts int64, serial string, payload []byte) http.Header {
+		signature, err := wechatpay_utils.SignSHA256WithRSA(
+			fmt.Sprintf("%d\n%s\n%s\n", ts, "NONCE", string(payload)), key)
+		assert.Nil(t, err)
+		header := http.Header{}
+		header.Set("Wechatpay-Serial", serial)
+		header.Set("Wechatpay-Signature", signature)
+		header.Set("Wechatpay-Timestamp", strconv.FormatInt(ts, 10))
+		header.Set("Wechatpay-Nonce", "NONCE")
+		return header
+	}
+
+	signHeader, err := VerifyNotifySignature(context.Background(), verifier, makeHeader(now.Unix(), "SERIAL", body), body, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "NONCE", signHeader.Nonce)
+
+	// 报文被篡改
+	_, err = VerifyNotifySignature(context.Background(), verifier, makeHeader(now.Unix(), "SERIAL", body), append(body, ' '), now)
+	assert.NotNil(t, err)
+	// 未知的平台证书
+	_, err = VerifyNotifySignature(context.Background(), verifier, makeHeader(now.Unix(), "UNKNOWN", body), body, now)
+	assert.NotNil(t, err)
+	// 过期的通知
+	_, err = VerifyNotifySignature(context.Background(), verifier, makeHeader(now.Add(-10*time.Minute).Unix(), "SERIAL", body), body, now)
+	assert.Equal(t, ErrNotifyTimestampExpire, err)
+	// 缺少签名头
+	_, err = VerifyNotifySignature(context.Background(), verifier, http.Header{}, body, now)
+	assert.Equal(t, ErrNotifyHeaderMissing, err)
+}
+
+func TestCrossCheckNotificationResource(t *testing.T) {
+	order := &dao.PlatformOrderModel{
+		AppId:           "wx8888888888888888",
+		MchId:           "1230000109",
+		TradeId:         "2024050112000000001",
+		PayerUid:        "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o",
+		ItemAmountTotal: 100,
+	}
+	resource := &NotificationResource{
+		AppId:      "wx8888888888888888",
+		MchId:      "1230000109",
+		OutTradeNo: "2024050112000000001",
+		Payer:      &NotificationResourcePayer{OpenId: "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"},
+		Amount:     &NotificationResourceAmount{Total: 100, PayerTotal: 100},
+	}
+	assert.Empty(t, CrossCheckNotificationResource(order, resource))
+
+	resource.Amount.Total = 1
+	resource.AppId = "wx0000000000000000"
+	resource.Payer = nil
+	assert.Equal(t, []string{"amount.total", "appid", "payer.openid"}, CrossCheckNotificationResource(order, resource))
+
+	// Native支付扫码用户不一定是平台订单记录的用户, 不校验付款用户
+	order.TradeType = dao.TradeTypeNative
+	resource.TradeType = dao.TradeTypeNative
+	resource.Payer = &NotificationResourcePayer{OpenId: "oUpF8uMuAJO_M2pxb1Q9zNjWeS7p"}
+	assert.Equal(t, []string{"amount.total", "appid"}, CrossCheckNotificationResource(order, resource))
+	order.TradeType = dao.TradeTypeJSAPI
+	resource.TradeType = dao.TradeTypeJSAPI
+	assert.Equal(t, []string{"amount.total", "appid", "payer.openid"}, CrossCheckNotificationResource(order, resource))
+
+	order.PayerUid = ""
+	assert.Equal(t, []string{"amount.total", "appid"}, CrossCheckNotificationResource(order, resource))
+
+	order.TradeType = dao.TradeTypeH5
+	resource.TradeType = dao.TradeTypeNative
+	assert.Equal(t, []string{"amount.total", "appid", "trade_type"}, CrossCheckNotificationResource(order, resource))
+}
+
+func TestAppendH5RedirectUrl(t *testing.T) {
+	h5Url := "https://wx.tenpay.com/cgi-bin/mmpayweb-bin/checkmweb?prepay_id=wx2016121516420242444321ca0631331346&package=1405458241"
+	assert.Equal(t, h5Url, AppendH5RedirectUrl(h5Url, ""))
+	assert.Equal(t,
+		h5Url+"&redirect_url=https%3A%2F%2Fwww.example.com%2Fpay%3Ftrade_id%3D1",
+		AppendH5RedirectUrl(h5Url, "https://www.example.com/pay?trade_id=1"))
+}
+
+func TestNotificationResourceFromTransaction(t *testing.T) {
+	resource, plaintext, err := NotificationResourceFromTransaction(&payments.Transaction{
+		Appid:         core.String("wx8888888888888888"),
+		Mchid:         core.String("1230000109"),
+		OutTradeNo:    core.String("2024050112000000001"),
+		TransactionId: core.String("1217752501201407033233368018"),
+		TradeType:     core.String("NATIVE"),
+		TradeState:    core.String(TradeStateSuccess),
+		SuccessTime:   core.String("2018-06-08T10:34:56+08:00"),
+		Amount:        &payments.TransactionAmount{Total: core.Int64(100), PayerTotal: core.Int64(90), Currency: core.String("CNY")},
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, plaintext, `"transaction_id":"1217752501201407033233368018"`)
+	assert.Equal(t, "2024050112000000001", resource.OutTradeNo)
+	assert.Equal(t, TradeStateSuccess, resource.TradeState)
+	assert.Equal(t, 100, resource.Amount.Total)
+	assert.Equal(t, 90, resource.Amount.PayerTotal)
+	// 未返回支付者时不能为nil
+	assert.NotNil(t, resource.Payer)
+	assert.Equal(t, "", resource.Payer.OpenId)
+}
+
+func TestCheckRepeatedPrepayOrder(t *testing.T) {
+	expected := &dao.PlatformOrderModel{
+		AppId:           "wx-app-id",
+		TradeId:         "trade-id",
+		PayerUid:        "payer-uid",
+		TradeType:       dao.TradeTypeJSAPI,
+		ItemDescription: "item",
+		ItemAmountTotal: 100,
+	}
+	order := *expected
+	order.State = dao.OrderStatePrepaid
+	order.ExpireTime = time.Now().Add(time.Minute).Unix()
+	assert.Nil(t, checkRepeatedPrepayOrder(&order, expected))
+
+	// 金额或商品描述不一致
+	changed := *expected
+	changed.ItemAmountTotal = 200
+	assert.Equal(t, codes.AlreadyExists, status.Code(checkRepeatedPrepayOrder(&order, &changed)))
+	changed = *expected
+	changed.ItemDescription = "other"
+	assert.Equal(t, codes.AlreadyExists, status.Code(checkRepeatedPrepayOrder(&order, &changed)))
+
+	// 商品明细不一致
+	changed = *expected
+	changed.Items = []*dao.OrderItemModel{{SkuId: "sku-1", Name: "item", UnitPrice: 100, Quantity: 1}}
+	assert.Equal(t, codes.AlreadyExists, status.Code(checkRepeatedPrepayOrder(&order, &changed)))
+	order.Items = []*dao.OrderItemModel{{SkuId: "sku-1", Name: "item", UnitPrice: 100, Quantity: 1}}
+	assert.Nil(t, checkRepeatedPrepayOrder(&order, &changed))
+	order.Items = nil
+
+	// 元数据不一致, 未携带元数据与空元数据视为一致
+	changed = *expected
+	changed.Metadata = map[string]string{"biz_order_id": "1"}
+	assert.Equal(t, codes.AlreadyExists, status.Code(checkRepeatedPrepayOrder(&order, &changed)))
+	changed.Metadata = map[string]string{}
+	assert.Nil(t, checkRepeatedPrepayOrder(&order, &changed))
+
+	// 已关闭或已过期的平台订单不能再发起支付
+	order.State = dao.OrderStateClosed
+	assert.Equal(t, codes.FailedPrecondition, status.Code(checkRepeatedPrepayOrder(&order, expected)))
+	order.State = dao.OrderStatePrepaid
+	order.ExpireTime = time.Now().Add(-time.Minute).Unix()
+	assert.Equal(t, codes.FailedPrecondition, status.Code(checkRepeatedPrepayOrder(&order, expected)))
+}
+
+func TestCheckRefreshablePrepayOrder(t *testing.T) {
+	now := time.Now()
+	order := &dao.PlatformOrderModel{
+		TradeId:    "trade-id",
+		TradeType:  dao.TradeTypeJSAPI,
+		PrepayId:   "wx201410272009395522657a690389285100",
+		PrepayTime: now.Add(-time.Hour).Unix(),
+		State:      dao.OrderStatePrepaid,
+		ExpireTime: now.Add(time.Minute).Unix(),
+	}
+	assert.Nil(t, checkRefreshablePrepayOrder(order))
+
+	// 预支付订单标识超过2小时后失效
+	order.PrepayTime = now.Add(-WxPrepayIdValidity).Unix()
+	assert.False(t, isWxPrepayIdValid(order, now))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(checkRefreshablePrepayOrder(order)))
+	// 早期保存的平台订单没有记录预支付订单的创建时间
+	order.PrepayTime = 0
+	assert.False(t, isWxPrepayIdValid(order, now))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(checkRefreshablePrepayOrder(order)))
+	order.PrepayTime = now.Add(-time.Hour).Unix()
+
+	// 已支付的平台订单不能再刷新支付信息
+	order.State = dao.OrderStatePaid
+	assert.Equal(t, codes.FailedPrecondition, status.Code(checkRefreshablePrepayOrder(order)))
+}
+
+func TestValidateOrderItems(t *testing.T) {
+	items := []*dao.OrderItemModel{
+		{SkuId: "sku-1", Name: "item-1", UnitPrice: 100, Quantity: 2},
+		{SkuId: "sku_2", Name: "item-2", UnitPrice: 50, Quantity: 1, WechatpayGoodsId: "1001"},
+	}
+	assert.Nil(t, ValidateOrderItems(items, 250))
+	assert.Equal(t, ErrOrderItemsTotalMismatch, ValidateOrderItems(items, 251))
+	assert.Equal(t, ErrOrderItemsTotalMismatch, ValidateOrderItems(items, 200))
+
+	// 非法商品编码/数量
+	items[0].SkuId = "sku 1"
+	assert.Equal(t, ErrInvalidOrderItem, ValidateOrderItems(items, 250))
+	items[0].SkuId, items[0].Quantity = "sku-1", 0
+	assert.Equal(t, ErrInvalidOrderItem, ValidateOrderItems(items, 250))
+
+	// 单价与数量之积溢出
+	items = []*dao.OrderItemModel{{SkuId: "sku-1", Name: "item-1", UnitPrice: math.MaxInt64, Quantity: 2}}
+	assert.Equal(t, ErrOrderItemsTotalMismatch, ValidateOrderItems(items, 100))
+}
+
+func TestEncodeAndDecodeAttach(t *testing.T) {
+	attach, err := EncodeAttach(map[string]string{"order": "A&B=1", "channel": "mini"})
+	assert.Nil(t, err)
+	assert.Equal(t, "channel=mini&order=A%26B%3D1", attach)
+	assert.Equal(t, map[string]string{"order": "A&B=1", "channel": "mini"}, DecodeAttach(attach))
+
+	attach, err = EncodeAttach(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "", attach)
+	assert.Nil(t, DecodeAttach(""))
+
+	_, err = EncodeAttach(map[string]string{"": "v"})
+	assert.Equal(t, ErrEmptyMetaKey, err)
+	_, err = EncodeAttach(map[string]string{"k": strings.Repeat("v", AttachMaxLength)})
+	assert.Equal(t, ErrAttachTooLong, err)
+}
+
+func TestToQueryWxPaymentStatusResponse(t *testing.T) {
+	order := &dao.PlatformOrderModel{
+		TradeId:       "S1234567890123456789ABCDEF",
+		State:         dao.OrderStatePaid,
+		Status:        dao.PaymentStatusAckAsyncNotification,
+		TransactionId: "4200000000000000000000000000",
+	}
+	resp := toQueryWxPaymentStatusResponse(order)
+	assert.NotNil(t, resp)
+	assert.Equal(t, TradeStateSuccess, resp.TradeState)
+	assert.True(t, resp.FromLocal)
+
+	// 早期订单记录缺失微信支付订单号, 需要请求微信支付
+	order.TransactionId = ""
+	assert.Nil(t, toQueryWxPaymentStatusResponse(order))
+
+	order.State, order.Status = dao.OrderStateClosed, dao.PaymentStatusClosePlatformOrder
+	assert.Equal(t, TradeStateClosed, toQueryWxPaymentStatusResponse(order).TradeState)
+
+	// 支付结果未确定
+	order.State, order.Status = dao.OrderStatePrepaid, dao.PaymentStatusGetPrepayId
+	assert.False(t, order.IsPaymentSettled())
+	assert.Nil(t, toQueryWxPaymentStatusResponse(order))
+}
+
+func TestRetryWxCall(t *testing.T) {
+	_logger := logrus.NewEntry(logrus.New())
+	systemErr := &core.APIError{StatusCode: http.StatusInternalServerError, Code: "SYSTEM_ERROR"}
+	assert.True(t, IsWxRetryableError(systemErr, WxRetryableCodes))
+	assert.False(t, IsWxRetryableError(systemErr, WxOrderRetryableCodes))
+
+	// 不在可重试错误码中的错误不重试
+	calls := 0
+	err := retryWxCall(context.Background(), _logger, "Test", WxOrderRetryableCodes, func() error {
+		calls += 1
+		return systemErr
+	})
+	assert.Equal(t, systemErr, err)
+	assert.Equal(t, 1, calls)
+
+	// 上下文结束后不再等待重试
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	begin := time.Now()
+	err = retryWxCall(ctx, _logger, "Test", WxRetryableCodes, func() error {
+		calls += 1
+		return systemErr
+	})
+	assert.Equal(t, systemErr, err)
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(begin), time.Second)
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"event_id":"e1"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000\n" + string(body) + "\n"))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), SignWebhookPayload("secret", 1700000000, body))
+	assert.NotEqual(t, SignWebhookPayload("secret", 1700000000, body), SignWebhookPayload("secret", 1700000001, body))
+
+	assert.Equal(t, 10*time.Second, WebhookBackoff(1, 10*time.Second, time.Hour))
+	assert.Equal(t, 80*time.Second, WebhookBackoff(4, 10*time.Second, time.Hour))
+	assert.Equal(t, time.Hour, WebhookBackoff(20, 10*time.Second, time.Hour))
+}
+
+func TestNewReplayPaymentEvent(t *testing.T) {
+	event := &dao.OutboxEventModel{
+		EventId:         "5f0c6e0a-3d4b-4c4e-9a57-2f7a1b0c9d11",
+		EventType:       dao.OutboxEventTypePaymentSucceeded,
+		AppId:           "wx0000000000000000",
+		TradeId:         "S1234567890123456789ABCDEF",
+		TradeType:       dao.TradeTypeJSAPI,
+		TransactionId:   "4200000000000000000000000000",
+		ItemAmountTotal: 100,
+		State:           dao.OrderStatePaid,
+	}
+	notification := &dao.PaymentNotificationModel{
+		ResourceTransactionId:    "4200000000000000000000000000",
+		ResourceTradeType:        dao.TradeTypeJSAPI,
+		ResourceAmountPayerTotal: 90,
+		ResourceSuccessTime:      "2024-01-01T00:00:00+08:00",
+	}
+	paymentEvent := newReplayPaymentEvent(event, notification)
+	assert.True(t, paymentEvent.Replay)
+	assert.Equal(t, event.EventId, paymentEvent.EventId)
+	assert.Equal(t, int64(90), paymentEvent.PayerTotal)
+	assert.Equal(t, "2024-01-01T00:00:00+08:00", paymentEvent.SuccessTime)
+
+	// 早期订单缺失支付通知时只使用支付事件中的支付结果
+	paymentEvent = newReplayPaymentEvent(event, nil)
+	assert.Equal(t, event.TransactionId, paymentEvent.TrxId)
+	assert.Equal(t, int64(0), paymentEvent.PayerTotal)
+
+	// 退款成功事件同样可以重放, 不使用支付通知改写
+	event.EventType = dao.OutboxEventTypeRefundSucceeded
+	event.State = dao.OrderStateRefunded
+	event.RefundedAmountTotal = 100
+	paymentEvent = newReplayPaymentEvent(event, notification)
+	assert.Equal(t, dao.OutboxEventTypeRefundSucceeded, paymentEvent.EventType)
+	assert.Equal(t, int64(100), paymentEvent.RefundedAmountTotal)
+	assert.Equal(t, "", paymentEvent.SuccessTime)
+	assert.True(t, paymentEvent.Replay)
+}
+
+func TestNewFallbackOutboxEvents(t *testing.T) {
+	// 早期保存的平台订单没有业务状态字段, 由处理步骤推导
+	order := &dao.PlatformOrderModel{
+		AppId:               "wx0000000000000000",
+		TradeId:             "S1234567890123456789ABCDEF",
+		ItemAmountTotal:     100,
+		RefundedAmountTotal: 40,
+		Status:              dao.PaymentStatusRefundSuccess,
+	}
+	events := newFallbackOutboxEvents(order)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, dao.OutboxEventTypePaymentSucceeded, events[0].EventType)
+	assert.Equal(t, dao.OrderStatePaid, events[0].State)
+	assert.Equal(t, dao.OutboxEventTypeRefundSucceeded, events[1].EventType)
+	assert.Equal(t, int64(40), events[1].RefundedAmountTotal)
+	// 多次重放时支付事件ID保持不变
+	assert.Equal(t, events[0].EventId, newFallbackOutboxEvents(order)[0].EventId)
+	assert.NotEqual(t, events[0].EventId, events[1].EventId)
+
+	// 所有重放目标使用相同的支付事件, Webhook请求体同样补齐支付结果
+	notification := &dao.PaymentNotificationModel{
+		ResourceTransactionId:    "4200000000000000000000000000",
+		ResourceAmountPayerTotal: 90,
+		ResourceSuccessTime:      "2024-01-01T00:00:00+08:00",
+	}
+	payload := newReplayWebhookPayload(events[0], newReplayPaymentEvent(events[0], notification))
+	assert.Equal(t, "4200000000000000000000000000", payload.TransactionId)
+	assert.Equal(t, int64(90), payload.PayerTotal)
+	assert.Equal(t, "2024-01-01T00:00:00+08:00", payload.SuccessTime)
+	assert.True(t, payload.Replay)
+
+	order.Status = dao.PaymentStatusClosePlatformOrder
+	events = newFallbackOutboxEvents(order)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, dao.OutboxEventTypePaymentClosed, events[0].EventType)
+
+	// 尚未支付的平台订单没有支付事件
+	order.State = dao.OrderStatePrepaid
+	assert.Nil(t, newFallbackOutboxEvents(order))
+}
+
+func TestFulfillmentHelpers(t *testing.T) {
+	impl := &WechatPaymentCallbackServiceImpl{
+		confFulfillmentOverduePolicyTable: map[string]string{"wx0000000000000001": dao.FulfillmentOverduePolicyRefund},
+	}
+	assert.Equal(t, dao.FulfillmentOverduePolicyRefund, impl.fulfillmentOverduePolicyOf("wx0000000000000001"))
+	assert.Equal(t, dao.FulfillmentOverduePolicyAlert, impl.fulfillmentOverduePolicyOf("wx0000000000000002"))
+
+	assert.Equal(t, "S1234567890123456789ABCDEFRF", MakeFulfillmentOutRefundNo("S1234567890123456789ABCDEF"))
+
+	// 自动退款的金额扣除已成功及处理中的退款, 失败或关闭的退款不扣除
+	order := &dao.PlatformOrderModel{TradeId: "S1234567890123456789ABCDEF", ItemAmountTotal: 100, RefundedAmountTotal: 30}
+	refunds := []*dao.RefundModel{
+		{OutRefundNo: "R1", AmountRefund: 30, Status: dao.RefundStatusSuccess},
+		{OutRefundNo: "R2", AmountRefund: 20, Status: dao.RefundStatusProcessing},
+		{OutRefundNo: "R3", AmountRefund: 10, Status: dao.RefundStatusClosed},
+		{OutRefundNo: "R4", AmountRefund: 10, Status: dao.RefundStatusCreateFailed},
+	}
+	outRefundNo := MakeFulfillmentOutRefundNo(order.TradeId)
+	assert.Equal(t, int64(50), fulfillmentRefundAmount(order, refunds, outRefundNo))
+	assert.Equal(t, int64(100), fulfillmentRefundAmount(order, nil, outRefundNo))
+	// 重试时沿用原退款单的金额
+	refunds = append(refunds, &dao.RefundModel{OutRefundNo: outRefundNo, AmountRefund: 70, Status: dao.RefundStatusCreated})
+	assert.Equal(t, int64(70), fulfillmentRefundAmount(order, refunds, outRefundNo))
+	// 剩余金额均在退款中
+	refunds = []*dao.RefundModel{{OutRefundNo: "R1", AmountRefund: 100, Status: dao.RefundStatusProcessing}}
+	assert.Equal(t, int64(0), fulfillmentRefundAmount(order, refunds, outRefundNo))
+
+	assert.True(t, isRetryableRefundError(refundError(codes.Unavailable, "WX_CREATE_REFUND_UNKNOWN", "S1234567890123456789ABCDEFRF")))
+	assert.True(t, isRetryableRefundError(status.Error(codes.Internal, "Failed to create refund.")))
+	assert.False(t, isRetryableRefundError(refundError(codes.Internal, "WX_CREATE_REFUND_ERROR", "S1234567890123456789ABCDEFRF")))
+	assert.False(t, isRetryableRefundError(status.Error(codes.FailedPrecondition, "Refund amount exceeds the refundable amount.")))
 }
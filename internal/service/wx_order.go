@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wechatpay-apiv3/wechatpay-go/core"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments"
+	"github.com/wechatpay-apiv3/wechatpay-go/services/payments/jsapi"
+
+	"github.com/amazingchow/wechat-payment-callback-service/internal/service/common"
+)
+
+var (
+	ErrWxOrderNotExist = errors.New("wechatpay: order not exist")
+)
+
+const (
+	WxErrorCodeOrderNotExist = "ORDER_NOT_EXIST"
+	WxCallMaxRetries         = 3
+)
+
+// 可以使用相同参数重新调用的微信支付接口错误码(系统错误/银行系统异常/频率超限), 不同接口的错误码写法不同.
+var (
+	// 下单/申请退款/查询退款接口
+	WxRetryableCodes = []string{"SYSTEM_ERROR", "BANK_ERROR", "FREQUENCY_LIMITED"}
+	// 查询订单/关闭订单接口
+	WxOrderRetryableCodes = []string{"SYSTEMERROR", "BANKERROR", "FREQUENCY_LIMITED"}
+)
+
+// withWxErrorCode 将微信支付接口返回的错误码附加到上下文中, 随平台订单状态迁移一并记录.
+func withWxErrorCode(ctx context.Context, err error) context.Context {
+	if err == ErrWxOrderNotExist {
+		return common.NewContextWithWxErrorCode(ctx, WxErrorCodeOrderNotExist)
+	}
+	var apiErr *core.APIError
+	if errors.As(err, &apiErr) && len(apiErr.Code) > 0 {
+		return common.NewContextWithWxErrorCode(ctx, apiErr.Code)
+	}
+	return ctx
+}
+
+// queryWxOrder 按平台订单交易ID查询微信支付订单, 系统错误/银行系统异常/频率超限时至多重试三次.
+// NOTE: 查询订单接口与下单渠道无关, Native/H5/APP下单的订单同样使用JSAPI服务查询.
+func (impl *WechatPaymentCallbackServiceImpl) queryWxOrder(
+	ctx context.Context, _logger *logrus.Entry, tradeId string) (
+	transaction *payments.Transaction, err error) {
+
+	err = retryWxCall(ctx, _logger, "JsapiApiService.QueryOrderByOutTradeNo", WxOrderRetryableCodes, func() (err error) {
+		transaction, _, err = impl.svc.QueryOrderByOutTradeNo(
+			ctx,
+			jsapi.QueryOrderByOutTradeNoRequest{
+				OutTradeNo: core.String(tradeId),
+				Mchid:      core.String(impl.confMerchantId),
+			},
+		)
+		return
+	})
+	err = wxOrderNotExistError(err)
+	return
+}
+
+// queryWxOrderById 按微信支付订单号查询微信支付订单, 系统错误/银行系统异常/频率超限时至多重试三次.
+func (impl *WechatPaymentCallbackServiceImpl) queryWxOrderById(
+	ctx context.Context, _logger *logrus.Entry, transactionId string) (
+	transaction *payments.Transaction, err error) {
+
+	err = retryWxCall(ctx, _logger, "JsapiApiService.QueryOrderById", WxOrderRetryableCodes, func() (err error) {
+		transaction, _, err = impl.svc.QueryOrderById(
+			ctx,
+			jsapi.QueryOrderByIdRequest{
+				TransactionId: core.String(transactionId),
+				Mchid:         core.String(impl.confMerchantId),
+			},
+		)
+		return
+	})
+	err = wxOrderNotExistError(err)
+	return
+}
+
+// wxOrderNotExistError 将查询订单接口返回的订单不存在错误转换为 ErrWxOrderNotExist.
+func wxOrderNotExistError(err error) error {
+	if core.IsAPIError(err, "ORDER_NOT_EXIST") || core.IsAPIError(err, "ORDERNOTEXIST") {
+		return ErrWxOrderNotExist
+	}
+	return err
+}
+
+// retryWxCall 调用微信支付接口, 返回 retryableCodes 中的错误码时使用相同参数至多重新调用 WxCallMaxRetries 次.
+// 等待重试期间 ctx 结束时不再重试, 返回最后一次调用的错误.
+func retryWxCall(ctx context.Context, _logger *logrus.Entry, method string, retryableCodes []string, call func() error) (
+	err error) {
+
+	for retries := 0; ; retries++ {
+		if err = call(); err == nil {
+			return
+		}
+		_logger.WithError(err).Errorf("Failed to invoke %s.", method)
+		if retries >= WxCallMaxRetries || !IsWxRetryableError(err, retryableCodes) {
+			return
+		}
+		// NOTE: 重试间隔时间可以根据实际情况调整
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Duration(retries+1) * time.Second):
+		}
+	}
+}
+
+// IsWxRetryableError 返回微信支付接口的错误码是否在 retryableCodes 中, 这些错误可以使用相同参数重新调用.
+func IsWxRetryableError(err error, retryableCodes []string) bool {
+	for _, code := range retryableCodes {
+		if core.IsAPIError(err, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWxAPIError 返回错误是否为微信支付接口返回的错误, 其他错误(如网络错误)无法确定请求是否已被受理.
+func IsWxAPIError(err error) bool {
+	var apiErr *core.APIError
+	return errors.As(err, &apiErr)
+}
+
+// closeWxOrder 按平台订单交易ID关闭微信支付订单, 订单不存在/订单已关闭视为关闭成功.
+// NOTE: 关单接口与下单渠道无关, Native/H5/APP下单的订单同样使用JSAPI服务关单.
+func (impl *WechatPaymentCallbackServiceImpl) closeWxOrder(
+	ctx context.Context, _logger *logrus.Entry, tradeId string) (err error) {
+
+	err = retryWxCall(ctx, _logger, "JsapiApiService.CloseOrder", WxOrderRetryableCodes, func() (err error) {
+		_, err = impl.svc.CloseOrder(
+			ctx,
+			jsapi.CloseOrderRequest{
+				OutTradeNo: core.String(tradeId),
+				Mchid:      core.String(impl.confMerchantId),
+			},
+		)
+		return
+	})
+	if core.IsAPIError(err, "ORDERNOTEXIST") ||
+		core.IsAPIError(err, "ORDER_CLOSED") ||
+		core.IsAPIError(err, "MCH_NOT_EXISTS") {
+		// 处理订单不存在/订单已关闭/商户号不存在
+		err = nil
+	}
+	return
+}